@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/spf13/cobra"
+)
+
+// The accounts read per query. Paging keeps no query open while the password
+// hashes are read.
+const exportPageSize = 1000
+
+func exportTimeOf(flagName string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--%s must be in RFC 3339, such as 2026-01-02T15:04:05Z", flagName)
+	}
+	return t, nil
+}
+
+func newExportCommand(configFilePath *string) *cobra.Command {
+	var (
+		filePath               string
+		formatName             string
+		columns                []string
+		roleName               string
+		statusName             string
+		createdAfter           string
+		createdBefore          string
+		usernamePrefix         string
+		isPIIMasked            bool
+		isPasswordHashIncluded bool
+	)
+
+	command := &cobra.Command{
+		Use:   "export",
+		Short: "Writes the accounts to a CSV or JSONL file.",
+		Long: "Writes the accounts that are not deleted to a CSV or JSONL file, in id order. " +
+			"Password hashes are left out unless --include-password-hashes is passed.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := accountFileFormatOf(formatName, filePath)
+			if err != nil {
+				return err
+			}
+
+			query := database.AccountListQuery{
+				UsernamePrefix: usernamePrefix,
+				Limit:          exportPageSize,
+			}
+			if roleName != "" {
+				role, ok := logic.RoleNamed(strings.ToLower(roleName))
+				if !ok {
+					return fmt.Errorf("unknown role %q", roleName)
+				}
+				roleId := uint8(role)
+				query.RoleId = &roleId
+			}
+			if statusName != "" {
+				accountStatus, ok := logic.AccountStatusNamed(strings.ToLower(statusName))
+				if !ok {
+					return fmt.Errorf("unknown status %q", statusName)
+				}
+				statusId := uint8(accountStatus)
+				query.StatusId = &statusId
+			}
+			if query.CreatedAfter, err = exportTimeOf("created-after", createdAfter); err != nil {
+				return err
+			}
+			if query.CreatedBefore, err = exportTimeOf("created-before", createdBefore); err != nil {
+				return err
+			}
+
+			options := logic.AccountExportOptions{
+				Columns:               columns,
+				MaskPII:               isPIIMasked,
+				IncludePasswordHashes: isPasswordHashIncluded,
+			}
+			// The columns are checked before the file is opened, so a typo does
+			// not truncate an earlier export.
+			if _, err := logic.NewAccountExportWriter(io.Discard, format, options); err != nil {
+				return err
+			}
+
+			svc, cleanup, err := initService(*configFilePath)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			// Opened once the service is up, so a bad config or an unreachable
+			// database leaves an earlier export as it is. The file may hold
+			// password hashes, so only the owner can read it.
+			var output io.Writer = os.Stdout
+			if filePath != "-" {
+				file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				output = file
+			}
+			writer, err := logic.NewAccountExportWriter(output, format, options)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			exportedCount := 0
+			for {
+				accs, err := svc.accountAccessor.ListAccounts(ctx, query)
+				if err != nil {
+					return err
+				}
+				for _, acc := range accs {
+					exported := logic.ExportedAccount{
+						AccountId: acc.Id,
+						AccountInfo: logic.AccountInfo{
+							Username:    acc.Username,
+							Fullname:    acc.Fullname,
+							Email:       acc.Email,
+							PhoneNumber: acc.PhoneNumber,
+							Role:        logic.Role(acc.RoleId),
+							Status:      logic.AccountStatus(acc.StatusId),
+						},
+						CreatedAt: acc.CreatedAt,
+					}
+					if isPasswordHashIncluded {
+						password, err := svc.accountPasswordAccessor.GetAccountPassword(ctx, acc.Id)
+						if err != nil {
+							return fmt.Errorf("failed to get the password of account %d: %w", acc.Id, err)
+						}
+						exported.HashedPassword = password.HashedString
+					}
+					if err := writer.Write(exported); err != nil {
+						return err
+					}
+				}
+				exportedCount += len(accs)
+				if len(accs) < exportPageSize {
+					break
+				}
+				query.AfterId = accs[len(accs)-1].Id
+			}
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "exported: %d\n", exportedCount)
+			return nil
+		},
+	}
+
+	command.Flags().StringVarP(&filePath, "file", "f", "-",
+		"Write the accounts to the file, or to the standard output for -.")
+	command.Flags().StringVar(&formatName, "format", "",
+		"The format of the file, csv or jsonl. Guessed from the file extension when left out.")
+	command.Flags().StringSliceVar(&columns, "columns", nil,
+		"The columns written, in order, out of id, username, fullname, email, phone_number, role, "+
+			"status, created_at and hashed_password. All but hashed_password when left out.")
+	command.Flags().StringVar(&roleName, "role", "",
+		"Only export the accounts of the role, admin or member.")
+	command.Flags().StringVar(&statusName, "status", "",
+		"Only export the accounts of the status, such as active or suspended.")
+	command.Flags().StringVar(&createdAfter, "created-after", "",
+		"Only export the accounts created after the time, in RFC 3339.")
+	command.Flags().StringVar(&createdBefore, "created-before", "",
+		"Only export the accounts created before the time, in RFC 3339.")
+	command.Flags().StringVar(&usernamePrefix, "username-prefix", "",
+		"Only export the accounts whose username starts with the prefix.")
+	command.Flags().BoolVar(&isPIIMasked, "mask-pii", false,
+		"Mask the fullname, the email and the phone number.")
+	command.Flags().BoolVar(&isPasswordHashIncluded, "include-password-hashes", false,
+		"Allow the hashed_password column, and add it to the default columns.")
+
+	return command
+}
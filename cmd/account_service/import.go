@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/spf13/cobra"
+)
+
+// Reads the format from the flag, or else from the extension of the file.
+func accountFileFormatOf(formatName string, filePath string) (logic.AccountFileFormat, error) {
+	if formatName == "" {
+		formatName = strings.TrimPrefix(filepath.Ext(filePath), ".")
+	}
+	switch strings.ToLower(formatName) {
+	case "csv":
+		return logic.AccountFileFormatCSV, nil
+	case "jsonl", "ndjson":
+		return logic.AccountFileFormatJSONL, nil
+	default:
+		return logic.AccountFileFormatNone, fmt.Errorf("unknown file format %q, use csv or jsonl", formatName)
+	}
+}
+
+// A line of the report, one per row.
+type importReportLine struct {
+	Row       int    `json:"row"`
+	Username  string `json:"username"`
+	Outcome   string `json:"outcome"`
+	AccountId uint64 `json:"account_id,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+func newImportCommand(configFilePath *string) *cobra.Command {
+	var (
+		filePath   string
+		formatName string
+		reportPath string
+		isDryRun   bool
+		batchSize  int
+	)
+
+	command := &cobra.Command{
+		Use:   "import",
+		Short: "Creates accounts from a CSV or JSONL file.",
+		Long: "Creates accounts from a CSV or JSONL file, whose rows hold the account info and " +
+			"either a password or a hashed password. Writes one JSON line per row telling how it went.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := accountFileFormatOf(formatName, filePath)
+			if err != nil {
+				return err
+			}
+
+			var input io.Reader = os.Stdin
+			if filePath != "-" {
+				file, err := os.Open(filePath)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				input = file
+			}
+
+			svc, cleanup, err := initService(*configFilePath)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			// Opened once the service is up, so a bad config leaves an earlier
+			// report as it is. The report names the accounts, so only the owner
+			// can read it.
+			var report io.Writer = os.Stdout
+			if reportPath != "" {
+				file, err := os.OpenFile(reportPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				report = file
+			}
+
+			rows, err := logic.NewAccountImportReader(input, format)
+			if err != nil {
+				return err
+			}
+			output, err := svc.accountLogic.ImportAccounts(context.Background(),
+				logic.ImportAccountsParams{
+					Rows:      rows,
+					DryRun:    isDryRun,
+					BatchSize: batchSize,
+				})
+			if err != nil {
+				return err
+			}
+
+			encoder := json.NewEncoder(report)
+			for _, result := range output.Results {
+				err := encoder.Encode(importReportLine{
+					Row:       result.Row,
+					Username:  result.Username,
+					Outcome:   result.Outcome.String(),
+					AccountId: result.AccountId,
+					Message:   result.Message,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "created: %d, valid: %d, duplicate: %d, invalid: %d, failed: %d\n",
+				output.Counts[logic.ImportAccountsOutcomeCreated],
+				output.Counts[logic.ImportAccountsOutcomeValid],
+				output.Counts[logic.ImportAccountsOutcomeDuplicate],
+				output.Counts[logic.ImportAccountsOutcomeInvalid],
+				output.Counts[logic.ImportAccountsOutcomeFailed])
+
+			notImported := output.Counts[logic.ImportAccountsOutcomeDuplicate] +
+				output.Counts[logic.ImportAccountsOutcomeInvalid] +
+				output.Counts[logic.ImportAccountsOutcomeFailed]
+			if notImported > 0 {
+				// The rows are told in the report, so this is no misuse of the
+				// command and no crash either.
+				fmt.Fprintf(cmd.ErrOrStderr(), "%d rows cannot be imported, see the report\n", notImported)
+				cmd.SilenceUsage = true
+				cmd.SilenceErrors = true
+				return errAlreadyReported
+			}
+			return nil
+		},
+	}
+
+	command.Flags().StringVarP(&filePath, "file", "f", "-",
+		"Read the rows from the file, or from the standard input for -.")
+	command.Flags().StringVar(&formatName, "format", "",
+		"The format of the rows, csv or jsonl. Guessed from the file extension when left out.")
+	command.Flags().StringVar(&reportPath, "report", "",
+		"Write the report to the file instead of the standard output.")
+	command.Flags().BoolVar(&isDryRun, "dry-run", false,
+		"Validate the rows without creating any account.")
+	command.Flags().IntVar(&batchSize, "batch-size", 0,
+		"The most rows created in one transaction. Zero uses the default.")
+
+	return command
+}
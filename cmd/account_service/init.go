@@ -4,12 +4,34 @@ import (
 	"github.com/Fiagram/account_service/internal/app"
 	"github.com/Fiagram/account_service/internal/configs"
 	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/dataaccess/notifier"
 	"github.com/Fiagram/account_service/internal/handler/grpc"
 	"github.com/Fiagram/account_service/internal/logic"
 	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
 )
 
-func InitStandaloneServer(configFilePath string) (app.StandaloneServer, func(), error) {
+// The pieces shared by the standalone server and the subcommands.
+type service struct {
+	config configs.Config
+	logger *zap.Logger
+
+	accountAccessor         database.AccountAccessor
+	accountPasswordAccessor database.AccountPasswordAccessor
+
+	accountLogic           logic.Account
+	refreshTokenLogic      logic.RefreshToken
+	accessTokenLogic       logic.AccessToken
+	authLogic              logic.Auth
+	loginThrottleLogic     logic.LoginThrottle
+	emailVerificationLogic logic.EmailVerification
+	phoneVerificationLogic logic.PhoneVerification
+	totpLogic              logic.TOTP
+	recoveryCodeLogic      logic.RecoveryCode
+	webAuthnLogic          logic.WebAuthn
+}
+
+func initService(configFilePath string) (*service, func(), error) {
 	config, err := configs.NewConfig(configFilePath)
 	if err != nil {
 		return nil, nil, err
@@ -20,7 +42,7 @@ func InitStandaloneServer(configFilePath string) (app.StandaloneServer, func(),
 		return nil, nil, err
 	}
 
-	db, dbCleanup, err := database.InitAndMigrateUpDatabase(config.Database, logger)
+	db, dbCleanup, err := database.InitAndCheckDatabase(config.Database, logger)
 	if err != nil {
 		loggerCleanup()
 		return nil, nil, err
@@ -28,17 +50,132 @@ func InitStandaloneServer(configFilePath string) (app.StandaloneServer, func(),
 
 	aAsor := database.NewAccountAccessor(db, logger)
 	apAsor := database.NewAccountPasswordAccessor(db, logger)
-	hashLogic := logic.NewHash(config.Auth.Hash)
-	accountLogic := logic.NewAccount(db, aAsor, apAsor, hashLogic, logger)
-
-	accountHandler := grpc.NewHandler(accountLogic)
-	grpcServer := grpc.NewServer(config.Grpc, accountHandler, logger)
-
-	standaloneServer := app.NewStandaloneServer(grpcServer, logger)
+	aphAsor := database.NewAccountPasswordHistoryAccessor(db, logger)
+	rtAsor := database.NewRefreshTokenAccessor(db, logger)
+	lfAsor := database.NewLoginFailureAccessor(db, logger)
+	prtAsor := database.NewPasswordResetTokenAccessor(db, logger)
+	vcAsor := database.NewVerificationCodeAccessor(db, logger)
+	totpAsor := database.NewAccountTOTPAccessor(db, logger)
+	lcAsor := database.NewLoginChallengeAccessor(db, logger)
+	rcAsor := database.NewRecoveryCodeAccessor(db, logger)
+	wcAsor := database.NewWebAuthnCredentialAccessor(db, logger)
+	wsAsor := database.NewWebAuthnSessionAccessor(db, logger)
+	ascAsor := database.NewAccountStatusChangeAccessor(db, logger)
+	accountNotifier, err := notifier.NewNotifier(config.Notifier, logger)
+	if err != nil {
+		dbCleanup()
+		loggerCleanup()
+		return nil, nil, err
+	}
+	smsSender, err := notifier.NewSMSSender(config.SMSSender, logger)
+	if err != nil {
+		dbCleanup()
+		loggerCleanup()
+		return nil, nil, err
+	}
+	hashLogic, err := logic.NewHash(config.Auth.Hash)
+	if err != nil {
+		dbCleanup()
+		loggerCleanup()
+		return nil, nil, err
+	}
+	loginThrottleLogic := logic.NewLoginThrottle(lfAsor, config.Auth.Lockout, logger)
+	passwordPolicyLogic, err := logic.NewPasswordPolicy(config.Auth.PasswordPolicy, hashLogic, logger)
+	if err != nil {
+		dbCleanup()
+		loggerCleanup()
+		return nil, nil, err
+	}
+	totpLogic, err := logic.NewTOTP(db, aAsor, totpAsor, rcAsor, config.Auth.TOTP, logger)
+	if err != nil {
+		dbCleanup()
+		loggerCleanup()
+		return nil, nil, err
+	}
+	recoveryCodeLogic := logic.NewRecoveryCode(db, rcAsor, totpLogic, hashLogic, config.Auth.SecondFactor, logger)
+	secondFactorLogic := logic.NewSecondFactor(aAsor, lcAsor, totpLogic, recoveryCodeLogic, loginThrottleLogic,
+		config.Auth.SecondFactor, logger)
+	webAuthnLogic, err := logic.NewWebAuthn(db, aAsor, wcAsor, wsAsor, loginThrottleLogic, config.Auth.WebAuthn, logger)
+	if err != nil {
+		dbCleanup()
+		loggerCleanup()
+		return nil, nil, err
+	}
+	accountLogic := logic.NewAccount(logic.AccountDependencies{
+		DB:                             db,
+		AccountAccessor:                aAsor,
+		AccountPasswordAccessor:        apAsor,
+		AccountPasswordHistoryAccessor: aphAsor,
+		RefreshTokenAccessor:           rtAsor,
+		PasswordResetTokenAccessor:     prtAsor,
+		VerificationCodeAccessor:       vcAsor,
+		AccountTOTPAccessor:            totpAsor,
+		LoginChallengeAccessor:         lcAsor,
+		RecoveryCodeAccessor:           rcAsor,
+		WebAuthnCredentialAccessor:     wcAsor,
+		WebAuthnSessionAccessor:        wsAsor,
+		AccountStatusChangeAccessor:    ascAsor,
+		Notifier:                       accountNotifier,
+		HashLogic:                      hashLogic,
+		LoginThrottleLogic:             loginThrottleLogic,
+		PasswordPolicyLogic:            passwordPolicyLogic,
+		SecondFactorLogic:              secondFactorLogic,
+		PasswordHistoryConfig:          config.Auth.PasswordHistory,
+		PasswordResetConfig:            config.Auth.PasswordReset,
+		PhoneNumberConfig:              config.PhoneNumber,
+		AccountDeletionConfig:          config.AccountDeletion,
+		Logger:                         logger,
+	})
+	refreshTokenLogic := logic.NewRefreshToken(db, aAsor, rtAsor, config.Auth.RefreshToken, logger)
+	accessTokenLogic, err := logic.NewAccessToken(config.Auth.AccessToken, logger)
+	if err != nil {
+		dbCleanup()
+		loggerCleanup()
+		return nil, nil, err
+	}
+	authLogic := logic.NewAuth(accountLogic, accessTokenLogic, refreshTokenLogic,
+		secondFactorLogic, webAuthnLogic, logger)
+	emailVerificationLogic := logic.NewEmailVerification(db, aAsor, vcAsor, accountNotifier,
+		config.Auth.EmailVerification, logger)
+	phoneVerificationLogic := logic.NewPhoneVerification(db, aAsor, vcAsor, smsSender,
+		config.Auth.PhoneVerification, logger)
 
-	return standaloneServer,
+	return &service{
+			config:                  config,
+			logger:                  logger,
+			accountAccessor:         aAsor,
+			accountPasswordAccessor: apAsor,
+			accountLogic:            accountLogic,
+			refreshTokenLogic:       refreshTokenLogic,
+			accessTokenLogic:        accessTokenLogic,
+			authLogic:               authLogic,
+			loginThrottleLogic:      loginThrottleLogic,
+			emailVerificationLogic:  emailVerificationLogic,
+			phoneVerificationLogic:  phoneVerificationLogic,
+			totpLogic:               totpLogic,
+			recoveryCodeLogic:       recoveryCodeLogic,
+			webAuthnLogic:           webAuthnLogic,
+		},
 		func() {
 			dbCleanup()
 			loggerCleanup()
 		}, nil
 }
+
+func InitStandaloneServer(configFilePath string) (app.StandaloneServer, func(), error) {
+	svc, cleanup, err := initService(configFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accountHandler := grpc.NewHandler(svc.accountLogic, svc.refreshTokenLogic, svc.accessTokenLogic,
+		svc.authLogic, svc.loginThrottleLogic, svc.emailVerificationLogic, svc.phoneVerificationLogic,
+		svc.totpLogic, svc.recoveryCodeLogic, svc.webAuthnLogic)
+	grpcServer := grpc.NewServer(svc.config.Grpc, accountHandler, svc.logger)
+
+	accountPurger := app.NewAccountPurger(svc.accountLogic, svc.config.AccountDeletion, svc.logger)
+
+	standaloneServer := app.NewStandaloneServer(grpcServer, accountPurger, svc.logger)
+
+	return standaloneServer, cleanup, nil
+}
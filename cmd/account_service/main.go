@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/spf13/cobra"
 )
@@ -12,6 +14,10 @@ var (
 	commitHash string
 )
 
+// Returned by a command that already told on the standard error why it
+// failed, so it only needs to exit non-zero.
+var errAlreadyReported = errors.New("already reported")
+
 func main() {
 	var configFilePath string
 
@@ -30,11 +36,18 @@ func main() {
 		},
 	}
 
-	rootCommand.Flags().StringVarP(&configFilePath,
+	rootCommand.PersistentFlags().StringVarP(&configFilePath,
 		"config-file-path", "c", "",
 		"Use the provided config file, otherwise the default embedded config applied.")
 
+	rootCommand.AddCommand(newImportCommand(&configFilePath))
+	rootCommand.AddCommand(newExportCommand(&configFilePath))
+	rootCommand.AddCommand(newMigrateCommand(&configFilePath))
+
 	if err := rootCommand.Execute(); err != nil {
+		if errors.Is(err, errAlreadyReported) {
+			os.Exit(1)
+		}
 		log.Panic(err)
 	}
 }
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// Connects to the database without checking its schema, which the
+// subcommands are there to change.
+func initMigrator(configFilePath string) (database.Migration, func(), error) {
+	config, err := configs.NewConfig(configFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger, loggerCleanup, err := utils.InitializeLogger(config.Log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if configs.DatatabaseType(config.Database.Type) == configs.DatabaseTypeMemory {
+		loggerCleanup()
+		return nil, nil, database.ErrDatabaseTypeTestOnly
+	}
+
+	db, dbCleanup, err := database.InitDatabase(config.Database, logger)
+	if err != nil {
+		loggerCleanup()
+		return nil, nil, err
+	}
+
+	return database.NewMigrator(db, logger), func() {
+		dbCleanup()
+		loggerCleanup()
+	}, nil
+}
+
+func newMigrateCommand(configFilePath *string) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manages the migrations of the database schema.",
+		Long: "Manages the migrations of the database schema embedded in the binary. " +
+			"The server refuses to start while any of them is pending, unless database.auto_migrate is set.",
+	}
+
+	command.AddCommand(
+		newMigrateUpCommand(configFilePath),
+		newMigrateDownCommand(configFilePath),
+		newMigrateStatusCommand(configFilePath),
+		newMigrateRedoCommand(configFilePath),
+	)
+	return command
+}
+
+func newMigrateUpCommand(configFilePath *string) *cobra.Command {
+	var steps int
+
+	command := &cobra.Command{
+		Use:   "up",
+		Short: "Applies the pending migrations.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if steps < 0 {
+				return errors.New("--steps must not be negative")
+			}
+			migrator, cleanup, err := initMigrator(*configFilePath)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			applied, err := migrator.UpSteps(context.Background(), steps)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "applied %d migrations\n", applied)
+			return nil
+		},
+	}
+
+	command.Flags().IntVar(&steps, "steps", 0,
+		"The most migrations applied. Zero applies all the pending ones.")
+	return command
+}
+
+func newMigrateDownCommand(configFilePath *string) *cobra.Command {
+	var (
+		steps int
+		isAll bool
+	)
+
+	command := &cobra.Command{
+		Use:   "down",
+		Short: "Rolls back the latest migrations, one unless told otherwise.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if isAll && cmd.Flags().Changed("steps") {
+				return errors.New("--steps and --all cannot be used together")
+			}
+			if isAll {
+				steps = 0
+			} else if steps <= 0 {
+				return errors.New("--steps must be positive, use --all to roll back every migration")
+			}
+			migrator, cleanup, err := initMigrator(*configFilePath)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			rolledBack, err := migrator.DownSteps(context.Background(), steps)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "rolled back %d migrations\n", rolledBack)
+			return nil
+		},
+	}
+
+	command.Flags().IntVar(&steps, "steps", 1,
+		"The most migrations rolled back.")
+	command.Flags().BoolVar(&isAll, "all", false,
+		"Roll back every applied migration, dropping all the data.")
+	return command
+}
+
+func newMigrateStatusCommand(configFilePath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Lists the migrations and when they were applied.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			migrator, cleanup, err := initMigrator(*configFilePath)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			statuses, err := migrator.Status(context.Background())
+			if err != nil {
+				return err
+			}
+
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(writer, "MIGRATION\tAPPLIED AT")
+			pending := 0
+			for _, status := range statuses {
+				appliedAt := "pending"
+				if status.AppliedAt != nil {
+					appliedAt = status.AppliedAt.UTC().Format(time.RFC3339)
+				} else {
+					pending++
+				}
+				if status.IsUnknown {
+					appliedAt += " (unknown to this binary)"
+				}
+				fmt.Fprintf(writer, "%s\t%s\n", status.Id, appliedAt)
+			}
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%d pending\n", pending)
+			return nil
+		},
+	}
+}
+
+func newMigrateRedoCommand(configFilePath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "redo",
+		Short: "Rolls back the latest applied migration and applies it again.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			migrator, cleanup, err := initMigrator(*configFilePath)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			id, err := migrator.Redo(context.Background())
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "redid %s\n", id)
+			return nil
+		},
+	}
+}
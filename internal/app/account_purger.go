@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/logic"
+	"go.uber.org/zap"
+)
+
+// Purges, on a fixed interval, the deleted accounts whose retention period is
+// over.
+type AccountPurger interface {
+	// Blocks until the context is done.
+	Start(ctx context.Context)
+}
+
+type accountPurger struct {
+	accountLogic          logic.Account
+	accountDeletionConfig configs.AccountDeletion
+	logger                *zap.Logger
+}
+
+func NewAccountPurger(
+	accountLogic logic.Account,
+	accountDeletionConfig configs.AccountDeletion,
+	logger *zap.Logger,
+) AccountPurger {
+	return &accountPurger{
+		accountLogic:          accountLogic,
+		accountDeletionConfig: accountDeletionConfig,
+		logger:                logger,
+	}
+}
+
+func (p accountPurger) Start(ctx context.Context) {
+	if p.accountDeletionConfig.PurgeInterval <= 0 {
+		p.logger.Info("account purge is turned off")
+		return
+	}
+
+	ticker := time.NewTicker(p.accountDeletionConfig.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p accountPurger) purge(ctx context.Context) {
+	output, err := p.accountLogic.PurgeDeletedAccounts(ctx, logic.PurgeDeletedAccountsParams{})
+	if err != nil {
+		p.logger.With(zap.Error(err)).Error("failed to purge deleted accounts")
+		return
+	}
+	if len(output.PurgedAccountIds) > 0 {
+		p.logger.With(zap.Uint64s("account_ids", output.PurgedAccountIds)).
+			Info("purged deleted accounts")
+	}
+}
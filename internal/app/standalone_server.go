@@ -15,17 +15,20 @@ type StandaloneServer interface {
 }
 
 type standaloneServer struct {
-	grpcServer grpc.Server
-	logger     *zap.Logger
+	grpcServer    grpc.Server
+	accountPurger AccountPurger
+	logger        *zap.Logger
 }
 
 func NewStandaloneServer(
 	grpcServer grpc.Server,
+	accountPurger AccountPurger,
 	logger *zap.Logger,
 ) StandaloneServer {
 	return &standaloneServer{
-		grpcServer: grpcServer,
-		logger:     logger,
+		grpcServer:    grpcServer,
+		accountPurger: accountPurger,
+		logger:        logger,
 	}
 }
 
@@ -35,6 +38,10 @@ func (s standaloneServer) Start() error {
 		s.logger.With(zap.Error(err)).Info("grpc server stopped")
 	}()
 
+	purgerCtx, stopPurger := context.WithCancel(context.Background())
+	defer stopPurger()
+	go s.accountPurger.Start(purgerCtx)
+
 	utils.BlockUntilSignal(syscall.SIGINT, syscall.SIGTERM)
 	return nil
 }
@@ -0,0 +1,14 @@
+package configs
+
+import "time"
+
+type AccountDeletion struct {
+	// How long a deleted account can still be restored before it is purged.
+	RetentionPeriod time.Duration `yaml:"retention_period"`
+	// How often the purge job looks for accounts past the retention period.
+	// Zero turns the job off.
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+	// The most accounts read and purged per query. A run goes on batch after
+	// batch until it has tried every account past the retention period.
+	PurgeBatchSize int `yaml:"purge_batch_size"`
+}
@@ -1,9 +1,144 @@
 package configs
 
+import "time"
+
 type Auth struct {
-	Hash Hash `yaml:"hash"`
+	Hash              Hash             `yaml:"hash"`
+	RefreshToken      RefreshToken     `yaml:"refresh_token"`
+	AccessToken       AccessToken      `yaml:"access_token"`
+	Lockout           Lockout          `yaml:"lockout"`
+	PasswordPolicy    PasswordPolicy   `yaml:"password_policy"`
+	PasswordHistory   PasswordHistory  `yaml:"password_history"`
+	PasswordReset     PasswordReset    `yaml:"password_reset"`
+	EmailVerification VerificationCode `yaml:"email_verification"`
+	PhoneVerification VerificationCode `yaml:"phone_verification"`
+	TOTP              TOTP             `yaml:"totp"`
+	SecondFactor      SecondFactor     `yaml:"second_factor"`
+	WebAuthn          WebAuthn         `yaml:"webauthn"`
 }
 
+type HashAlgorithm string
+
+const (
+	HashAlgorithmBcrypt   HashAlgorithm = "bcrypt"
+	HashAlgorithmArgon2id HashAlgorithm = "argon2id"
+)
+
+// Algorithm selects how new passwords are hashed. Hashes stored with another
+// algorithm or other parameters stay verifiable and are upgraded on login.
 type Hash struct {
-	Cost int `yaml:"cost"`
+	Algorithm HashAlgorithm `yaml:"algorithm"`
+	Cost      int           `yaml:"cost"`
+	Argon2id  Argon2id      `yaml:"argon2id"`
+}
+
+type Argon2id struct {
+	Memory      uint32 `yaml:"memory"`
+	Iterations  uint32 `yaml:"iterations"`
+	Parallelism uint8  `yaml:"parallelism"`
+	SaltLength  uint32 `yaml:"salt_length"`
+	KeyLength   uint32 `yaml:"key_length"`
+}
+
+type RefreshToken struct {
+	TTL time.Duration `yaml:"ttl"`
+}
+
+type AccessToken struct {
+	Issuer   string        `yaml:"issuer"`
+	Audience string        `yaml:"audience"`
+	TTL      time.Duration `yaml:"ttl"`
+	// The first key signs new tokens, the others are only published through
+	// the JWKS so tokens signed before a key rotation stay verifiable.
+	// When no key is configured, an ephemeral key is generated on startup.
+	Keys []SigningKey `yaml:"keys"`
+}
+
+type SigningKey struct {
+	Id             string `yaml:"id"`
+	PrivateKeyFile string `yaml:"private_key_file"`
+}
+
+// Failed logins are tracked per username and per peer address, each scope with
+// its own thresholds.
+type Lockout struct {
+	Account LockoutPolicy `yaml:"account"`
+	Address LockoutPolicy `yaml:"address"`
+}
+
+// Once BackoffAfter failures are reached, every further attempt has to wait
+// BackoffBase doubled per extra failure, up to BackoffMax. Reaching LockAfter
+// failures locks the scope for LockDuration. Failures older than ResetAfter
+// are forgotten. A zero threshold disables that stage.
+type LockoutPolicy struct {
+	BackoffAfter uint32        `yaml:"backoff_after"`
+	BackoffBase  time.Duration `yaml:"backoff_base"`
+	BackoffMax   time.Duration `yaml:"backoff_max"`
+	LockAfter    uint32        `yaml:"lock_after"`
+	LockDuration time.Duration `yaml:"lock_duration"`
+	ResetAfter   time.Duration `yaml:"reset_after"`
+}
+
+type PasswordPolicy struct {
+	// Counted in characters, not bytes.
+	MinLength int `yaml:"min_length"`
+	// Capped by the input limit of the hash algorithm, zero means no limit
+	// other than the algorithm's.
+	MaxBytes         int  `yaml:"max_bytes"`
+	RequireUppercase bool `yaml:"require_uppercase"`
+	RequireLowercase bool `yaml:"require_lowercase"`
+	RequireDigit     bool `yaml:"require_digit"`
+	RequireSymbol    bool `yaml:"require_symbol"`
+	// Rejects passwords containing the username, the fullname or the email.
+	DisallowAccountInfo bool `yaml:"disallow_account_info"`
+	// A file with one breached password per line, either in plaintext or as
+	// the hex SHA-1 digest used by Have I Been Pwned ("<sha1>[:<count>]").
+	BreachedPasswordFile string `yaml:"breached_password_file"`
+}
+
+// Size is the number of recent passwords, the current one included, that
+// cannot be chosen again. Zero disables the history.
+type PasswordHistory struct {
+	Size int `yaml:"size"`
+}
+
+type PasswordReset struct {
+	TTL time.Duration `yaml:"ttl"`
+}
+
+type VerificationCode struct {
+	// Number of decimal digits of a code.
+	Length int           `yaml:"length"`
+	TTL    time.Duration `yaml:"ttl"`
+	// Wrong guesses allowed before the code is discarded.
+	MaxAttempts uint32 `yaml:"max_attempts"`
+}
+
+type TOTP struct {
+	// Shown by authenticator apps next to the account name.
+	Issuer string `yaml:"issuer"`
+	// Base64 encoded 32 byte AES key the secrets are stored under. TOTP
+	// enrollment is refused when empty.
+	EncryptionKey string `yaml:"encryption_key"`
+	// Number of 30 second steps a code may be off to tolerate clock drift.
+	Skew uint `yaml:"skew"`
+}
+
+type SecondFactor struct {
+	ChallengeTTL time.Duration `yaml:"challenge_ttl"`
+	// Wrong codes allowed on one challenge before it is discarded.
+	MaxAttempts uint32 `yaml:"max_attempts"`
+	// Number of recovery codes handed out at once.
+	RecoveryCodeCount int `yaml:"recovery_code_count"`
+}
+
+type WebAuthn struct {
+	// The domain the credentials are scoped to, such as "fiagram.com".
+	RPID          string `yaml:"rp_id"`
+	RPDisplayName string `yaml:"rp_display_name"`
+	// The origins of the pages allowed to run a ceremony, such as
+	// "https://app.fiagram.com".
+	RPOrigins []string `yaml:"rp_origins"`
+	// How long a ceremony may take between its begin and finish.
+	SessionTTL time.Duration `yaml:"session_ttl"`
 }
@@ -9,10 +9,14 @@ import (
 )
 
 type Config struct {
-	Grpc     Grpc     `yaml:"grpc"`
-	Database Database `yaml:"database"`
-	Auth     Auth     `yaml:"auth"`
-	Log      Log      `yaml:"log"`
+	Grpc            Grpc            `yaml:"grpc"`
+	Database        Database        `yaml:"database"`
+	Auth            Auth            `yaml:"auth"`
+	AccountDeletion AccountDeletion `yaml:"account_deletion"`
+	PhoneNumber     PhoneNumber     `yaml:"phone_number"`
+	Notifier        Notifier        `yaml:"notifier"`
+	SMSSender       SMSSender       `yaml:"sms_sender"`
+	Log             Log             `yaml:"log"`
 }
 
 // Creates a new config instance by reading from a given YAML file.
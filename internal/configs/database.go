@@ -2,13 +2,28 @@ package configs
 
 type DatatabaseType string
 
-const DatabaseTypeMySql DatatabaseType = "mysql"
+const (
+	DatabaseTypeMySql    DatatabaseType = "mysql"
+	DatabaseTypePostgres DatatabaseType = "postgres"
+	DatabaseTypeSqlite   DatatabaseType = "sqlite"
+	// Holds the accounts, their passwords and the roles in memory until the
+	// process exits, for tests only. The other accessors fail on it, so the
+	// server and the subcommands refuse it.
+	DatabaseTypeMemory DatatabaseType = "memory"
+)
 
 type Database struct {
+	// Either mysql, postgres or sqlite, mysql when left empty. Tests may also
+	// use memory.
 	Type     string `yaml:"type"`
 	Host     string `yaml:"host"`
 	Port     int    `yaml:"port"`
 	Username string `yaml:"username"`
 	Password string `yaml:"password"`
+	// The path of the database file for sqlite, which ignores the host, the
+	// port and the credentials.
 	Database string `yaml:"database"`
+	// Migrates the schema up on boot. Otherwise the migrate subcommand does,
+	// and the server refuses to start while the schema is behind.
+	AutoMigrate bool `yaml:"auto_migrate"`
 }
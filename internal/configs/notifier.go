@@ -0,0 +1,14 @@
+package configs
+
+type NotifierType string
+
+const (
+	NotifierTypeLog  NotifierType = "log"
+	NotifierTypeFile NotifierType = "file"
+)
+
+type Notifier struct {
+	Type NotifierType `yaml:"type"`
+	// Where the file notifier appends its messages, one JSON object per line.
+	FilePath string `yaml:"file_path"`
+}
@@ -0,0 +1,7 @@
+package configs
+
+type PhoneNumber struct {
+	// Country calling code, without the plus sign, assumed for numbers
+	// written in national format.
+	DefaultCountryCode string `yaml:"default_country_code"`
+}
@@ -0,0 +1,14 @@
+package configs
+
+type SMSSenderType string
+
+const (
+	SMSSenderTypeLog  SMSSenderType = "log"
+	SMSSenderTypeFile SMSSenderType = "file"
+)
+
+type SMSSender struct {
+	Type SMSSenderType `yaml:"type"`
+	// Where the file sender appends its texts, one JSON object per line.
+	FilePath string `yaml:"file_path"`
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"strings"
@@ -12,16 +13,88 @@ import (
 )
 
 type Account struct {
-	Id          uint64    `json:"id"`
-	Username    string    `json:"username"`
-	Fullname    string    `json:"fullname"`
-	Email       string    `json:"email"`
-	PhoneNumber string    `json:"phone_number"`
-	RoleId      uint8     `json:"role_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	Id              uint64     `json:"id"`
+	Username        string     `json:"username"`
+	Fullname        string     `json:"fullname"`
+	Email           string     `json:"email"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	PhoneNumber     string     `json:"phone_number"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at"`
+	RoleId          uint8      `json:"role_id"`
+	StatusId        uint8      `json:"status_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	// Nil unless the account has been deleted and awaits its purge.
+	DeletedAt *time.Time `json:"deleted_at"`
 }
 
+// Columns are listed explicitly, since columns added by later migrations are
+// appended to the end of the table.
+const accountColumns = `id, username, fullname, email, email_verified_at,
+		phone_number, phone_verified_at, role_id, status_id, created_at, updated_at,
+		deleted_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// Scans the account columns, then the extra ones selected after them.
+func scanAccount(row rowScanner, extra ...any) (Account, error) {
+	var out Account
+	dest := []any{&out.Id,
+		&out.Username,
+		&out.Fullname,
+		&out.Email,
+		&out.EmailVerifiedAt,
+		&out.PhoneNumber,
+		&out.PhoneVerifiedAt,
+		&out.RoleId,
+		&out.StatusId,
+		&out.CreatedAt,
+		&out.UpdatedAt,
+		&out.DeletedAt}
+	err := row.Scan(append(dest, extra...)...)
+	return out, err
+}
+
+// Narrows and pages a listing of accounts. Pages are keyed on the id, so a
+// page stays stable while accounts are created or deleted.
+type AccountListQuery struct {
+	// Nil matches every role.
+	RoleId *uint8
+	// Nil matches every status.
+	StatusId *uint8
+	// Zero leaves the creation time unbounded on that side. Both bounds are
+	// exclusive.
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	UsernamePrefix string
+	// Lists the accounts past this id, in the listing order. Zero starts from
+	// the first one.
+	AfterId      uint64
+	IsDescending bool
+	// Required by ListAccounts. Zero streams every account.
+	Limit int
+}
+
+// Looks for accounts by words of their username, fullname or email. Results
+// are ranked, so they are paged by offset.
+type AccountSearchQuery struct {
+	// Each term must start a word of one of the fields. Terms hold letters and
+	// digits only.
+	Terms  []string
+	Offset int
+	Limit  int
+}
+
+type AccountSearchResult struct {
+	Account
+	// Higher is more relevant. Scores compare within one search only.
+	Score float64
+}
+
+// Reads and updates leave deleted accounts out, as if they were gone, until
+// they are restored or purged.
 type AccountAccessor interface {
 	CreateAccount(ctx context.Context, account Account) (uint64, error)
 
@@ -29,13 +102,45 @@ type AccountAccessor interface {
 	GetAccountByUsername(ctx context.Context, username string) (Account, error)
 
 	UpdateAccount(ctx context.Context, account Account) error
+	// Marks the email of the account as verified, provided it still is the
+	// given one.
+	SetAccountEmailVerified(ctx context.Context, id uint64, email string, verifiedAt time.Time) error
+	// Marks the phone number of the account as verified, provided it still is
+	// the given one.
+	SetAccountPhoneVerified(ctx context.Context, id uint64, phoneNumber string, verifiedAt time.Time) error
+	// Moves the account to another status, provided it still is in the given
+	// one.
+	UpdateAccountStatus(ctx context.Context, id uint64, fromStatusId uint8, toStatusId uint8) error
 
 	DeleteAccount(ctx context.Context, id uint64) error
 	DeleteAccountByUsername(ctx context.Context, username string) error
-
+	SoftDeleteAccount(ctx context.Context, id uint64, deletedAt time.Time) error
+	// Undoes the deletion of an account deleted after the given time.
+	RestoreAccount(ctx context.Context, id uint64, deletedAfter time.Time) error
+	// Removes an account deleted before the given time. Fails for an account
+	// restored in the meantime.
+	PurgeAccount(ctx context.Context, id uint64, deletedBefore time.Time) error
+	// Lists the accounts deleted before the given time whose id is past
+	// afterId, in id order, so the list can be read page by page.
+	GetDeletedAccountIdList(ctx context.Context, deletedBefore time.Time, afterId uint64, limit int) ([]uint64, error)
+
+	// Counts deleted accounts too, since their usernames stay reserved until
+	// they are purged.
 	IsUsernameTaken(ctx context.Context, username string) (bool, error)
 
+	// Deprecated: Use ListAccounts, which pages the accounts.
 	GetAccountAll(ctx context.Context) ([]Account, error)
+	// Lists the accounts matching the query, ordered by id.
+	ListAccounts(ctx context.Context, query AccountListQuery) ([]Account, error)
+	// Calls fn with the accounts one at a time as the rows are read, so the
+	// listing is never held in memory. A slow fn holds the connection and
+	// slows the reading down. Stops at the first error of fn and returns it.
+	StreamAccounts(ctx context.Context, query AccountListQuery, fn func(Account) error) error
+	// Searches with the FULLTEXT index of MySQL, or the text search of
+	// Postgres, falling back to LIKE where the database cannot run either.
+	// SQLite has neither and always searches with LIKE.
+	// Ranks the results most relevant first.
+	SearchAccounts(ctx context.Context, query AccountSearchQuery) ([]AccountSearchResult, error)
 	GetAccountList(ctx context.Context, ids []uint64) ([]Account, error)
 
 	WithExecutor(exec Executor) AccountAccessor
@@ -46,12 +151,16 @@ type accountAccessor struct {
 	logger *zap.Logger
 }
 
+// Holds the accounts in memory when given a memory database.
 func NewAccountAccessor(
 	exec Executor,
 	logger *zap.Logger,
 ) AccountAccessor {
+	if store := memoryStoreOf(exec); store != nil {
+		return newMemoryAccountAccessor(store, exec, logger)
+	}
 	return &accountAccessor{
-		exec:   exec,
+		exec:   newDialectExecutor(exec),
 		logger: logger,
 	}
 }
@@ -67,36 +176,22 @@ func (a accountAccessor) CreateAccount(
 
 	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account", acc))
 	const query = `INSERT INTO accounts 
-			(username, fullname, email, phone_number, role_id) 
-			VALUES (?, ?, ?, ?, ?)`
-	result, err := a.exec.ExecContext(ctx, query,
+			(username, fullname, email, phone_number, role_id, status_id) 
+			VALUES (?, ?, ?, ?, ?, ?)`
+	id, err := insertReturningId(ctx, a.exec, query,
 		strings.TrimSpace(acc.Username),
 		strings.TrimSpace(acc.Fullname),
 		strings.TrimSpace(acc.Email),
 		strings.TrimSpace(acc.PhoneNumber),
 		acc.RoleId,
+		acc.StatusId,
 	)
 	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to create account")
 		return 0, err
 	}
 
-	rowEfNum, err := result.RowsAffected()
-	if rowEfNum != 1 || err != nil {
-		errMsg := "failed to effect row"
-		logger.With(zap.Int64("rowEfNum", rowEfNum)).
-			With(zap.Error(err)).
-			Error(errMsg)
-		return 0, errors.New(errMsg)
-	}
-
-	lastInsertedId, err := result.LastInsertId()
-	if err != nil {
-		logger.With(zap.Error(err)).Error("failed to get last inserted id")
-		return 0, err
-	}
-
-	return uint64(lastInsertedId), nil
+	return id, nil
 }
 
 func (a accountAccessor) GetAccount(
@@ -108,18 +203,10 @@ func (a accountAccessor) GetAccount(
 	}
 
 	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
-	const query = `SELECT * FROM accounts WHERE id = ?`
+	const query = `SELECT ` + accountColumns + ` FROM accounts WHERE id = ? AND deleted_at IS NULL`
 	row := a.exec.QueryRowContext(ctx, query, id)
 
-	var out Account
-	err := row.Scan(&out.Id,
-		&out.Username,
-		&out.Fullname,
-		&out.Email,
-		&out.PhoneNumber,
-		&out.RoleId,
-		&out.CreatedAt,
-		&out.UpdatedAt)
+	out, err := scanAccount(row)
 	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to get account by id")
 		return Account{}, err
@@ -137,18 +224,10 @@ func (a accountAccessor) GetAccountByUsername(
 	}
 
 	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("username", username))
-	const query = `SELECT * FROM accounts WHERE username = ?`
+	const query = `SELECT ` + accountColumns + ` FROM accounts WHERE username = ? AND deleted_at IS NULL`
 	row := a.exec.QueryRowContext(ctx, query, username)
 
-	var out Account
-	err := row.Scan(&out.Id,
-		&out.Username,
-		&out.Fullname,
-		&out.Email,
-		&out.PhoneNumber,
-		&out.RoleId,
-		&out.CreatedAt,
-		&out.UpdatedAt)
+	out, err := scanAccount(row)
 	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to get account by username")
 		return Account{}, err
@@ -213,6 +292,136 @@ func (a accountAccessor) DeleteAccountByUsername(
 	return nil
 }
 
+func (a accountAccessor) SoftDeleteAccount(
+	ctx context.Context,
+	id uint64,
+	deletedAt time.Time,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	const query = `UPDATE accounts SET
+			deleted_at = ?
+			WHERE id = ? AND deleted_at IS NULL`
+	result, err := a.exec.ExecContext(ctx, query, deletedAt, id)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to soft delete account")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a accountAccessor) RestoreAccount(
+	ctx context.Context,
+	id uint64,
+	deletedAfter time.Time,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	const query = `UPDATE accounts SET
+			deleted_at = NULL
+			WHERE id = ? AND deleted_at > ?`
+	result, err := a.exec.ExecContext(ctx, query, id, deletedAfter)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to restore account")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a accountAccessor) PurgeAccount(
+	ctx context.Context,
+	id uint64,
+	deletedBefore time.Time,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	const query = `DELETE FROM accounts WHERE id = ? AND deleted_at < ?`
+	result, err := a.exec.ExecContext(ctx, query, id, deletedBefore)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to purge account")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a accountAccessor) GetDeletedAccountIdList(
+	ctx context.Context,
+	deletedBefore time.Time,
+	afterId uint64,
+	limit int,
+) ([]uint64, error) {
+	if limit <= 0 {
+		return nil, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger)
+	const query = `SELECT id FROM accounts
+			WHERE deleted_at < ? AND id > ?
+			ORDER BY id
+			LIMIT ?`
+	rows, err := a.exec.QueryContext(ctx, query, deletedBefore, afterId, limit)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get deleted account ids")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uint64
+	for rows.Next() {
+		var id uint64
+		if err := rows.Scan(&id); err != nil {
+			logger.With(zap.Error(err)).Error("failed to scan account id")
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get deleted account ids")
+		return nil, err
+	}
+
+	return ids, nil
+}
+
 func (a accountAccessor) UpdateAccount(
 	ctx context.Context,
 	acc Account,
@@ -225,14 +434,18 @@ func (a accountAccessor) UpdateAccount(
 	const query = `UPDATE accounts SET 
 			fullname = ?, 
 			email = ?, 
+			email_verified_at = ?, 
 			phone_number = ?, 
+			phone_verified_at = ?, 
 			role_id = ? 
-			WHERE username = ?`
+			WHERE username = ? AND deleted_at IS NULL`
 
 	result, err := a.exec.ExecContext(ctx, query,
 		strings.TrimSpace(acc.Fullname),
 		strings.TrimSpace(acc.Email),
+		acc.EmailVerifiedAt,
 		strings.TrimSpace(acc.PhoneNumber),
+		acc.PhoneVerifiedAt,
 		acc.RoleId,
 		strings.TrimSpace(acc.Username),
 	)
@@ -253,6 +466,102 @@ func (a accountAccessor) UpdateAccount(
 	return nil
 }
 
+func (a accountAccessor) SetAccountEmailVerified(
+	ctx context.Context,
+	id uint64,
+	email string,
+	verifiedAt time.Time,
+) error {
+	if id == 0 || email == "" {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	const query = `UPDATE accounts SET
+			email_verified_at = ?
+			WHERE id = ? AND email = ? AND deleted_at IS NULL`
+	result, err := a.exec.ExecContext(ctx, query, verifiedAt, id, strings.TrimSpace(email))
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to set account email verified")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a accountAccessor) SetAccountPhoneVerified(
+	ctx context.Context,
+	id uint64,
+	phoneNumber string,
+	verifiedAt time.Time,
+) error {
+	if id == 0 || phoneNumber == "" {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	const query = `UPDATE accounts SET
+			phone_verified_at = ?
+			WHERE id = ? AND phone_number = ? AND deleted_at IS NULL`
+	result, err := a.exec.ExecContext(ctx, query, verifiedAt, id, strings.TrimSpace(phoneNumber))
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to set account phone verified")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a accountAccessor) UpdateAccountStatus(
+	ctx context.Context,
+	id uint64,
+	fromStatusId uint8,
+	toStatusId uint8,
+) error {
+	if id == 0 || toStatusId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	const query = `UPDATE accounts SET
+			status_id = ?
+			WHERE id = ? AND status_id = ? AND deleted_at IS NULL`
+	result, err := a.exec.ExecContext(ctx, query, toStatusId, id, fromStatusId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to update account status")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
 func (a accountAccessor) IsUsernameTaken(
 	ctx context.Context,
 	username string,
@@ -280,7 +589,7 @@ func (a accountAccessor) GetAccountAll(
 	ctx context.Context,
 ) ([]Account, error) {
 	logger := utils.LoggerWithContext(ctx, a.logger)
-	const query = `SELECT * FROM accounts`
+	const query = `SELECT ` + accountColumns + ` FROM accounts WHERE deleted_at IS NULL`
 	rows, err := a.exec.QueryContext(ctx, query)
 	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to get all accounts")
@@ -290,25 +599,300 @@ func (a accountAccessor) GetAccountAll(
 
 	var accounts []Account
 	for rows.Next() {
-		var acc Account
-		err := rows.Scan(&acc.Id,
-			&acc.Username,
-			&acc.Fullname,
-			&acc.Email,
-			&acc.PhoneNumber,
-			&acc.RoleId,
-			&acc.CreatedAt,
-			&acc.UpdatedAt)
+		acc, err := scanAccount(rows)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("failed to scan account")
+			return nil, err
+		}
+		accounts = append(accounts, acc)
+	}
+	if err := rows.Err(); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get all accounts")
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// Escapes the wildcards of LIKE, so the prefix matches literally. The escape
+// character is named in the query, a backslash would need quoting that
+// differs between databases.
+var likeEscaper = strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`)
+
+func (a accountAccessor) ListAccounts(
+	ctx context.Context,
+	query AccountListQuery,
+) ([]Account, error) {
+	if query.Limit <= 0 {
+		return nil, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("query", query))
+	statement, args := accountListStatement(query)
+	rows, err := a.exec.QueryContext(ctx, statement, args...)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to list accounts")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []Account
+	for rows.Next() {
+		acc, err := scanAccount(rows)
 		if err != nil {
 			logger.With(zap.Error(err)).Error("failed to scan account")
 			return nil, err
 		}
 		accounts = append(accounts, acc)
 	}
+	if err := rows.Err(); err != nil {
+		logger.With(zap.Error(err)).Error("failed to list accounts")
+		return nil, err
+	}
 
 	return accounts, nil
 }
 
+func (a accountAccessor) StreamAccounts(
+	ctx context.Context,
+	query AccountListQuery,
+	fn func(Account) error,
+) error {
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("query", query))
+	statement, args := accountListStatement(query)
+	rows, err := a.exec.QueryContext(ctx, statement, args...)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to stream accounts")
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		acc, err := scanAccount(rows)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("failed to scan account")
+			return err
+		}
+		if err := fn(acc); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		logger.With(zap.Error(err)).Error("failed to stream accounts")
+		return err
+	}
+
+	return nil
+}
+
+func accountListStatement(query AccountListQuery) (string, []any) {
+	conditions := []string{"deleted_at IS NULL"}
+	args := []any{}
+	if query.RoleId != nil {
+		conditions = append(conditions, "role_id = ?")
+		args = append(args, *query.RoleId)
+	}
+	if query.StatusId != nil {
+		conditions = append(conditions, "status_id = ?")
+		args = append(args, *query.StatusId)
+	}
+	if !query.CreatedAfter.IsZero() {
+		conditions = append(conditions, "created_at > ?")
+		args = append(args, query.CreatedAfter)
+	}
+	if !query.CreatedBefore.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, query.CreatedBefore)
+	}
+	if query.UsernamePrefix != "" {
+		conditions = append(conditions, "username LIKE ? ESCAPE '!'")
+		args = append(args, likeEscaper.Replace(query.UsernamePrefix)+"%")
+	}
+	order := "ASC"
+	if query.IsDescending {
+		order = "DESC"
+	}
+	if query.AfterId != 0 {
+		if query.IsDescending {
+			conditions = append(conditions, "id < ?")
+		} else {
+			conditions = append(conditions, "id > ?")
+		}
+		args = append(args, query.AfterId)
+	}
+
+	statement := `SELECT ` + accountColumns + ` FROM accounts
+			WHERE ` + strings.Join(conditions, " AND ") + `
+			ORDER BY id ` + order
+	if query.Limit > 0 {
+		statement += ` LIMIT ?`
+		args = append(args, query.Limit)
+	}
+	return statement, args
+}
+
+func (a accountAccessor) SearchAccounts(
+	ctx context.Context,
+	query AccountSearchQuery,
+) ([]AccountSearchResult, error) {
+	if len(query.Terms) == 0 || query.Limit <= 0 || query.Offset < 0 {
+		return nil, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("query", query))
+	if dialectOf(a.exec) == dialectSQLite {
+		results, err := a.searchAccountsLike(ctx, query)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("failed to search accounts")
+			return nil, err
+		}
+		return results, nil
+	}
+
+	results, err := a.searchAccountsFullText(ctx, query)
+	if err == nil {
+		return results, nil
+	}
+	if ctx.Err() != nil {
+		logger.With(zap.Error(err)).Error("failed to search accounts")
+		return nil, err
+	}
+
+	logger.With(zap.Error(err)).Warn("failed to search accounts with the fulltext index, falling back to like")
+	results, err = a.searchAccountsLike(ctx, query)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to search accounts")
+		return nil, err
+	}
+	return results, nil
+}
+
+func (a accountAccessor) searchAccountsFullText(
+	ctx context.Context,
+	query AccountSearchQuery,
+) ([]AccountSearchResult, error) {
+	if dialectOf(a.exec) == dialectPostgres {
+		return a.searchAccountsTextSearch(ctx, query)
+	}
+
+	// Every term is required, and matches the words it starts
+	words := make([]string, 0, len(query.Terms))
+	for _, term := range query.Terms {
+		words = append(words, "+"+term+"*")
+	}
+	against := strings.Join(words, " ")
+
+	const statement = `SELECT ` + accountColumns + `,
+			MATCH (username, fullname, email) AGAINST (? IN BOOLEAN MODE) AS score
+			FROM accounts
+			WHERE deleted_at IS NULL
+			AND MATCH (username, fullname, email) AGAINST (? IN BOOLEAN MODE)
+			ORDER BY score DESC, id ASC
+			LIMIT ? OFFSET ?`
+	rows, err := a.exec.QueryContext(ctx, statement, against, against, query.Limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAccountSearchResults(rows)
+}
+
+// The document searched on Postgres, as the accounts_search index is built.
+// The email is split at its @ and dots, so its domain matches too.
+const accountSearchDocumentPostgres = `to_tsvector('simple',
+			username::text || ' ' || fullname::text || ' ' || translate(email::text, '@.', '  '))`
+
+// Escapes a term as a quoted lexeme of a text search query.
+var tsQueryEscaper = strings.NewReplacer(`'`, `''`, `\`, `\\`)
+
+// Searches with the text search of Postgres, which plays the part of the
+// FULLTEXT index of MySQL.
+func (a accountAccessor) searchAccountsTextSearch(
+	ctx context.Context,
+	query AccountSearchQuery,
+) ([]AccountSearchResult, error) {
+	// Every term is required, and matches the words it starts
+	lexemes := make([]string, 0, len(query.Terms))
+	for _, term := range query.Terms {
+		lexemes = append(lexemes, "'"+tsQueryEscaper.Replace(term)+"':*")
+	}
+	tsQuery := strings.Join(lexemes, " & ")
+
+	const statement = `SELECT ` + accountColumns + `,
+			ts_rank(` + accountSearchDocumentPostgres + `, to_tsquery('simple', ?)) AS score
+			FROM accounts
+			WHERE deleted_at IS NULL
+			AND ` + accountSearchDocumentPostgres + ` @@ to_tsquery('simple', ?)
+			ORDER BY score DESC, id ASC
+			LIMIT ? OFFSET ?`
+	rows, err := a.exec.QueryContext(ctx, statement, tsQuery, tsQuery, query.Limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAccountSearchResults(rows)
+}
+
+// Scores a field two for a term starting it, one for a term found elsewhere
+// in it, and adds the scores up over the terms and fields.
+func (a accountAccessor) searchAccountsLike(
+	ctx context.Context,
+	query AccountSearchQuery,
+) ([]AccountSearchResult, error) {
+	fields := []string{"username", "fullname", "email"}
+	var scores, conditions []string
+	var scoreArgs, conditionArgs []any
+	for _, term := range query.Terms {
+		prefix := likeEscaper.Replace(term) + "%"
+		contains := "%" + prefix
+
+		var matches []string
+		for _, field := range fields {
+			scores = append(scores, `CASE WHEN `+field+` LIKE ? ESCAPE '!' THEN 2
+					WHEN `+field+` LIKE ? ESCAPE '!' THEN 1 ELSE 0 END`)
+			scoreArgs = append(scoreArgs, prefix, contains)
+			matches = append(matches, field+` LIKE ? ESCAPE '!'`)
+			conditionArgs = append(conditionArgs, contains)
+		}
+		conditions = append(conditions, "("+strings.Join(matches, " OR ")+")")
+	}
+
+	statement := `SELECT ` + accountColumns + `,
+			` + strings.Join(scores, " + ") + ` AS score
+			FROM accounts
+			WHERE deleted_at IS NULL
+			AND ` + strings.Join(conditions, " AND ") + `
+			ORDER BY score DESC, id ASC
+			LIMIT ? OFFSET ?`
+	args := append(scoreArgs, conditionArgs...)
+	args = append(args, query.Limit, query.Offset)
+	rows, err := a.exec.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAccountSearchResults(rows)
+}
+
+func scanAccountSearchResults(rows *sql.Rows) ([]AccountSearchResult, error) {
+	var results []AccountSearchResult
+	for rows.Next() {
+		var score float64
+		acc, err := scanAccount(rows, &score)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, AccountSearchResult{
+			Account: acc,
+			Score:   score,
+		})
+	}
+	return results, rows.Err()
+}
+
 func (a accountAccessor) GetAccountList(
 	ctx context.Context,
 	ids []uint64,
@@ -318,7 +902,7 @@ func (a accountAccessor) GetAccountList(
 	}
 
 	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("ids", ids))
-	query := `SELECT * FROM accounts WHERE id IN (?` + strings.Repeat(",?", len(ids)-1) + `)`
+	query := `SELECT ` + accountColumns + ` FROM accounts WHERE id IN (?` + strings.Repeat(",?", len(ids)-1) + `) AND deleted_at IS NULL`
 
 	args := make([]any, len(ids))
 	for i, id := range ids {
@@ -334,21 +918,17 @@ func (a accountAccessor) GetAccountList(
 
 	var accounts []Account
 	for rows.Next() {
-		var acc Account
-		err := rows.Scan(&acc.Id,
-			&acc.Username,
-			&acc.Fullname,
-			&acc.Email,
-			&acc.PhoneNumber,
-			&acc.RoleId,
-			&acc.CreatedAt,
-			&acc.UpdatedAt)
+		acc, err := scanAccount(rows)
 		if err != nil {
 			logger.With(zap.Error(err)).Error("failed to scan account")
 			return nil, err
 		}
 		accounts = append(accounts, acc)
 	}
+	if err := rows.Err(); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get account list")
+		return nil, err
+	}
 
 	return accounts, nil
 }
@@ -357,7 +937,7 @@ func (a accountAccessor) WithExecutor(
 	exec Executor,
 ) AccountAccessor {
 	return &accountAccessor{
-		exec:   exec,
+		exec:   withDialectOf(a.exec, exec),
 		logger: a.logger,
 	}
 }
@@ -0,0 +1,686 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+// Holds the accounts in the store of a memory database, behaving as the
+// accounts table does.
+type memoryAccountAccessor struct {
+	store  *memoryStore
+	exec   Executor
+	logger *zap.Logger
+}
+
+func newMemoryAccountAccessor(
+	store *memoryStore,
+	exec Executor,
+	logger *zap.Logger,
+) AccountAccessor {
+	return &memoryAccountAccessor{
+		store:  store,
+		exec:   exec,
+		logger: logger,
+	}
+}
+
+func (a memoryAccountAccessor) CreateAccount(
+	ctx context.Context,
+	acc Account,
+) (uint64, error) {
+	if acc.Username == "" &&
+		acc.RoleId == 0 {
+		return 0, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account", acc))
+	id := a.store.lastAccountId.Add(1)
+	now := time.Now().UTC()
+	created := Account{
+		Id:          id,
+		Username:    strings.TrimSpace(acc.Username),
+		Fullname:    strings.TrimSpace(acc.Fullname),
+		Email:       strings.TrimSpace(acc.Email),
+		PhoneNumber: strings.TrimSpace(acc.PhoneNumber),
+		RoleId:      acc.RoleId,
+		StatusId:    acc.StatusId,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	roleExists := false
+	for _, role := range a.store.roles {
+		roleExists = roleExists || role.Id == created.RoleId
+	}
+
+	err := a.store.write(ctx, a.exec, func(state *memoryState) error {
+		if !roleExists {
+			return errMemoryForeignKey
+		}
+		key := memoryUsernameKey(created.Username)
+		if _, ok := state.accountIdByUsername[key]; ok {
+			return errMemoryDuplicateKey
+		}
+		state.accounts[id] = created
+		state.accountIdByUsername[key] = id
+		return nil
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create account")
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// Returns the account unless it is deleted.
+func (a memoryAccountAccessor) getAccount(
+	ctx context.Context,
+	matches func(state memoryState) (Account, bool),
+) (Account, error) {
+	var out Account
+	err := a.store.read(ctx, a.exec, func(state memoryState) error {
+		acc, ok := matches(state)
+		if !ok || acc.DeletedAt != nil {
+			return sql.ErrNoRows
+		}
+		out = acc.clone()
+		return nil
+	})
+	return out, err
+}
+
+func (a memoryAccountAccessor) GetAccount(
+	ctx context.Context,
+	id uint64,
+) (Account, error) {
+	if id == 0 {
+		return Account{}, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	out, err := a.getAccount(ctx, func(state memoryState) (Account, bool) {
+		acc, ok := state.accounts[id]
+		return acc, ok
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get account by id")
+		return Account{}, err
+	}
+
+	return out, nil
+}
+
+func (a memoryAccountAccessor) GetAccountByUsername(
+	ctx context.Context,
+	username string,
+) (Account, error) {
+	if username == "" {
+		return Account{}, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("username", username))
+	out, err := a.getAccount(ctx, func(state memoryState) (Account, bool) {
+		acc, ok := state.accounts[state.accountIdByUsername[memoryUsernameKey(username)]]
+		return acc, ok
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get account by username")
+		return Account{}, err
+	}
+
+	return out, nil
+}
+
+// Applies update to the account, failing as no row is affected when the
+// account is missing or update declines it.
+func (a memoryAccountAccessor) updateAccount(
+	ctx context.Context,
+	id func(state memoryState) uint64,
+	update func(acc *Account) bool,
+) error {
+	now := time.Now().UTC()
+	return a.store.write(ctx, a.exec, func(state *memoryState) error {
+		acc, ok := state.accounts[id(*state)]
+		if !ok || !update(&acc) {
+			return errMemoryRowNotAffected
+		}
+		acc.UpdatedAt = now
+		state.accounts[acc.Id] = acc
+		return nil
+	})
+}
+
+// Removes the account, failing as no row is affected when the account is
+// missing or matches declines it, and as the foreign key does while its
+// password is kept.
+func (a memoryAccountAccessor) deleteAccount(
+	ctx context.Context,
+	id func(state memoryState) uint64,
+	matches func(acc Account) bool,
+) error {
+	return a.store.write(ctx, a.exec, func(state *memoryState) error {
+		acc, ok := state.accounts[id(*state)]
+		if !ok || !matches(acc) {
+			return errMemoryRowNotAffected
+		}
+		if _, ok := state.accountPasswords[acc.Id]; ok {
+			return errMemoryForeignKey
+		}
+		delete(state.accounts, acc.Id)
+		delete(state.accountIdByUsername, memoryUsernameKey(acc.Username))
+		return nil
+	})
+}
+
+func (a memoryAccountAccessor) DeleteAccount(
+	ctx context.Context,
+	id uint64,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	err := a.deleteAccount(ctx,
+		func(memoryState) uint64 { return id },
+		func(Account) bool { return true })
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete account")
+		return err
+	}
+
+	return nil
+}
+
+func (a memoryAccountAccessor) DeleteAccountByUsername(
+	ctx context.Context,
+	username string,
+) error {
+	if username == "" {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("username", username))
+	err := a.deleteAccount(ctx,
+		func(state memoryState) uint64 { return state.accountIdByUsername[memoryUsernameKey(username)] },
+		func(Account) bool { return true })
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete account")
+		return err
+	}
+
+	return nil
+}
+
+func (a memoryAccountAccessor) SoftDeleteAccount(
+	ctx context.Context,
+	id uint64,
+	deletedAt time.Time,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	err := a.updateAccount(ctx,
+		func(memoryState) uint64 { return id },
+		func(acc *Account) bool {
+			if acc.DeletedAt != nil {
+				return false
+			}
+			acc.DeletedAt = &deletedAt
+			return true
+		})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to soft delete account")
+		return err
+	}
+
+	return nil
+}
+
+func (a memoryAccountAccessor) RestoreAccount(
+	ctx context.Context,
+	id uint64,
+	deletedAfter time.Time,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	err := a.updateAccount(ctx,
+		func(memoryState) uint64 { return id },
+		func(acc *Account) bool {
+			if acc.DeletedAt == nil || !acc.DeletedAt.After(deletedAfter) {
+				return false
+			}
+			acc.DeletedAt = nil
+			return true
+		})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to restore account")
+		return err
+	}
+
+	return nil
+}
+
+func (a memoryAccountAccessor) PurgeAccount(
+	ctx context.Context,
+	id uint64,
+	deletedBefore time.Time,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	err := a.deleteAccount(ctx,
+		func(memoryState) uint64 { return id },
+		func(acc Account) bool { return acc.DeletedAt != nil && acc.DeletedAt.Before(deletedBefore) })
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to purge account")
+		return err
+	}
+
+	return nil
+}
+
+func (a memoryAccountAccessor) GetDeletedAccountIdList(
+	ctx context.Context,
+	deletedBefore time.Time,
+	afterId uint64,
+	limit int,
+) ([]uint64, error) {
+	if limit <= 0 {
+		return nil, ErrLackOfInfor
+	}
+
+	var deleted []Account
+	err := a.store.read(ctx, a.exec, func(state memoryState) error {
+		for _, acc := range state.accounts {
+			if acc.DeletedAt != nil && acc.DeletedAt.Before(deletedBefore) && acc.Id > afterId {
+				deleted = append(deleted, acc)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		utils.LoggerWithContext(ctx, a.logger).
+			With(zap.Error(err)).
+			Error("failed to get deleted account ids")
+		return nil, err
+	}
+
+	sort.Slice(deleted, func(i, j int) bool {
+		return deleted[i].Id < deleted[j].Id
+	})
+	var ids []uint64
+	for _, acc := range deleted[:min(limit, len(deleted))] {
+		ids = append(ids, acc.Id)
+	}
+
+	return ids, nil
+}
+
+func (a memoryAccountAccessor) UpdateAccount(
+	ctx context.Context,
+	acc Account,
+) error {
+	if acc.Username == "" {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account", acc))
+	acc = acc.clone()
+	err := a.updateAccount(ctx,
+		func(state memoryState) uint64 { return state.accountIdByUsername[memoryUsernameKey(acc.Username)] },
+		func(stored *Account) bool {
+			if stored.DeletedAt != nil {
+				return false
+			}
+			stored.Fullname = strings.TrimSpace(acc.Fullname)
+			stored.Email = strings.TrimSpace(acc.Email)
+			stored.EmailVerifiedAt = acc.EmailVerifiedAt
+			stored.PhoneNumber = strings.TrimSpace(acc.PhoneNumber)
+			stored.PhoneVerifiedAt = acc.PhoneVerifiedAt
+			stored.RoleId = acc.RoleId
+			return true
+		})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to update account")
+		return err
+	}
+
+	return nil
+}
+
+func (a memoryAccountAccessor) SetAccountEmailVerified(
+	ctx context.Context,
+	id uint64,
+	email string,
+	verifiedAt time.Time,
+) error {
+	if id == 0 || email == "" {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	err := a.updateAccount(ctx,
+		func(memoryState) uint64 { return id },
+		func(acc *Account) bool {
+			if acc.DeletedAt != nil || !strings.EqualFold(acc.Email, strings.TrimSpace(email)) {
+				return false
+			}
+			acc.EmailVerifiedAt = &verifiedAt
+			return true
+		})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to set account email verified")
+		return err
+	}
+
+	return nil
+}
+
+func (a memoryAccountAccessor) SetAccountPhoneVerified(
+	ctx context.Context,
+	id uint64,
+	phoneNumber string,
+	verifiedAt time.Time,
+) error {
+	if id == 0 || phoneNumber == "" {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	err := a.updateAccount(ctx,
+		func(memoryState) uint64 { return id },
+		func(acc *Account) bool {
+			if acc.DeletedAt != nil || acc.PhoneNumber != strings.TrimSpace(phoneNumber) {
+				return false
+			}
+			acc.PhoneVerifiedAt = &verifiedAt
+			return true
+		})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to set account phone verified")
+		return err
+	}
+
+	return nil
+}
+
+func (a memoryAccountAccessor) UpdateAccountStatus(
+	ctx context.Context,
+	id uint64,
+	fromStatusId uint8,
+	toStatusId uint8,
+) error {
+	if id == 0 || toStatusId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("account_id", id))
+	err := a.updateAccount(ctx,
+		func(memoryState) uint64 { return id },
+		func(acc *Account) bool {
+			if acc.DeletedAt != nil || acc.StatusId != fromStatusId {
+				return false
+			}
+			acc.StatusId = toStatusId
+			return true
+		})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to update account status")
+		return err
+	}
+
+	return nil
+}
+
+func (a memoryAccountAccessor) IsUsernameTaken(
+	ctx context.Context,
+	username string,
+) (bool, error) {
+	if username == "" {
+		return false, ErrLackOfInfor
+	}
+
+	var isTaken bool
+	err := a.store.read(ctx, a.exec, func(state memoryState) error {
+		_, isTaken = state.accountIdByUsername[memoryUsernameKey(username)]
+		return nil
+	})
+	if err != nil {
+		utils.LoggerWithContext(ctx, a.logger).
+			With(zap.Any("username", username)).
+			With(zap.Error(err)).
+			Error("failed to check username taken")
+		return false, err
+	}
+
+	return isTaken, nil
+}
+
+// Returns the accounts that are not deleted and match, ordered by id.
+func (a memoryAccountAccessor) findAccounts(
+	ctx context.Context,
+	matches func(acc Account) bool,
+) ([]Account, error) {
+	var accounts []Account
+	err := a.store.read(ctx, a.exec, func(state memoryState) error {
+		for _, acc := range state.accounts {
+			if acc.DeletedAt == nil && matches(acc) {
+				accounts = append(accounts, acc.clone())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Id < accounts[j].Id
+	})
+	return accounts, nil
+}
+
+func (a memoryAccountAccessor) GetAccountAll(
+	ctx context.Context,
+) ([]Account, error) {
+	accounts, err := a.findAccounts(ctx, func(Account) bool { return true })
+	if err != nil {
+		utils.LoggerWithContext(ctx, a.logger).
+			With(zap.Error(err)).
+			Error("failed to get all accounts")
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (a memoryAccountAccessor) ListAccounts(
+	ctx context.Context,
+	query AccountListQuery,
+) ([]Account, error) {
+	if query.Limit <= 0 {
+		return nil, ErrLackOfInfor
+	}
+
+	accounts, err := a.listAccounts(ctx, query)
+	if err != nil {
+		utils.LoggerWithContext(ctx, a.logger).
+			With(zap.Any("query", query)).
+			With(zap.Error(err)).
+			Error("failed to list accounts")
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// The accounts are copied out before fn is called, so fn holds no lock.
+func (a memoryAccountAccessor) StreamAccounts(
+	ctx context.Context,
+	query AccountListQuery,
+	fn func(Account) error,
+) error {
+	accounts, err := a.listAccounts(ctx, query)
+	if err != nil {
+		utils.LoggerWithContext(ctx, a.logger).
+			With(zap.Any("query", query)).
+			With(zap.Error(err)).
+			Error("failed to stream accounts")
+		return err
+	}
+
+	for _, acc := range accounts {
+		if err := fn(acc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a memoryAccountAccessor) listAccounts(
+	ctx context.Context,
+	query AccountListQuery,
+) ([]Account, error) {
+	usernamePrefix := strings.ToLower(query.UsernamePrefix)
+	accounts, err := a.findAccounts(ctx, func(acc Account) bool {
+		switch {
+		case query.RoleId != nil && acc.RoleId != *query.RoleId,
+			query.StatusId != nil && acc.StatusId != *query.StatusId,
+			!query.CreatedAfter.IsZero() && !acc.CreatedAt.After(query.CreatedAfter),
+			!query.CreatedBefore.IsZero() && !acc.CreatedAt.Before(query.CreatedBefore),
+			!strings.HasPrefix(strings.ToLower(acc.Username), usernamePrefix),
+			query.AfterId != 0 && !query.IsDescending && acc.Id <= query.AfterId,
+			query.AfterId != 0 && query.IsDescending && acc.Id >= query.AfterId:
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if query.IsDescending {
+		sort.Slice(accounts, func(i, j int) bool {
+			return accounts[i].Id > accounts[j].Id
+		})
+	}
+	if query.Limit > 0 && len(accounts) > query.Limit {
+		accounts = accounts[:query.Limit]
+	}
+	return accounts, nil
+}
+
+// Ranks the way the LIKE fallback of the SQL accessor does: a field scores
+// two for a term starting it and one for a term found elsewhere in it.
+func (a memoryAccountAccessor) SearchAccounts(
+	ctx context.Context,
+	query AccountSearchQuery,
+) ([]AccountSearchResult, error) {
+	if len(query.Terms) == 0 || query.Limit <= 0 || query.Offset < 0 {
+		return nil, ErrLackOfInfor
+	}
+
+	var results []AccountSearchResult
+	_, err := a.findAccounts(ctx, func(acc Account) bool {
+		fields := []string{
+			strings.ToLower(acc.Username),
+			strings.ToLower(acc.Fullname),
+			strings.ToLower(acc.Email),
+		}
+		score := 0
+		for _, term := range query.Terms {
+			term = strings.ToLower(term)
+			isFound := false
+			for _, field := range fields {
+				if strings.HasPrefix(field, term) {
+					score += 2
+				} else if strings.Contains(field, term) {
+					score++
+				} else {
+					continue
+				}
+				isFound = true
+			}
+			if !isFound {
+				return false
+			}
+		}
+		results = append(results, AccountSearchResult{
+			Account: acc.clone(),
+			Score:   float64(score),
+		})
+		return true
+	})
+	if err != nil {
+		utils.LoggerWithContext(ctx, a.logger).
+			With(zap.Any("query", query)).
+			With(zap.Error(err)).
+			Error("failed to search accounts")
+		return nil, err
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Id < results[j].Id
+	})
+	if query.Offset >= len(results) {
+		return nil, nil
+	}
+	results = results[query.Offset:]
+	return results[:min(query.Limit, len(results))], nil
+}
+
+func (a memoryAccountAccessor) GetAccountList(
+	ctx context.Context,
+	ids []uint64,
+) ([]Account, error) {
+	if len(ids) == 0 {
+		return []Account{}, fmt.Errorf("ids is empty")
+	}
+
+	wanted := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	accounts, err := a.findAccounts(ctx, func(acc Account) bool {
+		return wanted[acc.Id]
+	})
+	if err != nil {
+		utils.LoggerWithContext(ctx, a.logger).
+			With(zap.Any("ids", ids)).
+			With(zap.Error(err)).
+			Error("failed to get account list")
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (a memoryAccountAccessor) WithExecutor(
+	exec Executor,
+) AccountAccessor {
+	return &memoryAccountAccessor{
+		store:  a.store,
+		exec:   exec,
+		logger: a.logger,
+	}
+}
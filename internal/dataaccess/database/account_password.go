@@ -21,6 +21,9 @@ type AccountPasswordAccessor interface {
 	CreateAccountPassword(ctx context.Context, ap AccountPassword) error
 	GetAccountPassword(ctx context.Context, id uint64) (AccountPassword, error)
 	UpdateAccountPassword(ctx context.Context, ap AccountPassword) error
+	// Updates the password only while it is still previousHashedString, and
+	// tells whether it was. Leaves a password changed meanwhile as it is.
+	UpdateAccountPasswordIfUnchanged(ctx context.Context, ap AccountPassword, previousHashedString string) (bool, error)
 	DeleteAccountPassword(ctx context.Context, id uint64) error
 	WithExecutor(exec Executor) AccountPasswordAccessor
 }
@@ -30,12 +33,16 @@ type accountPasswordAccessor struct {
 	logger *zap.Logger
 }
 
+// Holds the passwords in memory when given a memory database.
 func NewAccountPasswordAccessor(
 	exec Executor,
 	logger *zap.Logger,
 ) AccountPasswordAccessor {
+	if store := memoryStoreOf(exec); store != nil {
+		return newMemoryAccountPasswordAccessor(store, exec, logger)
+	}
 	return &accountPasswordAccessor{
-		exec:   exec,
+		exec:   newDialectExecutor(exec),
 		logger: logger,
 	}
 }
@@ -166,11 +173,43 @@ func (a accountPasswordAccessor) UpdateAccountPassword(
 	return nil
 }
 
+func (a accountPasswordAccessor) UpdateAccountPasswordIfUnchanged(
+	ctx context.Context,
+	ap AccountPassword,
+	previousHashedString string,
+) (bool, error) {
+	if ap.OfAccountId == 0 || ap.HashedString == "" || previousHashedString == "" {
+		return false, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ap.OfAccountId))
+	const query = `UPDATE account_passwords SET
+			hashed_string = ?
+			WHERE of_account_id = ? AND hashed_string = ?`
+	result, err := a.exec.ExecContext(ctx, query,
+		strings.TrimSpace(ap.HashedString),
+		ap.OfAccountId,
+		strings.TrimSpace(previousHashedString),
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to update password")
+		return false, err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to update password")
+		return false, err
+	}
+
+	return rowEfNum == 1, nil
+}
+
 func (a accountPasswordAccessor) WithExecutor(
 	exec Executor,
 ) AccountPasswordAccessor {
 	return &accountPasswordAccessor{
-		exec:   exec,
+		exec:   withDialectOf(a.exec, exec),
 		logger: a.logger,
 	}
 }
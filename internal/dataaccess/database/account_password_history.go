@@ -0,0 +1,171 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+type AccountPasswordHistory struct {
+	Id           uint64    `json:"id"`
+	OfAccountId  uint64    `json:"of_account_id"`
+	HashedString string    `json:"hashed_string"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type AccountPasswordHistoryAccessor interface {
+	CreateAccountPasswordHistory(ctx context.Context, aph AccountPasswordHistory) error
+	// Returns the latest entries of the account, newest first.
+	GetAccountPasswordHistoryList(ctx context.Context, ofAccountId uint64, limit int) ([]AccountPasswordHistory, error)
+	// Deletes the entries of the account older than the given entry id.
+	DeleteAccountPasswordHistoryBefore(ctx context.Context, ofAccountId uint64, beforeId uint64) error
+	DeleteAccountPasswordHistoryOfAccount(ctx context.Context, ofAccountId uint64) error
+	WithExecutor(exec Executor) AccountPasswordHistoryAccessor
+}
+
+type accountPasswordHistoryAccessor struct {
+	exec   Executor
+	logger *zap.Logger
+}
+
+func NewAccountPasswordHistoryAccessor(
+	exec Executor,
+	logger *zap.Logger,
+) AccountPasswordHistoryAccessor {
+	return &accountPasswordHistoryAccessor{
+		exec:   newDialectExecutor(exec),
+		logger: logger,
+	}
+}
+
+func (a accountPasswordHistoryAccessor) CreateAccountPasswordHistory(
+	ctx context.Context,
+	aph AccountPasswordHistory,
+) error {
+	if aph.OfAccountId == 0 || aph.HashedString == "" {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", aph.OfAccountId))
+	const query = `INSERT INTO account_password_history
+			(of_account_id, hashed_string)
+			VALUES (?, ?)`
+	result, err := a.exec.ExecContext(ctx, query,
+		aph.OfAccountId,
+		strings.TrimSpace(aph.HashedString),
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to insert password history")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a accountPasswordHistoryAccessor) GetAccountPasswordHistoryList(
+	ctx context.Context,
+	ofAccountId uint64,
+	limit int,
+) ([]AccountPasswordHistory, error) {
+	if ofAccountId == 0 {
+		return nil, ErrLackOfInfor
+	}
+	if limit <= 0 {
+		return []AccountPasswordHistory{}, nil
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `SELECT id, of_account_id, hashed_string, created_at
+			FROM account_password_history
+			WHERE of_account_id = ?
+			ORDER BY id DESC
+			LIMIT ?`
+	rows, err := a.exec.QueryContext(ctx, query, ofAccountId, limit)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get password history")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []AccountPasswordHistory
+	for rows.Next() {
+		var aph AccountPasswordHistory
+		err := rows.Scan(&aph.Id,
+			&aph.OfAccountId,
+			&aph.HashedString,
+			&aph.CreatedAt)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("failed to scan password history")
+			return nil, err
+		}
+		out = append(out, aph)
+	}
+	if err := rows.Err(); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get password history")
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (a accountPasswordHistoryAccessor) DeleteAccountPasswordHistoryBefore(
+	ctx context.Context,
+	ofAccountId uint64,
+	beforeId uint64,
+) error {
+	if ofAccountId == 0 || beforeId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `DELETE FROM account_password_history
+			WHERE of_account_id = ? AND id < ?`
+	_, err := a.exec.ExecContext(ctx, query, ofAccountId, beforeId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to prune password history")
+		return err
+	}
+
+	return nil
+}
+
+func (a accountPasswordHistoryAccessor) DeleteAccountPasswordHistoryOfAccount(
+	ctx context.Context,
+	ofAccountId uint64,
+) error {
+	if ofAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `DELETE FROM account_password_history WHERE of_account_id = ?`
+	_, err := a.exec.ExecContext(ctx, query, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete password history")
+		return err
+	}
+
+	return nil
+}
+
+func (a accountPasswordHistoryAccessor) WithExecutor(
+	exec Executor,
+) AccountPasswordHistoryAccessor {
+	return &accountPasswordHistoryAccessor{
+		exec:   withDialectOf(a.exec, exec),
+		logger: a.logger,
+	}
+}
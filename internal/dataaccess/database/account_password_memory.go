@@ -0,0 +1,185 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+// Holds the account passwords in the store of a memory database, behaving
+// as the account_passwords table does.
+type memoryAccountPasswordAccessor struct {
+	store  *memoryStore
+	exec   Executor
+	logger *zap.Logger
+}
+
+func newMemoryAccountPasswordAccessor(
+	store *memoryStore,
+	exec Executor,
+	logger *zap.Logger,
+) AccountPasswordAccessor {
+	return &memoryAccountPasswordAccessor{
+		store:  store,
+		exec:   exec,
+		logger: logger,
+	}
+}
+
+func (a memoryAccountPasswordAccessor) CreateAccountPassword(
+	ctx context.Context,
+	ap AccountPassword,
+) error {
+	if ap.OfAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ap.OfAccountId))
+	now := time.Now().UTC()
+	created := AccountPassword{
+		OfAccountId:  ap.OfAccountId,
+		HashedString: strings.TrimSpace(ap.HashedString),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	err := a.store.write(ctx, a.exec, func(state *memoryState) error {
+		if _, ok := state.accounts[created.OfAccountId]; !ok {
+			return errMemoryForeignKey
+		}
+		if _, ok := state.accountPasswords[created.OfAccountId]; ok {
+			return errMemoryDuplicateKey
+		}
+		state.accountPasswords[created.OfAccountId] = created
+		return nil
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to insert account password")
+		return err
+	}
+
+	return nil
+}
+
+func (a memoryAccountPasswordAccessor) GetAccountPassword(
+	ctx context.Context,
+	id uint64,
+) (AccountPassword, error) {
+	if id == 0 {
+		return AccountPassword{}, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", id))
+	var out AccountPassword
+	err := a.store.read(ctx, a.exec, func(state memoryState) error {
+		ap, ok := state.accountPasswords[id]
+		if !ok {
+			return sql.ErrNoRows
+		}
+		out = ap
+		return nil
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get password")
+		return AccountPassword{}, err
+	}
+
+	return out, nil
+}
+
+func (a memoryAccountPasswordAccessor) DeleteAccountPassword(
+	ctx context.Context,
+	id uint64,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", id))
+	err := a.store.write(ctx, a.exec, func(state *memoryState) error {
+		if _, ok := state.accountPasswords[id]; !ok {
+			return errMemoryRowNotAffected
+		}
+		delete(state.accountPasswords, id)
+		return nil
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete password")
+		return err
+	}
+
+	return nil
+}
+
+func (a memoryAccountPasswordAccessor) UpdateAccountPassword(
+	ctx context.Context,
+	ap AccountPassword,
+) error {
+	if ap.OfAccountId == 0 && ap.HashedString == "" {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ap.OfAccountId))
+	now := time.Now().UTC()
+	err := a.store.write(ctx, a.exec, func(state *memoryState) error {
+		stored, ok := state.accountPasswords[ap.OfAccountId]
+		if !ok {
+			return errMemoryRowNotAffected
+		}
+		stored.HashedString = strings.TrimSpace(ap.HashedString)
+		stored.UpdatedAt = now
+		state.accountPasswords[ap.OfAccountId] = stored
+		return nil
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to update password")
+		return err
+	}
+
+	return nil
+}
+
+func (a memoryAccountPasswordAccessor) UpdateAccountPasswordIfUnchanged(
+	ctx context.Context,
+	ap AccountPassword,
+	previousHashedString string,
+) (bool, error) {
+	if ap.OfAccountId == 0 || ap.HashedString == "" || previousHashedString == "" {
+		return false, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ap.OfAccountId))
+	now := time.Now().UTC()
+	isUpdated := false
+	err := a.store.write(ctx, a.exec, func(state *memoryState) error {
+		isUpdated = false
+		stored, ok := state.accountPasswords[ap.OfAccountId]
+		if !ok || stored.HashedString != strings.TrimSpace(previousHashedString) {
+			return nil
+		}
+		stored.HashedString = strings.TrimSpace(ap.HashedString)
+		stored.UpdatedAt = now
+		state.accountPasswords[ap.OfAccountId] = stored
+		isUpdated = true
+		return nil
+	})
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to update password")
+		return false, err
+	}
+
+	return isUpdated, nil
+}
+
+func (a memoryAccountPasswordAccessor) WithExecutor(
+	exec Executor,
+) AccountPasswordAccessor {
+	return &memoryAccountPasswordAccessor{
+		store:  a.store,
+		exec:   exec,
+		logger: a.logger,
+	}
+}
@@ -0,0 +1,221 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+type RefreshToken struct {
+	Id          uint64     `json:"id"`
+	OfAccountId uint64     `json:"of_account_id"`
+	FamilyId    string     `json:"family_id"`
+	HashedToken string     `json:"hashed_token"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RotatedAt   *time.Time `json:"rotated_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type RefreshTokenAccessor interface {
+	CreateRefreshToken(ctx context.Context, rt RefreshToken) (uint64, error)
+
+	GetRefreshTokenByHashedToken(ctx context.Context, hashedToken string) (RefreshToken, error)
+
+	RotateRefreshToken(ctx context.Context, id uint64, rotatedAt time.Time) error
+
+	RevokeRefreshTokenFamily(ctx context.Context, familyId string, revokedAt time.Time) error
+	RevokeRefreshTokenOfAccount(ctx context.Context, ofAccountId uint64, revokedAt time.Time) error
+
+	DeleteRefreshTokenOfAccount(ctx context.Context, ofAccountId uint64) error
+
+	WithExecutor(exec Executor) RefreshTokenAccessor
+}
+
+type refreshTokenAccessor struct {
+	exec   Executor
+	logger *zap.Logger
+}
+
+func NewRefreshTokenAccessor(
+	exec Executor,
+	logger *zap.Logger,
+) RefreshTokenAccessor {
+	return &refreshTokenAccessor{
+		exec:   newDialectExecutor(exec),
+		logger: logger,
+	}
+}
+
+func (a refreshTokenAccessor) CreateRefreshToken(
+	ctx context.Context,
+	rt RefreshToken,
+) (uint64, error) {
+	if rt.OfAccountId == 0 ||
+		rt.FamilyId == "" ||
+		rt.HashedToken == "" {
+		return 0, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).
+		With(zap.Any("of_account_id", rt.OfAccountId)).
+		With(zap.Any("family_id", rt.FamilyId))
+	const query = `INSERT INTO account_refresh_tokens
+			(of_account_id, family_id, hashed_token, expires_at)
+			VALUES (?, ?, ?, ?)`
+	id, err := insertReturningId(ctx, a.exec, query,
+		rt.OfAccountId,
+		strings.TrimSpace(rt.FamilyId),
+		strings.TrimSpace(rt.HashedToken),
+		rt.ExpiresAt,
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create refresh token")
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (a refreshTokenAccessor) GetRefreshTokenByHashedToken(
+	ctx context.Context,
+	hashedToken string,
+) (RefreshToken, error) {
+	if hashedToken == "" {
+		return RefreshToken{}, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger)
+	const query = `SELECT id, of_account_id, family_id, hashed_token,
+			expires_at, rotated_at, revoked_at, created_at, updated_at
+			FROM account_refresh_tokens WHERE hashed_token = ?`
+	row := a.exec.QueryRowContext(ctx, query, hashedToken)
+
+	var out RefreshToken
+	err := row.Scan(&out.Id,
+		&out.OfAccountId,
+		&out.FamilyId,
+		&out.HashedToken,
+		&out.ExpiresAt,
+		&out.RotatedAt,
+		&out.RevokedAt,
+		&out.CreatedAt,
+		&out.UpdatedAt)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get refresh token by hashed token")
+		return RefreshToken{}, err
+	}
+
+	return out, nil
+}
+
+// Marks an active refresh token as rotated. The update only applies to a token
+// that has been neither rotated nor revoked, so two concurrent rotations of the
+// same token cannot both succeed.
+func (a refreshTokenAccessor) RotateRefreshToken(
+	ctx context.Context,
+	id uint64,
+	rotatedAt time.Time,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("refresh_token_id", id))
+	const query = `UPDATE account_refresh_tokens SET
+			rotated_at = ?
+			WHERE id = ? AND rotated_at IS NULL AND revoked_at IS NULL`
+	result, err := a.exec.ExecContext(ctx, query, rotatedAt, id)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to rotate refresh token")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a refreshTokenAccessor) RevokeRefreshTokenFamily(
+	ctx context.Context,
+	familyId string,
+	revokedAt time.Time,
+) error {
+	if familyId == "" {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("family_id", familyId))
+	const query = `UPDATE account_refresh_tokens SET
+			revoked_at = ?
+			WHERE family_id = ? AND revoked_at IS NULL`
+	_, err := a.exec.ExecContext(ctx, query, revokedAt, familyId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to revoke refresh token family")
+		return err
+	}
+
+	return nil
+}
+
+func (a refreshTokenAccessor) RevokeRefreshTokenOfAccount(
+	ctx context.Context,
+	ofAccountId uint64,
+	revokedAt time.Time,
+) error {
+	if ofAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `UPDATE account_refresh_tokens SET
+			revoked_at = ?
+			WHERE of_account_id = ? AND revoked_at IS NULL`
+	_, err := a.exec.ExecContext(ctx, query, revokedAt, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to revoke refresh tokens of account")
+		return err
+	}
+
+	return nil
+}
+
+func (a refreshTokenAccessor) DeleteRefreshTokenOfAccount(
+	ctx context.Context,
+	ofAccountId uint64,
+) error {
+	if ofAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `DELETE FROM account_refresh_tokens WHERE of_account_id = ?`
+	_, err := a.exec.ExecContext(ctx, query, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete refresh tokens of account")
+		return err
+	}
+
+	return nil
+}
+
+func (a refreshTokenAccessor) WithExecutor(
+	exec Executor,
+) RefreshTokenAccessor {
+	return &refreshTokenAccessor{
+		exec:   withDialectOf(a.exec, exec),
+		logger: a.logger,
+	}
+}
@@ -23,12 +23,16 @@ type accountRoleAccessor struct {
 	logger *zap.Logger
 }
 
+// Holds the roles in memory when given a memory database.
 func NewAccountRoleAccessor(
 	exec Executor,
 	logger *zap.Logger,
 ) AccountRoleAccessor {
+	if store := memoryStoreOf(exec); store != nil {
+		return newMemoryAccountRoleAccessor(store, logger)
+	}
 	return &accountRoleAccessor{
-		exec:   exec,
+		exec:   newDialectExecutor(exec),
 		logger: logger,
 	}
 }
@@ -81,7 +85,7 @@ func (a accountRoleAccessor) WithExecutor(
 	exec Executor,
 ) AccountRoleAccessor {
 	return &accountRoleAccessor{
-		exec:   exec,
+		exec:   withDialectOf(a.exec, exec),
 		logger: a.logger,
 	}
 }
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+// Serves the roles seeded in the store of a memory database, which never
+// change, so transactions make no difference to them.
+type memoryAccountRoleAccessor struct {
+	store  *memoryStore
+	logger *zap.Logger
+}
+
+func newMemoryAccountRoleAccessor(
+	store *memoryStore,
+	logger *zap.Logger,
+) AccountRoleAccessor {
+	return &memoryAccountRoleAccessor{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (a memoryAccountRoleAccessor) GetRoleById(
+	ctx context.Context,
+	id uint8,
+) (AccountRole, error) {
+	if id == 0 {
+		return AccountRole{}, ErrLackOfInfor
+	}
+
+	for _, role := range a.store.roles {
+		if role.Id == id {
+			return role, nil
+		}
+	}
+
+	utils.LoggerWithContext(ctx, a.logger).
+		With(zap.Any("role_id", id)).
+		With(zap.Error(sql.ErrNoRows)).
+		Error("failed to get account row by id")
+	return AccountRole{}, sql.ErrNoRows
+}
+
+func (a memoryAccountRoleAccessor) GetRoleByName(
+	ctx context.Context,
+	name string,
+) (AccountRole, error) {
+	if name == "" {
+		return AccountRole{}, ErrLackOfInfor
+	}
+
+	for _, role := range a.store.roles {
+		if strings.EqualFold(role.Name, name) {
+			return role, nil
+		}
+	}
+
+	utils.LoggerWithContext(ctx, a.logger).
+		With(zap.Any("role_name", name)).
+		With(zap.Error(sql.ErrNoRows)).
+		Error("failed to get account row by name")
+	return AccountRole{}, sql.ErrNoRows
+}
+
+func (a memoryAccountRoleAccessor) WithExecutor(
+	_ Executor,
+) AccountRoleAccessor {
+	return a
+}
@@ -0,0 +1,155 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+// A record of an account moving from one status to another.
+type AccountStatusChange struct {
+	Id           uint64 `json:"id"`
+	OfAccountId  uint64 `json:"of_account_id"`
+	FromStatusId uint8  `json:"from_status_id"`
+	ToStatusId   uint8  `json:"to_status_id"`
+	Reason       string `json:"reason"`
+	// Zero when the service itself made the change.
+	ActorAccountId uint64    `json:"actor_account_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type AccountStatusChangeAccessor interface {
+	CreateAccountStatusChange(ctx context.Context, asc AccountStatusChange) (uint64, error)
+
+	// Lists the changes of the account, oldest first.
+	GetAccountStatusChangeList(ctx context.Context, ofAccountId uint64) ([]AccountStatusChange, error)
+
+	DeleteAccountStatusChangeOfAccount(ctx context.Context, ofAccountId uint64) error
+
+	WithExecutor(exec Executor) AccountStatusChangeAccessor
+}
+
+type accountStatusChangeAccessor struct {
+	exec   Executor
+	logger *zap.Logger
+}
+
+func NewAccountStatusChangeAccessor(
+	exec Executor,
+	logger *zap.Logger,
+) AccountStatusChangeAccessor {
+	return &accountStatusChangeAccessor{
+		exec:   newDialectExecutor(exec),
+		logger: logger,
+	}
+}
+
+func (a accountStatusChangeAccessor) CreateAccountStatusChange(
+	ctx context.Context,
+	asc AccountStatusChange,
+) (uint64, error) {
+	if asc.OfAccountId == 0 || asc.ToStatusId == 0 {
+		return 0, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", asc.OfAccountId))
+	actorAccountId := sql.NullInt64{
+		Int64: int64(asc.ActorAccountId),
+		Valid: asc.ActorAccountId != 0,
+	}
+	const query = `INSERT INTO account_status_changes
+			(of_account_id, from_status_id, to_status_id, reason, actor_account_id)
+			VALUES (?, ?, ?, ?, ?)`
+	id, err := insertReturningId(ctx, a.exec, query,
+		asc.OfAccountId,
+		asc.FromStatusId,
+		asc.ToStatusId,
+		strings.TrimSpace(asc.Reason),
+		actorAccountId,
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create account status change")
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (a accountStatusChangeAccessor) GetAccountStatusChangeList(
+	ctx context.Context,
+	ofAccountId uint64,
+) ([]AccountStatusChange, error) {
+	if ofAccountId == 0 {
+		return nil, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `SELECT id, of_account_id, from_status_id, to_status_id, reason,
+			actor_account_id, created_at
+			FROM account_status_changes
+			WHERE of_account_id = ?
+			ORDER BY id`
+	rows, err := a.exec.QueryContext(ctx, query, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get account status changes")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []AccountStatusChange
+	for rows.Next() {
+		var asc AccountStatusChange
+		var actorAccountId sql.NullInt64
+		err := rows.Scan(&asc.Id,
+			&asc.OfAccountId,
+			&asc.FromStatusId,
+			&asc.ToStatusId,
+			&asc.Reason,
+			&actorAccountId,
+			&asc.CreatedAt)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("failed to scan account status change")
+			return nil, err
+		}
+		asc.ActorAccountId = uint64(actorAccountId.Int64)
+		out = append(out, asc)
+	}
+	if err := rows.Err(); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get account status changes")
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (a accountStatusChangeAccessor) DeleteAccountStatusChangeOfAccount(
+	ctx context.Context,
+	ofAccountId uint64,
+) error {
+	if ofAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `DELETE FROM account_status_changes WHERE of_account_id = ?`
+	_, err := a.exec.ExecContext(ctx, query, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete account status changes of account")
+		return err
+	}
+
+	return nil
+}
+
+func (a accountStatusChangeAccessor) WithExecutor(
+	exec Executor,
+) AccountStatusChangeAccessor {
+	return &accountStatusChangeAccessor{
+		exec:   withDialectOf(a.exec, exec),
+		logger: a.logger,
+	}
+}
@@ -0,0 +1,206 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+type AccountTOTP struct {
+	OfAccountId     uint64     `json:"of_account_id"`
+	EncryptedSecret string     `json:"encrypted_secret"`
+	ConfirmedAt     *time.Time `json:"confirmed_at"`
+	LastUsedStep    int64      `json:"last_used_step"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type AccountTOTPAccessor interface {
+	CreateAccountTOTP(ctx context.Context, at AccountTOTP) error
+
+	GetAccountTOTP(ctx context.Context, ofAccountId uint64) (AccountTOTP, error)
+
+	ConfirmAccountTOTP(ctx context.Context, ofAccountId uint64, confirmedAt time.Time) error
+	// Records the time step of an accepted code. The update only applies to a
+	// later step than the last one, so a code cannot be replayed.
+	UseAccountTOTPStep(ctx context.Context, ofAccountId uint64, step int64) error
+
+	DeleteAccountTOTP(ctx context.Context, ofAccountId uint64) error
+
+	WithExecutor(exec Executor) AccountTOTPAccessor
+}
+
+type accountTOTPAccessor struct {
+	exec   Executor
+	logger *zap.Logger
+}
+
+func NewAccountTOTPAccessor(
+	exec Executor,
+	logger *zap.Logger,
+) AccountTOTPAccessor {
+	return &accountTOTPAccessor{
+		exec:   newDialectExecutor(exec),
+		logger: logger,
+	}
+}
+
+func (a accountTOTPAccessor) CreateAccountTOTP(
+	ctx context.Context,
+	at AccountTOTP,
+) error {
+	if at.OfAccountId == 0 || at.EncryptedSecret == "" {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", at.OfAccountId))
+	const query = `INSERT INTO account_totp
+			(of_account_id, encrypted_secret)
+			VALUES (?, ?)`
+	result, err := a.exec.ExecContext(ctx, query,
+		at.OfAccountId,
+		strings.TrimSpace(at.EncryptedSecret),
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create account totp")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+// Returns sql.ErrNoRows without logging it, since most accounts have no TOTP.
+func (a accountTOTPAccessor) GetAccountTOTP(
+	ctx context.Context,
+	ofAccountId uint64,
+) (AccountTOTP, error) {
+	if ofAccountId == 0 {
+		return AccountTOTP{}, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `SELECT of_account_id, encrypted_secret, confirmed_at,
+			last_used_step, created_at, updated_at
+			FROM account_totp WHERE of_account_id = ?`
+	row := a.exec.QueryRowContext(ctx, query, ofAccountId)
+
+	var out AccountTOTP
+	err := row.Scan(&out.OfAccountId,
+		&out.EncryptedSecret,
+		&out.ConfirmedAt,
+		&out.LastUsedStep,
+		&out.CreatedAt,
+		&out.UpdatedAt)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.With(zap.Error(err)).Error("failed to get account totp")
+		}
+		return AccountTOTP{}, err
+	}
+
+	return out, nil
+}
+
+func (a accountTOTPAccessor) ConfirmAccountTOTP(
+	ctx context.Context,
+	ofAccountId uint64,
+	confirmedAt time.Time,
+) error {
+	if ofAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `UPDATE account_totp SET
+			confirmed_at = ?
+			WHERE of_account_id = ? AND confirmed_at IS NULL`
+	result, err := a.exec.ExecContext(ctx, query, confirmedAt, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to confirm account totp")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a accountTOTPAccessor) UseAccountTOTPStep(
+	ctx context.Context,
+	ofAccountId uint64,
+	step int64,
+) error {
+	if ofAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `UPDATE account_totp SET
+			last_used_step = ?
+			WHERE of_account_id = ? AND last_used_step < ?`
+	result, err := a.exec.ExecContext(ctx, query, step, ofAccountId, step)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to use account totp step")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a accountTOTPAccessor) DeleteAccountTOTP(
+	ctx context.Context,
+	ofAccountId uint64,
+) error {
+	if ofAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `DELETE FROM account_totp WHERE of_account_id = ?`
+	_, err := a.exec.ExecContext(ctx, query, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete account totp")
+		return err
+	}
+
+	return nil
+}
+
+func (a accountTOTPAccessor) WithExecutor(
+	exec Executor,
+) AccountTOTPAccessor {
+	return &accountTOTPAccessor{
+		exec:   withDialectOf(a.exec, exec),
+		logger: a.logger,
+	}
+}
@@ -5,14 +5,24 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
 
 	"github.com/Fiagram/account_service/internal/configs"
 	"go.uber.org/zap"
 
 	_ "github.com/go-sql-driver/mysql" // Import MySQL driver
+	_ "github.com/jackc/pgx/v5/stdlib" // Import Postgres driver
+	_ "modernc.org/sqlite"             // Import SQLite driver
 )
 
-var ErrLackOfInfor = errors.New("lack of information")
+var (
+	ErrLackOfInfor = errors.New("lack of information")
+	// Only the account, account password and account role accessors hold
+	// their data in a memory database, which is too few to run the service.
+	ErrDatabaseTypeTestOnly = errors.New("the memory database type is for tests only")
+)
 
 type Executor interface {
 	Exec(query string, args ...any) (sql.Result, error)
@@ -28,16 +38,56 @@ type Executor interface {
 	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
 }
 
-func InitAndMigrateUpDatabase(databaseConfig configs.Database, logger *zap.Logger) (*sql.DB, func(), error) {
-	connectionString := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true",
-		databaseConfig.Username,
-		databaseConfig.Password,
-		databaseConfig.Host,
-		databaseConfig.Port,
-		databaseConfig.Database,
-	)
-
-	db, err := sql.Open("mysql", connectionString)
+// Returns the driver and the data source name for the database type, which
+// is MySQL when left empty.
+func driverAndDataSourceOf(databaseConfig configs.Database) (string, string, error) {
+	switch configs.DatatabaseType(databaseConfig.Type) {
+	case configs.DatabaseTypeMySql, "":
+		return "mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true",
+			databaseConfig.Username,
+			databaseConfig.Password,
+			databaseConfig.Host,
+			databaseConfig.Port,
+			databaseConfig.Database,
+		), nil
+	case configs.DatabaseTypePostgres:
+		dataSource := url.URL{
+			Scheme: "postgres",
+			User:   url.UserPassword(databaseConfig.Username, databaseConfig.Password),
+			Host:   net.JoinHostPort(databaseConfig.Host, strconv.Itoa(databaseConfig.Port)),
+			Path:   databaseConfig.Database,
+		}
+		return "pgx", dataSource.String(), nil
+	case configs.DatabaseTypeSqlite:
+		// Writers wait on each other instead of failing, and transactions
+		// take the write lock up front, which SQLite cannot upgrade to
+		// without failing when another writer holds it
+		return "sqlite", "file:" + databaseConfig.Database +
+			"?_pragma=foreign_keys(1)" +
+			"&_pragma=busy_timeout(10000)" +
+			"&_pragma=journal_mode(WAL)" +
+			"&_txlock=immediate", nil
+	default:
+		return "", "", fmt.Errorf("unsupported database type %q", databaseConfig.Type)
+	}
+}
+
+// Connects to the database, leaving its schema as it is.
+func InitDatabase(databaseConfig configs.Database, logger *zap.Logger) (*sql.DB, func(), error) {
+	if configs.DatatabaseType(databaseConfig.Type) == configs.DatabaseTypeMemory {
+		db := openMemoryDatabase()
+		return db, func() {
+			db.Close()
+		}, nil
+	}
+
+	driverName, dataSourceName, err := driverAndDataSourceOf(databaseConfig)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed when connecting the database")
+		return nil, nil, err
+	}
+
+	db, err := sql.Open(driverName, dataSourceName)
 	if err != nil {
 		logger.With(zap.Error(err)).Error("failed when connecting the database")
 		return nil, nil, err
@@ -47,6 +97,15 @@ func InitAndMigrateUpDatabase(databaseConfig configs.Database, logger *zap.Logge
 		db.Close()
 	}
 
+	return db, cleanupDb, nil
+}
+
+func InitAndMigrateUpDatabase(databaseConfig configs.Database, logger *zap.Logger) (*sql.DB, func(), error) {
+	db, cleanupDb, err := InitDatabase(databaseConfig, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	migrator := NewMigrator(db, logger)
 	err = migrator.Up(context.Background())
 	if err != nil {
@@ -57,3 +116,30 @@ func InitAndMigrateUpDatabase(databaseConfig configs.Database, logger *zap.Logge
 
 	return db, cleanupDb, nil
 }
+
+// Migrates the schema up when auto migration is on, and otherwise refuses a
+// schema that is not at the version of the binary. Refuses a memory database,
+// which cannot serve.
+func InitAndCheckDatabase(databaseConfig configs.Database, logger *zap.Logger) (*sql.DB, func(), error) {
+	if configs.DatatabaseType(databaseConfig.Type) == configs.DatabaseTypeMemory {
+		logger.With(zap.Error(ErrDatabaseTypeTestOnly)).Error("failed when connecting the database")
+		return nil, nil, ErrDatabaseTypeTestOnly
+	}
+	if databaseConfig.AutoMigrate {
+		return InitAndMigrateUpDatabase(databaseConfig, logger)
+	}
+
+	db, cleanupDb, err := InitDatabase(databaseConfig, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	migrator := NewMigrator(db, logger)
+	err = migrator.CheckSchema(context.Background())
+	if err != nil {
+		cleanupDb()
+		return nil, nil, err
+	}
+
+	return db, cleanupDb, nil
+}
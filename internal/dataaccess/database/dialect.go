@@ -0,0 +1,186 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"modernc.org/sqlite"
+)
+
+// The SQL dialect of a database. Queries are written for MySQL, with ?
+// placeholders, and rewritten for the others where they differ.
+type dialect uint8
+
+const (
+	dialectMySQL dialect = iota
+	dialectPostgres
+	dialectSQLite
+	// Runs no SQL at all, see memoryDriver.
+	dialectMemory
+)
+
+func dialectOfDB(db *sql.DB) dialect {
+	switch db.Driver().(type) {
+	case *stdlib.Driver:
+		return dialectPostgres
+	case *sqlite.Driver:
+		return dialectSQLite
+	case *memoryDriver:
+		return dialectMemory
+	default:
+		return dialectMySQL
+	}
+}
+
+// Rewrites the ? placeholders for the dialect, leaving the ones in quoted
+// literals alone.
+func (d dialect) rebind(query string) string {
+	if d != dialectPostgres {
+		return query
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(query) + 8)
+	n := 0
+	isQuoted := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'':
+			isQuoted = !isQuoted
+			sb.WriteByte(c)
+		case c == '?' && !isQuoted:
+			n++
+			sb.WriteByte('$')
+			sb.WriteString(strconv.Itoa(n))
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// The layout of CURRENT_TIMESTAMP in SQLite, which keeps times as text.
+const sqliteTimeLayout = "2006-01-02 15:04:05.999999999"
+
+// Converts the arguments for the dialect. SQLite compares times as text, so
+// they are written in UTC the way its CURRENT_TIMESTAMP is.
+func (d dialect) bind(args []any) []any {
+	if d != dialectSQLite {
+		return args
+	}
+
+	bound := make([]any, len(args))
+	for i, arg := range args {
+		switch t := arg.(type) {
+		case time.Time:
+			bound[i] = t.UTC().Format(sqliteTimeLayout)
+		case *time.Time:
+			if t != nil {
+				bound[i] = t.UTC().Format(sqliteTimeLayout)
+			}
+		default:
+			bound[i] = arg
+		}
+	}
+	return bound
+}
+
+// An executor running queries written for MySQL, and their arguments, on
+// the dialect of the database behind it. A transaction does not tell its driver, so the
+// accessors hand theirs down to the executors they are given.
+type dialectExecutor struct {
+	exec    Executor
+	dialect dialect
+}
+
+// Wraps the executor of a new accessor, whose dialect is told by the driver
+// when it is a database.
+func newDialectExecutor(exec Executor) Executor {
+	d := dialectMySQL
+	if db, ok := exec.(*sql.DB); ok && db != nil {
+		d = dialectOfDB(db)
+	}
+	return &dialectExecutor{exec: exec, dialect: d}
+}
+
+// Wraps the executor given to an accessor, in the dialect of the one it had.
+func withDialectOf(parent Executor, exec Executor) Executor {
+	if _, ok := exec.(*dialectExecutor); ok {
+		return exec
+	}
+	return &dialectExecutor{exec: exec, dialect: dialectOf(parent)}
+}
+
+func dialectOf(exec Executor) dialect {
+	if e, ok := exec.(*dialectExecutor); ok {
+		return e.dialect
+	}
+	return dialectMySQL
+}
+
+func (e *dialectExecutor) Exec(query string, args ...any) (sql.Result, error) {
+	return e.exec.Exec(e.dialect.rebind(query), e.dialect.bind(args)...)
+}
+
+func (e *dialectExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return e.exec.ExecContext(ctx, e.dialect.rebind(query), e.dialect.bind(args)...)
+}
+
+func (e *dialectExecutor) Query(query string, args ...any) (*sql.Rows, error) {
+	return e.exec.Query(e.dialect.rebind(query), e.dialect.bind(args)...)
+}
+
+func (e *dialectExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return e.exec.QueryContext(ctx, e.dialect.rebind(query), e.dialect.bind(args)...)
+}
+
+func (e *dialectExecutor) QueryRow(query string, args ...any) *sql.Row {
+	return e.exec.QueryRow(e.dialect.rebind(query), e.dialect.bind(args)...)
+}
+
+func (e *dialectExecutor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return e.exec.QueryRowContext(ctx, e.dialect.rebind(query), e.dialect.bind(args)...)
+}
+
+func (e *dialectExecutor) Prepare(query string) (*sql.Stmt, error) {
+	return e.exec.Prepare(e.dialect.rebind(query))
+}
+
+func (e *dialectExecutor) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return e.exec.PrepareContext(ctx, e.dialect.rebind(query))
+}
+
+// Runs an insert of one row and returns its id. Postgres has no last insert
+// id, so the id is returned by the insert itself there.
+func insertReturningId(ctx context.Context, exec Executor, query string, args ...any) (uint64, error) {
+	if dialectOf(exec) == dialectPostgres {
+		var id uint64
+		err := exec.QueryRowContext(ctx, query+` RETURNING id`, args...).Scan(&id)
+		return id, err
+	}
+
+	result, err := exec.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if rowEfNum != 1 {
+		return 0, errors.New("failed to effect row")
+	}
+
+	lastInsertedId, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(lastInsertedId), nil
+}
@@ -0,0 +1,189 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+// A login that passed the password check and waits for its second factor.
+type LoginChallenge struct {
+	Id              uint64     `json:"id"`
+	OfAccountId     uint64     `json:"of_account_id"`
+	HashedChallenge string     `json:"hashed_challenge"`
+	FailedAttempts  uint32     `json:"failed_attempts"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	UsedAt          *time.Time `json:"used_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type LoginChallengeAccessor interface {
+	CreateLoginChallenge(ctx context.Context, lc LoginChallenge) (uint64, error)
+
+	GetLoginChallengeByHashedChallenge(ctx context.Context, hashedChallenge string) (LoginChallenge, error)
+
+	IncreaseLoginChallengeFailedAttempts(ctx context.Context, id uint64) error
+	UseLoginChallenge(ctx context.Context, id uint64, usedAt time.Time) error
+
+	DeleteLoginChallengeOfAccount(ctx context.Context, ofAccountId uint64) error
+
+	WithExecutor(exec Executor) LoginChallengeAccessor
+}
+
+type loginChallengeAccessor struct {
+	exec   Executor
+	logger *zap.Logger
+}
+
+func NewLoginChallengeAccessor(
+	exec Executor,
+	logger *zap.Logger,
+) LoginChallengeAccessor {
+	return &loginChallengeAccessor{
+		exec:   newDialectExecutor(exec),
+		logger: logger,
+	}
+}
+
+func (a loginChallengeAccessor) CreateLoginChallenge(
+	ctx context.Context,
+	lc LoginChallenge,
+) (uint64, error) {
+	if lc.OfAccountId == 0 || lc.HashedChallenge == "" {
+		return 0, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", lc.OfAccountId))
+	const query = `INSERT INTO login_challenges
+			(of_account_id, hashed_challenge, expires_at)
+			VALUES (?, ?, ?)`
+	id, err := insertReturningId(ctx, a.exec, query,
+		lc.OfAccountId,
+		strings.TrimSpace(lc.HashedChallenge),
+		lc.ExpiresAt,
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create login challenge")
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (a loginChallengeAccessor) GetLoginChallengeByHashedChallenge(
+	ctx context.Context,
+	hashedChallenge string,
+) (LoginChallenge, error) {
+	if hashedChallenge == "" {
+		return LoginChallenge{}, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger)
+	const query = `SELECT id, of_account_id, hashed_challenge, failed_attempts,
+			expires_at, used_at, created_at, updated_at
+			FROM login_challenges WHERE hashed_challenge = ?`
+	row := a.exec.QueryRowContext(ctx, query, hashedChallenge)
+
+	var out LoginChallenge
+	err := row.Scan(&out.Id,
+		&out.OfAccountId,
+		&out.HashedChallenge,
+		&out.FailedAttempts,
+		&out.ExpiresAt,
+		&out.UsedAt,
+		&out.CreatedAt,
+		&out.UpdatedAt)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get login challenge by hashed challenge")
+		return LoginChallenge{}, err
+	}
+
+	return out, nil
+}
+
+func (a loginChallengeAccessor) IncreaseLoginChallengeFailedAttempts(
+	ctx context.Context,
+	id uint64,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("login_challenge_id", id))
+	const query = `UPDATE login_challenges SET
+			failed_attempts = failed_attempts + 1
+			WHERE id = ?`
+	_, err := a.exec.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to increase login challenge failed attempts")
+		return err
+	}
+
+	return nil
+}
+
+// Marks an unused challenge as used. The update only applies to a challenge
+// that has not been used yet, so one challenge completes one login.
+func (a loginChallengeAccessor) UseLoginChallenge(
+	ctx context.Context,
+	id uint64,
+	usedAt time.Time,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("login_challenge_id", id))
+	const query = `UPDATE login_challenges SET
+			used_at = ?
+			WHERE id = ? AND used_at IS NULL`
+	result, err := a.exec.ExecContext(ctx, query, usedAt, id)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to use login challenge")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a loginChallengeAccessor) DeleteLoginChallengeOfAccount(
+	ctx context.Context,
+	ofAccountId uint64,
+) error {
+	if ofAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `DELETE FROM login_challenges WHERE of_account_id = ?`
+	_, err := a.exec.ExecContext(ctx, query, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete login challenges of account")
+		return err
+	}
+
+	return nil
+}
+
+func (a loginChallengeAccessor) WithExecutor(
+	exec Executor,
+) LoginChallengeAccessor {
+	return &loginChallengeAccessor{
+		exec:   withDialectOf(a.exec, exec),
+		logger: a.logger,
+	}
+}
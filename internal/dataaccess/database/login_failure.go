@@ -0,0 +1,285 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+type LoginFailureScope string
+
+const (
+	LoginFailureScopeUsername LoginFailureScope = "username"
+	LoginFailureScopeAddress  LoginFailureScope = "address"
+)
+
+type LoginFailure struct {
+	Scope        LoginFailureScope `json:"scope"`
+	Identifier   string            `json:"identifier"`
+	FailureCount uint32            `json:"failure_count"`
+	LastFailedAt time.Time         `json:"last_failed_at"`
+	LockedUntil  *time.Time        `json:"locked_until"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+type LoginFailureAccessor interface {
+	CreateLoginFailure(ctx context.Context, lf LoginFailure) error
+	GetLoginFailure(ctx context.Context, scope LoginFailureScope, identifier string) (LoginFailure, error)
+	UpdateLoginFailure(ctx context.Context, lf LoginFailure) error
+	// Counts one more failure at failedAt in a single statement, starting over
+	// from one when resetBefore is set and the unlocked row last failed before
+	// it. Returns the row as it is afterwards.
+	IncrementLoginFailure(ctx context.Context, scope LoginFailureScope, identifier string,
+		failedAt time.Time, resetBefore *time.Time) (LoginFailure, error)
+	// Locks the row until lockedUntil unless it is still locked at now, and
+	// starts the count over, so only failures since the lock count towards
+	// the next one. Returns whether it was this call that locked it.
+	LockLoginFailure(ctx context.Context, scope LoginFailureScope, identifier string,
+		lockedUntil time.Time, now time.Time) (bool, error)
+	DeleteLoginFailure(ctx context.Context, scope LoginFailureScope, identifier string) error
+	WithExecutor(exec Executor) LoginFailureAccessor
+}
+
+type loginFailureAccessor struct {
+	exec   Executor
+	logger *zap.Logger
+}
+
+func NewLoginFailureAccessor(
+	exec Executor,
+	logger *zap.Logger,
+) LoginFailureAccessor {
+	return &loginFailureAccessor{
+		exec:   newDialectExecutor(exec),
+		logger: logger,
+	}
+}
+
+func (a loginFailureAccessor) CreateLoginFailure(
+	ctx context.Context,
+	lf LoginFailure,
+) error {
+	if lf.Scope == "" || lf.Identifier == "" {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).
+		With(zap.Any("scope", lf.Scope)).
+		With(zap.Any("identifier", lf.Identifier))
+	const query = `INSERT INTO login_failures
+			(scope, identifier, failure_count, last_failed_at, locked_until)
+			VALUES (?, ?, ?, ?, ?)`
+	result, err := a.exec.ExecContext(ctx, query,
+		lf.Scope,
+		lf.Identifier,
+		lf.FailureCount,
+		lf.LastFailedAt,
+		lf.LockedUntil,
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create login failure")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a loginFailureAccessor) GetLoginFailure(
+	ctx context.Context,
+	scope LoginFailureScope,
+	identifier string,
+) (LoginFailure, error) {
+	if scope == "" || identifier == "" {
+		return LoginFailure{}, ErrLackOfInfor
+	}
+
+	const query = `SELECT scope, identifier, failure_count, last_failed_at,
+			locked_until, created_at, updated_at
+			FROM login_failures WHERE scope = ? AND identifier = ?`
+	row := a.exec.QueryRowContext(ctx, query, scope, identifier)
+
+	var out LoginFailure
+	err := row.Scan(&out.Scope,
+		&out.Identifier,
+		&out.FailureCount,
+		&out.LastFailedAt,
+		&out.LockedUntil,
+		&out.CreatedAt,
+		&out.UpdatedAt)
+	if err != nil {
+		// A missing row is the common case for a login without past failures,
+		// so it is left to the caller to decide whether it is worth logging.
+		return LoginFailure{}, err
+	}
+
+	return out, nil
+}
+
+func (a loginFailureAccessor) UpdateLoginFailure(
+	ctx context.Context,
+	lf LoginFailure,
+) error {
+	if lf.Scope == "" || lf.Identifier == "" {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).
+		With(zap.Any("scope", lf.Scope)).
+		With(zap.Any("identifier", lf.Identifier))
+	const query = `UPDATE login_failures SET
+			failure_count = ?,
+			last_failed_at = ?,
+			locked_until = ?
+			WHERE scope = ? AND identifier = ?`
+	result, err := a.exec.ExecContext(ctx, query,
+		lf.FailureCount,
+		lf.LastFailedAt,
+		lf.LockedUntil,
+		lf.Scope,
+		lf.Identifier,
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to update login failure")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a loginFailureAccessor) IncrementLoginFailure(
+	ctx context.Context,
+	scope LoginFailureScope,
+	identifier string,
+	failedAt time.Time,
+	resetBefore *time.Time,
+) (LoginFailure, error) {
+	if scope == "" || identifier == "" {
+		return LoginFailure{}, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).
+		With(zap.Any("scope", scope)).
+		With(zap.Any("identifier", identifier))
+
+	// MySQL assigns from left to right on the values already assigned, so
+	// last_failed_at goes last for the reset to see the one before.
+	args := []any{scope, identifier, failedAt}
+	var assignments []string
+	if resetBefore != nil {
+		const isReset = `(locked_until IS NULL OR locked_until <= ?) AND last_failed_at < ?`
+		assignments = append(assignments,
+			`failure_count = CASE WHEN `+isReset+` THEN 1 ELSE failure_count + 1 END`,
+			`locked_until = CASE WHEN `+isReset+` THEN NULL ELSE locked_until END`)
+		args = append(args, failedAt, *resetBefore, failedAt, *resetBefore)
+	} else {
+		assignments = append(assignments, `failure_count = failure_count + 1`)
+	}
+	assignments = append(assignments, `last_failed_at = ?`)
+	args = append(args, failedAt)
+
+	onConflict := `ON CONFLICT (scope, identifier) DO UPDATE SET`
+	if dialectOf(a.exec) == dialectMySQL {
+		onConflict = `ON DUPLICATE KEY UPDATE`
+	}
+	query := `INSERT INTO login_failures
+			(scope, identifier, failure_count, last_failed_at)
+			VALUES (?, ?, 1, ?) ` + onConflict + ` ` + strings.Join(assignments, ", ")
+	if _, err := a.exec.ExecContext(ctx, query, args...); err != nil {
+		logger.With(zap.Error(err)).Error("failed to increment login failure")
+		return LoginFailure{}, err
+	}
+
+	out, err := a.GetLoginFailure(ctx, scope, identifier)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get incremented login failure")
+		return LoginFailure{}, err
+	}
+
+	return out, nil
+}
+
+func (a loginFailureAccessor) LockLoginFailure(
+	ctx context.Context,
+	scope LoginFailureScope,
+	identifier string,
+	lockedUntil time.Time,
+	now time.Time,
+) (bool, error) {
+	if scope == "" || identifier == "" {
+		return false, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).
+		With(zap.Any("scope", scope)).
+		With(zap.Any("identifier", identifier))
+	const query = `UPDATE login_failures SET locked_until = ?, failure_count = 0
+			WHERE scope = ? AND identifier = ?
+			AND (locked_until IS NULL OR locked_until <= ?)`
+	result, err := a.exec.ExecContext(ctx, query, lockedUntil, scope, identifier, now)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to lock login failure")
+		return false, err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to lock login failure")
+		return false, err
+	}
+
+	return rowEfNum == 1, nil
+}
+
+func (a loginFailureAccessor) DeleteLoginFailure(
+	ctx context.Context,
+	scope LoginFailureScope,
+	identifier string,
+) error {
+	if scope == "" || identifier == "" {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).
+		With(zap.Any("scope", scope)).
+		With(zap.Any("identifier", identifier))
+	const query = `DELETE FROM login_failures WHERE scope = ? AND identifier = ?`
+	_, err := a.exec.ExecContext(ctx, query, scope, identifier)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete login failure")
+		return err
+	}
+
+	return nil
+}
+
+func (a loginFailureAccessor) WithExecutor(
+	exec Executor,
+) LoginFailureAccessor {
+	return &loginFailureAccessor{
+		exec:   withDialectOf(a.exec, exec),
+		logger: a.logger,
+	}
+}
@@ -0,0 +1,301 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	errMemoryNoSQL           = errors.New("the memory database runs no SQL, only the account, account password and account role accessors hold their data in memory")
+	errMemoryRowNotAffected  = errors.New("failed to effect row")
+	errMemoryDuplicateKey    = errors.New("duplicate key")
+	errMemoryForeignKey      = errors.New("foreign key constraint fails")
+	errMemoryTxNotResolvable = errors.New("executor is not of the memory database")
+)
+
+// Lets a memory accessor ask the connection behind a *sql.Tx for the
+// transaction it runs, which database/sql does not tell.
+const memoryTxQuery = "memory: transaction"
+
+// The tables the memory accessors hold.
+type memoryState struct {
+	accounts            map[uint64]Account
+	accountIdByUsername map[string]uint64
+	accountPasswords    map[uint64]AccountPassword
+}
+
+func newMemoryState() memoryState {
+	return memoryState{
+		accounts:            map[uint64]Account{},
+		accountIdByUsername: map[string]uint64{},
+		accountPasswords:    map[uint64]AccountPassword{},
+	}
+}
+
+func (s memoryState) clone() memoryState {
+	out := memoryState{
+		accounts:            make(map[uint64]Account, len(s.accounts)),
+		accountIdByUsername: make(map[string]uint64, len(s.accountIdByUsername)),
+		accountPasswords:    make(map[uint64]AccountPassword, len(s.accountPasswords)),
+	}
+	for id, acc := range s.accounts {
+		out.accounts[id] = acc
+	}
+	for username, id := range s.accountIdByUsername {
+		out.accountIdByUsername[username] = id
+	}
+	for id, ap := range s.accountPasswords {
+		out.accountPasswords[id] = ap
+	}
+	return out
+}
+
+// Usernames compare case-insensitively, as they do in MySQL.
+func memoryUsernameKey(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// Changes the state, or leaves it as it is when failing.
+type memoryChange func(state *memoryState) error
+
+// Holds the data of a memory database. Changes made outside a transaction
+// apply at once, under the lock.
+type memoryStore struct {
+	mutex sync.RWMutex
+	state memoryState
+	// Ids are not given back on rollback, as with AUTO_INCREMENT.
+	lastAccountId atomic.Uint64
+	roles         []AccountRole
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		state: newMemoryState(),
+		roles: []AccountRole{
+			{Id: 0, Name: "none"},
+			{Id: 1, Name: "admin"},
+			{Id: 2, Name: "member"},
+		},
+	}
+}
+
+// Returns the transaction the executor runs, nil for the database itself.
+func (s *memoryStore) txOf(ctx context.Context, exec Executor) (*memoryTx, error) {
+	if _, ok := exec.(*sql.DB); ok {
+		return nil, nil
+	}
+
+	var tx *memoryTx
+	if _, err := exec.ExecContext(ctx, memoryTxQuery, &tx); err != nil {
+		return nil, errors.Join(errMemoryTxNotResolvable, err)
+	}
+	if tx != nil && tx.store != s {
+		return nil, errMemoryTxNotResolvable
+	}
+	return tx, nil
+}
+
+// Calls fn with the state the executor sees, which fn must not change.
+func (s *memoryStore) read(ctx context.Context, exec Executor, fn func(state memoryState) error) error {
+	tx, err := s.txOf(ctx, exec)
+	if err != nil {
+		return err
+	}
+	if tx != nil {
+		return tx.read(fn)
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return fn(s.state)
+}
+
+// Applies the change at once, or within the transaction the executor runs.
+func (s *memoryStore) write(ctx context.Context, exec Executor, change memoryChange) error {
+	tx, err := s.txOf(ctx, exec)
+	if err != nil {
+		return err
+	}
+	if tx != nil {
+		return tx.write(change)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return change(&s.state)
+}
+
+// Works on a copy of the state taken when first used, and replays its
+// changes on the state of the store when committed. A change failing on
+// replay, as another transaction committed first, fails the commit.
+type memoryTx struct {
+	store   *memoryStore
+	mutex   sync.Mutex
+	state   *memoryState
+	changes []memoryChange
+}
+
+func (t *memoryTx) stateLocked() *memoryState {
+	if t.state == nil {
+		t.store.mutex.RLock()
+		state := t.store.state.clone()
+		t.store.mutex.RUnlock()
+		t.state = &state
+	}
+	return t.state
+}
+
+func (t *memoryTx) read(fn func(state memoryState) error) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return fn(*t.stateLocked())
+}
+
+func (t *memoryTx) write(change memoryChange) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if err := change(t.stateLocked()); err != nil {
+		return err
+	}
+	t.changes = append(t.changes, change)
+	return nil
+}
+
+func (t *memoryTx) commit() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(t.changes) == 0 {
+		return nil
+	}
+
+	t.store.mutex.Lock()
+	defer t.store.mutex.Unlock()
+	state := t.store.state.clone()
+	for _, change := range t.changes {
+		if err := change(&state); err != nil {
+			return err
+		}
+	}
+	t.store.state = state
+	return nil
+}
+
+// Backs a *sql.DB whose connections only open and close transactions on the
+// store, for the memory accessors to work in.
+type memoryDriver struct {
+	store *memoryStore
+}
+
+func (d *memoryDriver) Open(_ string) (driver.Conn, error) {
+	return &memoryConn{store: d.store}, nil
+}
+
+type memoryConnector struct {
+	driver *memoryDriver
+}
+
+func (c memoryConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return c.driver.Open("")
+}
+
+func (c memoryConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// Opens a database holding its data in memory, empty but for the roles.
+func openMemoryDatabase() *sql.DB {
+	return sql.OpenDB(memoryConnector{driver: &memoryDriver{store: newMemoryStore()}})
+}
+
+// Returns the store of a memory database, nil for any other executor.
+func memoryStoreOf(exec Executor) *memoryStore {
+	db, ok := exec.(*sql.DB)
+	if !ok || db == nil {
+		return nil
+	}
+	if d, ok := db.Driver().(*memoryDriver); ok {
+		return d.store
+	}
+	return nil
+}
+
+type memoryConn struct {
+	store *memoryStore
+	tx    *memoryTx
+}
+
+type memoryDriverTx struct {
+	conn *memoryConn
+}
+
+func (c *memoryConn) Prepare(_ string) (driver.Stmt, error) {
+	return nil, errMemoryNoSQL
+}
+
+func (c *memoryConn) Close() error {
+	return nil
+}
+
+func (c *memoryConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *memoryConn) BeginTx(_ context.Context, _ driver.TxOptions) (driver.Tx, error) {
+	c.tx = &memoryTx{store: c.store}
+	return memoryDriverTx{conn: c}, nil
+}
+
+// Answers the memory accessors asking for the transaction of the connection,
+// and fails any other statement.
+func (c *memoryConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if query != memoryTxQuery || len(args) != 1 {
+		return nil, errMemoryNoSQL
+	}
+	tx, ok := args[0].Value.(**memoryTx)
+	if !ok {
+		return nil, errMemoryNoSQL
+	}
+	*tx = c.tx
+	return driver.RowsAffected(0), nil
+}
+
+func (c *memoryConn) CheckNamedValue(value *driver.NamedValue) error {
+	if _, ok := value.Value.(**memoryTx); ok {
+		return nil
+	}
+	return driver.ErrSkip
+}
+
+func (t memoryDriverTx) Commit() error {
+	tx := t.conn.tx
+	t.conn.tx = nil
+	return tx.commit()
+}
+
+func (t memoryDriverTx) Rollback() error {
+	t.conn.tx = nil
+	return nil
+}
+
+// Copies the times an account points to, so the stored one shares nothing
+// with the callers.
+func (acc Account) clone() Account {
+	acc.EmailVerifiedAt = cloneTime(acc.EmailVerifiedAt)
+	acc.PhoneVerifiedAt = cloneTime(acc.PhoneVerifiedAt)
+	acc.DeletedAt = cloneTime(acc.DeletedAt)
+	return acc
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	out := *t
+	return &out
+}
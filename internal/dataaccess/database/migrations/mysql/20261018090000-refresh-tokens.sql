@@ -0,0 +1,32 @@
+-- +migrate Up
+DROP TABLE IF EXISTS account_refresh_tokens;
+
+CREATE TABLE IF NOT EXISTS account_refresh_tokens (
+    id BIGINT UNSIGNED AUTO_INCREMENT,
+    of_account_id BIGINT UNSIGNED NOT NULL,
+    family_id VARCHAR(64) NOT NULL,
+    hashed_token VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    rotated_at TIMESTAMP NULL DEFAULT NULL,
+    revoked_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    UNIQUE (hashed_token),
+    INDEX (family_id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS account_refresh_tokens;
+
+CREATE TABLE IF NOT EXISTS account_refresh_tokens (
+    of_account_id BIGINT UNSIGNED NOT NULL,
+    token TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (of_account_id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
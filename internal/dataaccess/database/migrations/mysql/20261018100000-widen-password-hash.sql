@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE account_passwords MODIFY hashed_string VARCHAR(255) NOT NULL;
+
+-- +migrate Down
+ALTER TABLE account_passwords MODIFY hashed_string VARCHAR(128) NOT NULL;
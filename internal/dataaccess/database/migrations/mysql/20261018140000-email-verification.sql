@@ -0,0 +1,24 @@
+-- +migrate Up
+ALTER TABLE accounts ADD COLUMN email_verified_at TIMESTAMP NULL DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS verification_codes (
+    id BIGINT UNSIGNED AUTO_INCREMENT,
+    of_account_id BIGINT UNSIGNED NOT NULL,
+    channel VARCHAR(16) NOT NULL,
+    target VARCHAR(255) NOT NULL,
+    hashed_code VARCHAR(128) NOT NULL,
+    failed_attempts INT UNSIGNED NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    INDEX (of_account_id, channel, id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS verification_codes;
+
+ALTER TABLE accounts DROP COLUMN email_verified_at;
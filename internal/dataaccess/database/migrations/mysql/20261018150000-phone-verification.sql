@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE accounts ADD COLUMN phone_verified_at TIMESTAMP NULL DEFAULT NULL;
+
+-- +migrate Down
+ALTER TABLE accounts DROP COLUMN phone_verified_at;
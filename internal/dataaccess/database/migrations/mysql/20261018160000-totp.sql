@@ -0,0 +1,32 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS account_totp (
+    of_account_id BIGINT UNSIGNED NOT NULL,
+    encrypted_secret VARCHAR(255) NOT NULL,
+    confirmed_at TIMESTAMP NULL DEFAULT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (of_account_id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE IF NOT EXISTS login_challenges (
+    id BIGINT UNSIGNED AUTO_INCREMENT,
+    of_account_id BIGINT UNSIGNED NOT NULL,
+    hashed_challenge VARCHAR(128) NOT NULL,
+    failed_attempts INT UNSIGNED NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    UNIQUE (hashed_challenge)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS login_challenges;
+
+DROP TABLE IF EXISTS account_totp;
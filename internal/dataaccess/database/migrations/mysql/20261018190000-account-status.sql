@@ -0,0 +1,39 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS account_status (
+    id INT UNSIGNED,
+    name VARCHAR(128) NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+INSERT INTO account_status (id, name) VALUES (1, 'pending');
+INSERT INTO account_status (id, name) VALUES (2, 'active');
+INSERT INTO account_status (id, name) VALUES (3, 'suspended');
+INSERT INTO account_status (id, name) VALUES (4, 'locked');
+INSERT INTO account_status (id, name) VALUES (5, 'deactivated');
+
+-- Existing accounts were all usable, so they start out active
+ALTER TABLE accounts ADD COLUMN status_id INT UNSIGNED NOT NULL DEFAULT 2;
+ALTER TABLE accounts ADD CONSTRAINT fk_accounts_status_id FOREIGN KEY (status_id) REFERENCES account_status(id);
+
+CREATE TABLE IF NOT EXISTS account_status_changes (
+    id BIGINT UNSIGNED AUTO_INCREMENT,
+    of_account_id BIGINT UNSIGNED NOT NULL,
+    from_status_id INT UNSIGNED NOT NULL,
+    to_status_id INT UNSIGNED NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    actor_account_id BIGINT UNSIGNED NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    INDEX (of_account_id, id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS account_status_changes;
+
+ALTER TABLE accounts DROP FOREIGN KEY fk_accounts_status_id;
+ALTER TABLE accounts DROP COLUMN status_id;
+
+DROP TABLE IF EXISTS account_status;
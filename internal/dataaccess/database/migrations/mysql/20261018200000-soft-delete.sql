@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE accounts ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL;
+
+CREATE INDEX accounts_deleted_at ON accounts (deleted_at);
+
+-- +migrate Down
+DROP INDEX accounts_deleted_at ON accounts;
+
+ALTER TABLE accounts DROP COLUMN deleted_at;
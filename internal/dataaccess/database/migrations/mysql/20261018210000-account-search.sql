@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE FULLTEXT INDEX accounts_search ON accounts (username, fullname, email);
+
+-- +migrate Down
+DROP INDEX accounts_search ON accounts;
@@ -0,0 +1,295 @@
+-- +migrate Up
+-- Usernames, names and emails compare case-insensitively, as they do under
+-- the default MySQL collation
+CREATE EXTENSION IF NOT EXISTS citext;
+
+-- Postgres has no ON UPDATE CURRENT_TIMESTAMP, so a trigger keeps updated_at
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TABLE IF NOT EXISTS account_role (
+    id INTEGER,
+    name VARCHAR(128) NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+INSERT INTO account_role (id, name) VALUES (0, 'none');
+INSERT INTO account_role (id, name) VALUES (1, 'admin');
+INSERT INTO account_role (id, name) VALUES (2, 'member');
+
+CREATE TABLE IF NOT EXISTS account_status (
+    id INTEGER,
+    name VARCHAR(128) NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+INSERT INTO account_status (id, name) VALUES (1, 'pending');
+INSERT INTO account_status (id, name) VALUES (2, 'active');
+INSERT INTO account_status (id, name) VALUES (3, 'suspended');
+INSERT INTO account_status (id, name) VALUES (4, 'locked');
+INSERT INTO account_status (id, name) VALUES (5, 'deactivated');
+
+CREATE TABLE IF NOT EXISTS accounts (
+    id BIGSERIAL,
+    username CITEXT NOT NULL,
+    fullname CITEXT NOT NULL,
+    email CITEXT NOT NULL,
+    email_verified_at TIMESTAMPTZ NULL DEFAULT NULL,
+    phone_number VARCHAR(20) NOT NULL,
+    phone_verified_at TIMESTAMPTZ NULL DEFAULT NULL,
+    role_id INTEGER NOT NULL,
+    status_id INTEGER NOT NULL DEFAULT 2,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ NULL DEFAULT NULL,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (role_id) REFERENCES account_role(id),
+    FOREIGN KEY (status_id) REFERENCES account_status(id),
+    UNIQUE (username)
+);
+
+CREATE INDEX accounts_deleted_at ON accounts (deleted_at);
+
+-- Matches the document searched by the accounts accessor, which the index
+-- is only used for as long as the two are written alike
+CREATE INDEX accounts_search ON accounts USING GIN (to_tsvector('simple',
+    username::text || ' ' || fullname::text || ' ' || translate(email::text, '@.', '  ')));
+
+CREATE TABLE IF NOT EXISTS account_passwords (
+    of_account_id BIGINT NOT NULL,
+    hashed_string VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (of_account_id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE IF NOT EXISTS account_password_history (
+    id BIGSERIAL,
+    of_account_id BIGINT NOT NULL,
+    hashed_string VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
+
+CREATE INDEX account_password_history_of_account_id ON account_password_history (of_account_id, id);
+
+CREATE TABLE IF NOT EXISTS account_refresh_tokens (
+    id BIGSERIAL,
+    of_account_id BIGINT NOT NULL,
+    family_id VARCHAR(64) NOT NULL,
+    hashed_token VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    rotated_at TIMESTAMPTZ NULL DEFAULT NULL,
+    revoked_at TIMESTAMPTZ NULL DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    UNIQUE (hashed_token)
+);
+
+CREATE INDEX account_refresh_tokens_family_id ON account_refresh_tokens (family_id);
+
+CREATE TABLE IF NOT EXISTS login_failures (
+    scope VARCHAR(16) NOT NULL,
+    identifier VARCHAR(255) NOT NULL,
+    failure_count INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMPTZ NULL DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (scope, identifier)
+);
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id BIGSERIAL,
+    of_account_id BIGINT NOT NULL,
+    hashed_token VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ NULL DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    UNIQUE (hashed_token)
+);
+
+CREATE TABLE IF NOT EXISTS verification_codes (
+    id BIGSERIAL,
+    of_account_id BIGINT NOT NULL,
+    channel VARCHAR(16) NOT NULL,
+    target VARCHAR(255) NOT NULL,
+    hashed_code VARCHAR(128) NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ NULL DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
+
+CREATE INDEX verification_codes_of_account_id ON verification_codes (of_account_id, channel, id);
+
+CREATE TABLE IF NOT EXISTS account_totp (
+    of_account_id BIGINT NOT NULL,
+    encrypted_secret VARCHAR(255) NOT NULL,
+    confirmed_at TIMESTAMPTZ NULL DEFAULT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (of_account_id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE IF NOT EXISTS login_challenges (
+    id BIGSERIAL,
+    of_account_id BIGINT NOT NULL,
+    hashed_challenge VARCHAR(128) NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ NULL DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    UNIQUE (hashed_challenge)
+);
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id BIGSERIAL,
+    of_account_id BIGINT NOT NULL,
+    hashed_code VARCHAR(255) NOT NULL,
+    used_at TIMESTAMPTZ NULL DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE IF NOT EXISTS webauthn_credentials (
+    id BIGSERIAL,
+    of_account_id BIGINT NOT NULL,
+    credential_id VARCHAR(255) NOT NULL,
+    public_key BYTEA NOT NULL,
+    attestation_type VARCHAR(32) NOT NULL DEFAULT '',
+    aaguid BYTEA NOT NULL,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    transports VARCHAR(255) NOT NULL DEFAULT '',
+    backup_eligible BOOLEAN NOT NULL DEFAULT FALSE,
+    backup_state BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_at TIMESTAMPTZ NULL DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    UNIQUE (credential_id)
+);
+
+CREATE TABLE IF NOT EXISTS webauthn_sessions (
+    id BIGSERIAL,
+    of_account_id BIGINT NULL DEFAULT NULL,
+    hashed_session VARCHAR(128) NOT NULL,
+    ceremony VARCHAR(16) NOT NULL,
+    session_data TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ NULL DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    UNIQUE (hashed_session)
+);
+
+CREATE TABLE IF NOT EXISTS account_status_changes (
+    id BIGSERIAL,
+    of_account_id BIGINT NOT NULL,
+    from_status_id INTEGER NOT NULL,
+    to_status_id INTEGER NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    actor_account_id BIGINT NULL DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
+
+CREATE INDEX account_status_changes_of_account_id ON account_status_changes (of_account_id, id);
+
+CREATE TRIGGER accounts_updated_at BEFORE UPDATE ON accounts
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER account_passwords_updated_at BEFORE UPDATE ON account_passwords
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER account_refresh_tokens_updated_at BEFORE UPDATE ON account_refresh_tokens
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER login_failures_updated_at BEFORE UPDATE ON login_failures
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER password_reset_tokens_updated_at BEFORE UPDATE ON password_reset_tokens
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER verification_codes_updated_at BEFORE UPDATE ON verification_codes
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER account_totp_updated_at BEFORE UPDATE ON account_totp
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER login_challenges_updated_at BEFORE UPDATE ON login_challenges
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER recovery_codes_updated_at BEFORE UPDATE ON recovery_codes
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER webauthn_credentials_updated_at BEFORE UPDATE ON webauthn_credentials
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER webauthn_sessions_updated_at BEFORE UPDATE ON webauthn_sessions
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- +migrate Down
+DROP TABLE IF EXISTS account_status_changes;
+
+DROP TABLE IF EXISTS webauthn_sessions;
+
+DROP TABLE IF EXISTS webauthn_credentials;
+
+DROP TABLE IF EXISTS recovery_codes;
+
+DROP TABLE IF EXISTS login_challenges;
+
+DROP TABLE IF EXISTS account_totp;
+
+DROP TABLE IF EXISTS verification_codes;
+
+DROP TABLE IF EXISTS password_reset_tokens;
+
+DROP TABLE IF EXISTS login_failures;
+
+DROP TABLE IF EXISTS account_refresh_tokens;
+
+DROP TABLE IF EXISTS account_password_history;
+
+DROP TABLE IF EXISTS account_passwords;
+
+DROP TABLE IF EXISTS accounts;
+
+DROP TABLE IF EXISTS account_status;
+
+DROP TABLE IF EXISTS account_role;
+
+DROP FUNCTION IF EXISTS set_updated_at();
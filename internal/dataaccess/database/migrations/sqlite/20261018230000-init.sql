@@ -0,0 +1,324 @@
+-- +migrate Up
+-- Usernames, names and emails compare case-insensitively, as they do under
+-- the default MySQL collation. Times are kept as text in UTC, the way
+-- CURRENT_TIMESTAMP writes them.
+CREATE TABLE IF NOT EXISTS account_role (
+    id INTEGER NOT NULL,
+    name VARCHAR(128) NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+INSERT INTO account_role (id, name) VALUES (0, 'none');
+INSERT INTO account_role (id, name) VALUES (1, 'admin');
+INSERT INTO account_role (id, name) VALUES (2, 'member');
+
+CREATE TABLE IF NOT EXISTS account_status (
+    id INTEGER NOT NULL,
+    name VARCHAR(128) NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+INSERT INTO account_status (id, name) VALUES (1, 'pending');
+INSERT INTO account_status (id, name) VALUES (2, 'active');
+INSERT INTO account_status (id, name) VALUES (3, 'suspended');
+INSERT INTO account_status (id, name) VALUES (4, 'locked');
+INSERT INTO account_status (id, name) VALUES (5, 'deactivated');
+
+CREATE TABLE IF NOT EXISTS accounts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username VARCHAR(255) NOT NULL COLLATE NOCASE,
+    fullname VARCHAR(255) NOT NULL COLLATE NOCASE,
+    email VARCHAR(255) NOT NULL COLLATE NOCASE,
+    email_verified_at TIMESTAMP NULL DEFAULT NULL,
+    phone_number VARCHAR(20) NOT NULL,
+    phone_verified_at TIMESTAMP NULL DEFAULT NULL,
+    role_id INTEGER NOT NULL,
+    status_id INTEGER NOT NULL DEFAULT 2,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+
+    FOREIGN KEY (role_id) REFERENCES account_role(id),
+    FOREIGN KEY (status_id) REFERENCES account_status(id),
+    UNIQUE (username)
+);
+
+CREATE INDEX accounts_deleted_at ON accounts (deleted_at);
+
+CREATE TABLE IF NOT EXISTS account_passwords (
+    of_account_id INTEGER NOT NULL,
+    hashed_string VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (of_account_id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE IF NOT EXISTS account_password_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    of_account_id INTEGER NOT NULL,
+    hashed_string VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
+
+CREATE INDEX account_password_history_of_account_id ON account_password_history (of_account_id, id);
+
+CREATE TABLE IF NOT EXISTS account_refresh_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    of_account_id INTEGER NOT NULL,
+    family_id VARCHAR(64) NOT NULL,
+    hashed_token VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    rotated_at TIMESTAMP NULL DEFAULT NULL,
+    revoked_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    UNIQUE (hashed_token)
+);
+
+CREATE INDEX account_refresh_tokens_family_id ON account_refresh_tokens (family_id);
+
+CREATE TABLE IF NOT EXISTS login_failures (
+    scope VARCHAR(16) NOT NULL,
+    identifier VARCHAR(255) NOT NULL,
+    failure_count INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (scope, identifier)
+);
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    of_account_id INTEGER NOT NULL,
+    hashed_token VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    UNIQUE (hashed_token)
+);
+
+CREATE TABLE IF NOT EXISTS verification_codes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    of_account_id INTEGER NOT NULL,
+    channel VARCHAR(16) NOT NULL,
+    target VARCHAR(255) NOT NULL,
+    hashed_code VARCHAR(128) NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
+
+CREATE INDEX verification_codes_of_account_id ON verification_codes (of_account_id, channel, id);
+
+CREATE TABLE IF NOT EXISTS account_totp (
+    of_account_id INTEGER NOT NULL,
+    encrypted_secret VARCHAR(255) NOT NULL,
+    confirmed_at TIMESTAMP NULL DEFAULT NULL,
+    last_used_step INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (of_account_id),
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE IF NOT EXISTS login_challenges (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    of_account_id INTEGER NOT NULL,
+    hashed_challenge VARCHAR(128) NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    UNIQUE (hashed_challenge)
+);
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    of_account_id INTEGER NOT NULL,
+    hashed_code VARCHAR(255) NOT NULL,
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
+
+CREATE TABLE IF NOT EXISTS webauthn_credentials (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    of_account_id INTEGER NOT NULL,
+    credential_id VARCHAR(255) NOT NULL,
+    public_key BLOB NOT NULL,
+    attestation_type VARCHAR(32) NOT NULL DEFAULT '',
+    aaguid BLOB NOT NULL,
+    sign_count INTEGER NOT NULL DEFAULT 0,
+    transports VARCHAR(255) NOT NULL DEFAULT '',
+    backup_eligible BOOLEAN NOT NULL DEFAULT FALSE,
+    backup_state BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    UNIQUE (credential_id)
+);
+
+CREATE TABLE IF NOT EXISTS webauthn_sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    of_account_id INTEGER NULL DEFAULT NULL,
+    hashed_session VARCHAR(128) NOT NULL,
+    ceremony VARCHAR(16) NOT NULL,
+    session_data TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id),
+    UNIQUE (hashed_session)
+);
+
+CREATE TABLE IF NOT EXISTS account_status_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    of_account_id INTEGER NOT NULL,
+    from_status_id INTEGER NOT NULL,
+    to_status_id INTEGER NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    actor_account_id INTEGER NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (of_account_id) REFERENCES accounts(id)
+);
+
+CREATE INDEX account_status_changes_of_account_id ON account_status_changes (of_account_id, id);
+
+-- SQLite has no ON UPDATE CURRENT_TIMESTAMP, so triggers keep updated_at.
+-- Recursive triggers are off, so their own updates do not fire them again.
+-- +migrate StatementBegin
+CREATE TRIGGER accounts_updated_at AFTER UPDATE ON accounts FOR EACH ROW
+BEGIN
+    UPDATE accounts SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER account_passwords_updated_at AFTER UPDATE ON account_passwords FOR EACH ROW
+BEGIN
+    UPDATE account_passwords SET updated_at = CURRENT_TIMESTAMP WHERE of_account_id = NEW.of_account_id;
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER account_refresh_tokens_updated_at AFTER UPDATE ON account_refresh_tokens FOR EACH ROW
+BEGIN
+    UPDATE account_refresh_tokens SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER login_failures_updated_at AFTER UPDATE ON login_failures FOR EACH ROW
+BEGIN
+    UPDATE login_failures SET updated_at = CURRENT_TIMESTAMP
+        WHERE scope = NEW.scope AND identifier = NEW.identifier;
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER password_reset_tokens_updated_at AFTER UPDATE ON password_reset_tokens FOR EACH ROW
+BEGIN
+    UPDATE password_reset_tokens SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER verification_codes_updated_at AFTER UPDATE ON verification_codes FOR EACH ROW
+BEGIN
+    UPDATE verification_codes SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER account_totp_updated_at AFTER UPDATE ON account_totp FOR EACH ROW
+BEGIN
+    UPDATE account_totp SET updated_at = CURRENT_TIMESTAMP WHERE of_account_id = NEW.of_account_id;
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER login_challenges_updated_at AFTER UPDATE ON login_challenges FOR EACH ROW
+BEGIN
+    UPDATE login_challenges SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER recovery_codes_updated_at AFTER UPDATE ON recovery_codes FOR EACH ROW
+BEGIN
+    UPDATE recovery_codes SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER webauthn_credentials_updated_at AFTER UPDATE ON webauthn_credentials FOR EACH ROW
+BEGIN
+    UPDATE webauthn_credentials SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER webauthn_sessions_updated_at AFTER UPDATE ON webauthn_sessions FOR EACH ROW
+BEGIN
+    UPDATE webauthn_sessions SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TABLE IF EXISTS account_status_changes;
+
+DROP TABLE IF EXISTS webauthn_sessions;
+
+DROP TABLE IF EXISTS webauthn_credentials;
+
+DROP TABLE IF EXISTS recovery_codes;
+
+DROP TABLE IF EXISTS login_challenges;
+
+DROP TABLE IF EXISTS account_totp;
+
+DROP TABLE IF EXISTS verification_codes;
+
+DROP TABLE IF EXISTS password_reset_tokens;
+
+DROP TABLE IF EXISTS login_failures;
+
+DROP TABLE IF EXISTS account_refresh_tokens;
+
+DROP TABLE IF EXISTS account_password_history;
+
+DROP TABLE IF EXISTS account_passwords;
+
+DROP TABLE IF EXISTS accounts;
+
+DROP TABLE IF EXISTS account_status;
+
+DROP TABLE IF EXISTS account_role;
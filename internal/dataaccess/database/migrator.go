@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"embed"
+	"errors"
+	"sort"
+	"time"
 
 	"github.com/Fiagram/account_service/internal/utils"
 	migrate "github.com/rubenv/sql-migrate"
@@ -13,16 +16,52 @@ import (
 var (
 	//go:embed migrations/mysql/*.sql
 	migrationDirectoryMysql embed.FS
+	//go:embed migrations/postgres/*.sql
+	migrationDirectoryPostgres embed.FS
+	//go:embed migrations/sqlite/*.sql
+	migrationDirectorySqlite embed.FS
 )
 
+var (
+	ErrSchemaBehind  = errors.New("database schema is behind the binary, run the migrate up subcommand or set database.auto_migrate")
+	ErrSchemaUnknown = errors.New("database has migrations unknown to the binary, which may be older than the schema")
+	ErrNoMigration   = errors.New("no migration has been applied")
+)
+
+// A migration the binary holds or the database has applied.
+type MigrationStatus struct {
+	Id string
+	// Nil while the migration is pending.
+	AppliedAt *time.Time
+	// Applied to the database, yet unknown to the binary.
+	IsUnknown bool
+}
+
 type Migration interface {
+	// Applies all the pending migrations.
 	Up(ctx context.Context) error
+	// Rolls back all the applied migrations.
 	Down(ctx context.Context) error
+	// Applies the pending migrations older than the latest applied one, then
+	// at most steps newer ones, all of them for zero, and returns how many
+	// were applied.
+	UpSteps(ctx context.Context, steps int) (int, error)
+	// Rolls back at most steps applied migrations, latest first, all of them
+	// for zero, and returns how many were rolled back.
+	DownSteps(ctx context.Context, steps int) (int, error)
+	// Rolls back the latest applied migration and applies it again.
+	Redo(ctx context.Context) (string, error)
+	// Lists the migrations in id order.
+	Status(ctx context.Context) ([]MigrationStatus, error)
+	// Returns ErrSchemaBehind while any migration is pending, and
+	// ErrSchemaUnknown when the database has applied one the binary lacks.
+	CheckSchema(ctx context.Context) error
 }
 
 type migrator struct {
-	db     *sql.DB
-	logger *zap.Logger
+	db      *sql.DB
+	dialect dialect
+	logger  *zap.Logger
 }
 
 func NewMigrator(
@@ -30,35 +69,181 @@ func NewMigrator(
 	logger *zap.Logger,
 ) Migration {
 	return &migrator{
-		db:     db,
-		logger: logger,
+		db:      db,
+		dialect: dialectOfDB(db),
+		logger:  logger,
+	}
+}
+
+// Returns the dialect name sql-migrate takes.
+func (m migrator) migrationDialect() string {
+	switch m.dialect {
+	case dialectPostgres:
+		return "postgres"
+	case dialectSQLite:
+		return "sqlite3"
+	default:
+		return "mysql"
 	}
 }
 
-func (m migrator) migrate(ctx context.Context, direction migrate.MigrationDirection) error {
+func (m migrator) source() migrate.MigrationSource {
+	switch m.dialect {
+	case dialectPostgres:
+		return migrate.EmbedFileSystemMigrationSource{
+			FileSystem: migrationDirectoryPostgres,
+			Root:       "migrations/postgres",
+		}
+	case dialectSQLite:
+		return migrate.EmbedFileSystemMigrationSource{
+			FileSystem: migrationDirectorySqlite,
+			Root:       "migrations/sqlite",
+		}
+	}
+	return migrate.EmbedFileSystemMigrationSource{
+		FileSystem: migrationDirectoryMysql,
+		Root:       "migrations/mysql",
+	}
+}
+
+func (m migrator) migrate(
+	ctx context.Context,
+	direction migrate.MigrationDirection,
+	steps int,
+) (int, error) {
+	// The memory database has no schema to migrate
+	if m.dialect == dialectMemory {
+		return 0, nil
+	}
+
 	logger := utils.LoggerWithContext(ctx, m.logger).
-		With(zap.String("direction", utils.If(direction == migrate.Up, "up", "down")))
-
-	applied_migrations, err := migrate.ExecContext(ctx, m.db, "mysql",
-		migrate.EmbedFileSystemMigrationSource{
-			FileSystem: migrationDirectoryMysql,
-			Root:       "migrations/mysql",
-		},
-		direction)
+		With(zap.String("direction", utils.If(direction == migrate.Up, "up", "down"))).
+		With(zap.Int("steps", steps))
+
+	applied_migrations, err := migrate.ExecMaxContext(ctx, m.db, m.migrationDialect(), m.source(), direction, steps)
 	if err != nil {
 		logger.With(zap.Error(err)).Error("failed to execute migration")
-		return err
+		return applied_migrations, err
 	}
 
 	logger.With(zap.Int("applied_migrations", applied_migrations)).
 		Info("successfully executed database migrations")
-	return nil
+	return applied_migrations, nil
 }
 
 func (m migrator) Down(ctx context.Context) error {
-	return m.migrate(ctx, migrate.Down)
+	_, err := m.DownSteps(ctx, 0)
+	return err
 }
 
 func (m migrator) Up(ctx context.Context) error {
-	return m.migrate(ctx, migrate.Up)
+	_, err := m.UpSteps(ctx, 0)
+	return err
+}
+
+func (m migrator) UpSteps(ctx context.Context, steps int) (int, error) {
+	return m.migrate(ctx, migrate.Up, steps)
+}
+
+func (m migrator) DownSteps(ctx context.Context, steps int) (int, error) {
+	return m.migrate(ctx, migrate.Down, steps)
+}
+
+func (m migrator) Redo(ctx context.Context) (string, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return "", err
+	}
+	var latest *MigrationStatus
+	for i := range statuses {
+		if statuses[i].AppliedAt != nil {
+			latest = &statuses[i]
+		}
+	}
+	if latest == nil {
+		return "", ErrNoMigration
+	}
+	if latest.IsUnknown {
+		return "", ErrSchemaUnknown
+	}
+
+	if _, err = m.DownSteps(ctx, 1); err != nil {
+		return "", err
+	}
+	// Older pending migrations are caught up whatever the steps, so the one
+	// step is the rolled back migration
+	if _, err = m.UpSteps(ctx, 1); err != nil {
+		return "", err
+	}
+	return latest.Id, nil
+}
+
+func (m migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if m.dialect == dialectMemory {
+		return nil, nil
+	}
+
+	logger := utils.LoggerWithContext(ctx, m.logger)
+
+	migrations, err := m.source().FindMigrations()
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to find migrations")
+		return nil, err
+	}
+	records, err := migrate.GetMigrationRecords(m.db, m.migrationDialect())
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get migration records")
+		return nil, err
+	}
+
+	appliedAts := make(map[string]time.Time, len(records))
+	for _, record := range records {
+		appliedAts[record.Id] = record.AppliedAt
+	}
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := MigrationStatus{Id: migration.Id}
+		if appliedAt, ok := appliedAts[migration.Id]; ok {
+			status.AppliedAt = &appliedAt
+			delete(appliedAts, migration.Id)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range records {
+		if _, ok := appliedAts[record.Id]; ok {
+			statuses = append(statuses, MigrationStatus{
+				Id:        record.Id,
+				AppliedAt: &record.AppliedAt,
+				IsUnknown: true,
+			})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Id < statuses[j].Id
+	})
+	return statuses, nil
+}
+
+func (m migrator) CheckSchema(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.IsUnknown {
+			return ErrSchemaUnknown
+		}
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	if pending > 0 {
+		utils.LoggerWithContext(ctx, m.logger).
+			With(zap.Int("pending_migrations", pending)).
+			Error("database schema is behind the binary")
+		return ErrSchemaBehind
+	}
+	return nil
 }
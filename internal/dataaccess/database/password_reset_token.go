@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+type PasswordResetToken struct {
+	Id          uint64     `json:"id"`
+	OfAccountId uint64     `json:"of_account_id"`
+	HashedToken string     `json:"hashed_token"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	UsedAt      *time.Time `json:"used_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type PasswordResetTokenAccessor interface {
+	CreatePasswordResetToken(ctx context.Context, prt PasswordResetToken) (uint64, error)
+
+	GetPasswordResetTokenByHashedToken(ctx context.Context, hashedToken string) (PasswordResetToken, error)
+
+	UsePasswordResetToken(ctx context.Context, id uint64, usedAt time.Time) error
+
+	DeletePasswordResetTokenOfAccount(ctx context.Context, ofAccountId uint64) error
+
+	WithExecutor(exec Executor) PasswordResetTokenAccessor
+}
+
+type passwordResetTokenAccessor struct {
+	exec   Executor
+	logger *zap.Logger
+}
+
+func NewPasswordResetTokenAccessor(
+	exec Executor,
+	logger *zap.Logger,
+) PasswordResetTokenAccessor {
+	return &passwordResetTokenAccessor{
+		exec:   newDialectExecutor(exec),
+		logger: logger,
+	}
+}
+
+func (a passwordResetTokenAccessor) CreatePasswordResetToken(
+	ctx context.Context,
+	prt PasswordResetToken,
+) (uint64, error) {
+	if prt.OfAccountId == 0 || prt.HashedToken == "" {
+		return 0, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", prt.OfAccountId))
+	const query = `INSERT INTO password_reset_tokens
+			(of_account_id, hashed_token, expires_at)
+			VALUES (?, ?, ?)`
+	id, err := insertReturningId(ctx, a.exec, query,
+		prt.OfAccountId,
+		strings.TrimSpace(prt.HashedToken),
+		prt.ExpiresAt,
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create password reset token")
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (a passwordResetTokenAccessor) GetPasswordResetTokenByHashedToken(
+	ctx context.Context,
+	hashedToken string,
+) (PasswordResetToken, error) {
+	if hashedToken == "" {
+		return PasswordResetToken{}, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger)
+	const query = `SELECT id, of_account_id, hashed_token,
+			expires_at, used_at, created_at, updated_at
+			FROM password_reset_tokens WHERE hashed_token = ?`
+	row := a.exec.QueryRowContext(ctx, query, hashedToken)
+
+	var out PasswordResetToken
+	err := row.Scan(&out.Id,
+		&out.OfAccountId,
+		&out.HashedToken,
+		&out.ExpiresAt,
+		&out.UsedAt,
+		&out.CreatedAt,
+		&out.UpdatedAt)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get password reset token by hashed token")
+		return PasswordResetToken{}, err
+	}
+
+	return out, nil
+}
+
+// Marks an unused token as used. The update only applies to a token that has
+// not been used yet, so a token cannot be redeemed twice.
+func (a passwordResetTokenAccessor) UsePasswordResetToken(
+	ctx context.Context,
+	id uint64,
+	usedAt time.Time,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("password_reset_token_id", id))
+	const query = `UPDATE password_reset_tokens SET
+			used_at = ?
+			WHERE id = ? AND used_at IS NULL`
+	result, err := a.exec.ExecContext(ctx, query, usedAt, id)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to use password reset token")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a passwordResetTokenAccessor) DeletePasswordResetTokenOfAccount(
+	ctx context.Context,
+	ofAccountId uint64,
+) error {
+	if ofAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `DELETE FROM password_reset_tokens WHERE of_account_id = ?`
+	_, err := a.exec.ExecContext(ctx, query, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete password reset tokens of account")
+		return err
+	}
+
+	return nil
+}
+
+func (a passwordResetTokenAccessor) WithExecutor(
+	exec Executor,
+) PasswordResetTokenAccessor {
+	return &passwordResetTokenAccessor{
+		exec:   withDialectOf(a.exec, exec),
+		logger: a.logger,
+	}
+}
@@ -0,0 +1,176 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+// A single-use code that stands in for the second factor of an account.
+type RecoveryCode struct {
+	Id          uint64     `json:"id"`
+	OfAccountId uint64     `json:"of_account_id"`
+	HashedCode  string     `json:"hashed_code"`
+	UsedAt      *time.Time `json:"used_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type RecoveryCodeAccessor interface {
+	CreateRecoveryCode(ctx context.Context, rc RecoveryCode) (uint64, error)
+
+	GetUnusedRecoveryCodeList(ctx context.Context, ofAccountId uint64) ([]RecoveryCode, error)
+
+	UseRecoveryCode(ctx context.Context, id uint64, usedAt time.Time) error
+
+	DeleteRecoveryCodeOfAccount(ctx context.Context, ofAccountId uint64) error
+
+	WithExecutor(exec Executor) RecoveryCodeAccessor
+}
+
+type recoveryCodeAccessor struct {
+	exec   Executor
+	logger *zap.Logger
+}
+
+func NewRecoveryCodeAccessor(
+	exec Executor,
+	logger *zap.Logger,
+) RecoveryCodeAccessor {
+	return &recoveryCodeAccessor{
+		exec:   newDialectExecutor(exec),
+		logger: logger,
+	}
+}
+
+func (a recoveryCodeAccessor) CreateRecoveryCode(
+	ctx context.Context,
+	rc RecoveryCode,
+) (uint64, error) {
+	if rc.OfAccountId == 0 || rc.HashedCode == "" {
+		return 0, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", rc.OfAccountId))
+	const query = `INSERT INTO recovery_codes
+			(of_account_id, hashed_code)
+			VALUES (?, ?)`
+	id, err := insertReturningId(ctx, a.exec, query,
+		rc.OfAccountId,
+		strings.TrimSpace(rc.HashedCode),
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create recovery code")
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (a recoveryCodeAccessor) GetUnusedRecoveryCodeList(
+	ctx context.Context,
+	ofAccountId uint64,
+) ([]RecoveryCode, error) {
+	if ofAccountId == 0 {
+		return nil, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `SELECT id, of_account_id, hashed_code, used_at, created_at, updated_at
+			FROM recovery_codes
+			WHERE of_account_id = ? AND used_at IS NULL
+			ORDER BY id`
+	rows, err := a.exec.QueryContext(ctx, query, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get recovery codes")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []RecoveryCode
+	for rows.Next() {
+		var rc RecoveryCode
+		err := rows.Scan(&rc.Id,
+			&rc.OfAccountId,
+			&rc.HashedCode,
+			&rc.UsedAt,
+			&rc.CreatedAt,
+			&rc.UpdatedAt)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("failed to scan recovery code")
+			return nil, err
+		}
+		out = append(out, rc)
+	}
+	if err := rows.Err(); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get recovery codes")
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Only marks a code that is still unused, so a code cannot be used twice by
+// concurrent requests.
+func (a recoveryCodeAccessor) UseRecoveryCode(
+	ctx context.Context,
+	id uint64,
+	usedAt time.Time,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("id", id))
+	const query = `UPDATE recovery_codes SET
+			used_at = ?
+			WHERE id = ? AND used_at IS NULL`
+	result, err := a.exec.ExecContext(ctx, query, usedAt, id)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to use recovery code")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a recoveryCodeAccessor) DeleteRecoveryCodeOfAccount(
+	ctx context.Context,
+	ofAccountId uint64,
+) error {
+	if ofAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `DELETE FROM recovery_codes WHERE of_account_id = ?`
+	_, err := a.exec.ExecContext(ctx, query, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete recovery codes of account")
+		return err
+	}
+
+	return nil
+}
+
+func (a recoveryCodeAccessor) WithExecutor(
+	exec Executor,
+) RecoveryCodeAccessor {
+	return &recoveryCodeAccessor{
+		exec:   withDialectOf(a.exec, exec),
+		logger: a.logger,
+	}
+}
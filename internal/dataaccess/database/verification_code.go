@@ -0,0 +1,213 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+type VerificationChannel string
+
+const (
+	VerificationChannelEmail VerificationChannel = "email"
+	VerificationChannelPhone VerificationChannel = "phone"
+)
+
+type VerificationCode struct {
+	Id             uint64              `json:"id"`
+	OfAccountId    uint64              `json:"of_account_id"`
+	Channel        VerificationChannel `json:"channel"`
+	Target         string              `json:"target"`
+	HashedCode     string              `json:"hashed_code"`
+	FailedAttempts uint32              `json:"failed_attempts"`
+	ExpiresAt      time.Time           `json:"expires_at"`
+	UsedAt         *time.Time          `json:"used_at"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+type VerificationCodeAccessor interface {
+	CreateVerificationCode(ctx context.Context, vc VerificationCode) (uint64, error)
+
+	// Returns the code issued last to the account on the channel.
+	GetLatestVerificationCode(ctx context.Context, ofAccountId uint64, channel VerificationChannel) (VerificationCode, error)
+
+	IncreaseVerificationCodeFailedAttempts(ctx context.Context, id uint64) error
+	UseVerificationCode(ctx context.Context, id uint64, usedAt time.Time) error
+
+	DeleteVerificationCodeOfAccount(ctx context.Context, ofAccountId uint64) error
+
+	WithExecutor(exec Executor) VerificationCodeAccessor
+}
+
+type verificationCodeAccessor struct {
+	exec   Executor
+	logger *zap.Logger
+}
+
+func NewVerificationCodeAccessor(
+	exec Executor,
+	logger *zap.Logger,
+) VerificationCodeAccessor {
+	return &verificationCodeAccessor{
+		exec:   newDialectExecutor(exec),
+		logger: logger,
+	}
+}
+
+func (a verificationCodeAccessor) CreateVerificationCode(
+	ctx context.Context,
+	vc VerificationCode,
+) (uint64, error) {
+	if vc.OfAccountId == 0 ||
+		vc.Channel == "" ||
+		vc.Target == "" ||
+		vc.HashedCode == "" {
+		return 0, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).
+		With(zap.Any("of_account_id", vc.OfAccountId)).
+		With(zap.Any("channel", vc.Channel))
+	const query = `INSERT INTO verification_codes
+			(of_account_id, channel, target, hashed_code, expires_at)
+			VALUES (?, ?, ?, ?, ?)`
+	id, err := insertReturningId(ctx, a.exec, query,
+		vc.OfAccountId,
+		vc.Channel,
+		strings.TrimSpace(vc.Target),
+		strings.TrimSpace(vc.HashedCode),
+		vc.ExpiresAt,
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create verification code")
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (a verificationCodeAccessor) GetLatestVerificationCode(
+	ctx context.Context,
+	ofAccountId uint64,
+	channel VerificationChannel,
+) (VerificationCode, error) {
+	if ofAccountId == 0 || channel == "" {
+		return VerificationCode{}, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).
+		With(zap.Any("of_account_id", ofAccountId)).
+		With(zap.Any("channel", channel))
+	const query = `SELECT id, of_account_id, channel, target, hashed_code,
+			failed_attempts, expires_at, used_at, created_at, updated_at
+			FROM verification_codes
+			WHERE of_account_id = ? AND channel = ?
+			ORDER BY id DESC
+			LIMIT 1`
+	row := a.exec.QueryRowContext(ctx, query, ofAccountId, channel)
+
+	var out VerificationCode
+	err := row.Scan(&out.Id,
+		&out.OfAccountId,
+		&out.Channel,
+		&out.Target,
+		&out.HashedCode,
+		&out.FailedAttempts,
+		&out.ExpiresAt,
+		&out.UsedAt,
+		&out.CreatedAt,
+		&out.UpdatedAt)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get latest verification code")
+		return VerificationCode{}, err
+	}
+
+	return out, nil
+}
+
+func (a verificationCodeAccessor) IncreaseVerificationCodeFailedAttempts(
+	ctx context.Context,
+	id uint64,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("verification_code_id", id))
+	const query = `UPDATE verification_codes SET
+			failed_attempts = failed_attempts + 1
+			WHERE id = ?`
+	_, err := a.exec.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to increase verification code failed attempts")
+		return err
+	}
+
+	return nil
+}
+
+// Marks an unused code as used. The update only applies to a code that has not
+// been used yet, so a code cannot be redeemed twice.
+func (a verificationCodeAccessor) UseVerificationCode(
+	ctx context.Context,
+	id uint64,
+	usedAt time.Time,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("verification_code_id", id))
+	const query = `UPDATE verification_codes SET
+			used_at = ?
+			WHERE id = ? AND used_at IS NULL`
+	result, err := a.exec.ExecContext(ctx, query, usedAt, id)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to use verification code")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a verificationCodeAccessor) DeleteVerificationCodeOfAccount(
+	ctx context.Context,
+	ofAccountId uint64,
+) error {
+	if ofAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `DELETE FROM verification_codes WHERE of_account_id = ?`
+	_, err := a.exec.ExecContext(ctx, query, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete verification codes of account")
+		return err
+	}
+
+	return nil
+}
+
+func (a verificationCodeAccessor) WithExecutor(
+	exec Executor,
+) VerificationCodeAccessor {
+	return &verificationCodeAccessor{
+		exec:   withDialectOf(a.exec, exec),
+		logger: a.logger,
+	}
+}
@@ -0,0 +1,252 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+// A public key credential, also known as a passkey, registered by an account.
+type WebAuthnCredential struct {
+	Id          uint64 `json:"id"`
+	OfAccountId uint64 `json:"of_account_id"`
+	// The credential id chosen by the authenticator, base64url encoded.
+	CredentialId    string     `json:"credential_id"`
+	PublicKey       []byte     `json:"public_key"`
+	AttestationType string     `json:"attestation_type"`
+	AAGUID          []byte     `json:"aaguid"`
+	SignCount       uint32     `json:"sign_count"`
+	Transports      []string   `json:"transports"`
+	BackupEligible  bool       `json:"backup_eligible"`
+	BackupState     bool       `json:"backup_state"`
+	LastUsedAt      *time.Time `json:"last_used_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type WebAuthnCredentialAccessor interface {
+	CreateWebAuthnCredential(ctx context.Context, wc WebAuthnCredential) (uint64, error)
+
+	GetWebAuthnCredentialList(ctx context.Context, ofAccountId uint64) ([]WebAuthnCredential, error)
+	GetWebAuthnCredentialByCredentialId(ctx context.Context, credentialId string) (WebAuthnCredential, error)
+
+	// Records an assertion made with the credential. A non-zero sign count
+	// must be greater than the stored one, otherwise nothing is updated and an
+	// error is returned, so two racing assertions cannot both pass the counter
+	// check.
+	UseWebAuthnCredential(ctx context.Context, id uint64, signCount uint32, backupState bool, usedAt time.Time) error
+
+	DeleteWebAuthnCredentialOfAccount(ctx context.Context, ofAccountId uint64) error
+
+	WithExecutor(exec Executor) WebAuthnCredentialAccessor
+}
+
+type webAuthnCredentialAccessor struct {
+	exec   Executor
+	logger *zap.Logger
+}
+
+func NewWebAuthnCredentialAccessor(
+	exec Executor,
+	logger *zap.Logger,
+) WebAuthnCredentialAccessor {
+	return &webAuthnCredentialAccessor{
+		exec:   newDialectExecutor(exec),
+		logger: logger,
+	}
+}
+
+const webAuthnCredentialColumns = `id, of_account_id, credential_id, public_key,
+			attestation_type, aaguid, sign_count, transports,
+			backup_eligible, backup_state, last_used_at, created_at, updated_at`
+
+func scanWebAuthnCredential(row rowScanner) (WebAuthnCredential, error) {
+	var out WebAuthnCredential
+	var transports string
+	err := row.Scan(&out.Id,
+		&out.OfAccountId,
+		&out.CredentialId,
+		&out.PublicKey,
+		&out.AttestationType,
+		&out.AAGUID,
+		&out.SignCount,
+		&transports,
+		&out.BackupEligible,
+		&out.BackupState,
+		&out.LastUsedAt,
+		&out.CreatedAt,
+		&out.UpdatedAt)
+	if err != nil {
+		return WebAuthnCredential{}, err
+	}
+	if transports != "" {
+		out.Transports = strings.Split(transports, ",")
+	}
+	return out, nil
+}
+
+func (a webAuthnCredentialAccessor) CreateWebAuthnCredential(
+	ctx context.Context,
+	wc WebAuthnCredential,
+) (uint64, error) {
+	if wc.OfAccountId == 0 ||
+		wc.CredentialId == "" ||
+		len(wc.PublicKey) == 0 {
+		return 0, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", wc.OfAccountId))
+	const query = `INSERT INTO webauthn_credentials
+			(of_account_id, credential_id, public_key, attestation_type, aaguid,
+			sign_count, transports, backup_eligible, backup_state)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	id, err := insertReturningId(ctx, a.exec, query,
+		wc.OfAccountId,
+		wc.CredentialId,
+		wc.PublicKey,
+		wc.AttestationType,
+		wc.AAGUID,
+		wc.SignCount,
+		strings.Join(wc.Transports, ","),
+		wc.BackupEligible,
+		wc.BackupState,
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create webauthn credential")
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (a webAuthnCredentialAccessor) GetWebAuthnCredentialList(
+	ctx context.Context,
+	ofAccountId uint64,
+) ([]WebAuthnCredential, error) {
+	if ofAccountId == 0 {
+		return nil, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `SELECT ` + webAuthnCredentialColumns + `
+			FROM webauthn_credentials
+			WHERE of_account_id = ?
+			ORDER BY id`
+	rows, err := a.exec.QueryContext(ctx, query, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get webauthn credentials")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []WebAuthnCredential
+	for rows.Next() {
+		wc, err := scanWebAuthnCredential(rows)
+		if err != nil {
+			logger.With(zap.Error(err)).Error("failed to scan webauthn credential")
+			return nil, err
+		}
+		out = append(out, wc)
+	}
+	if err := rows.Err(); err != nil {
+		logger.With(zap.Error(err)).Error("failed to get webauthn credentials")
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Returns sql.ErrNoRows without logging it, since the credential id comes
+// straight from the client.
+func (a webAuthnCredentialAccessor) GetWebAuthnCredentialByCredentialId(
+	ctx context.Context,
+	credentialId string,
+) (WebAuthnCredential, error) {
+	if credentialId == "" {
+		return WebAuthnCredential{}, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("credential_id", credentialId))
+	const query = `SELECT ` + webAuthnCredentialColumns + `
+			FROM webauthn_credentials
+			WHERE credential_id = ?`
+	out, err := scanWebAuthnCredential(a.exec.QueryRowContext(ctx, query, credentialId))
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.With(zap.Error(err)).Error("failed to get webauthn credential")
+		}
+		return WebAuthnCredential{}, err
+	}
+
+	return out, nil
+}
+
+func (a webAuthnCredentialAccessor) UseWebAuthnCredential(
+	ctx context.Context,
+	id uint64,
+	signCount uint32,
+	backupState bool,
+	usedAt time.Time,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("id", id))
+	const query = `UPDATE webauthn_credentials SET
+			sign_count = ?, backup_state = ?, last_used_at = ?
+			WHERE id = ? AND (sign_count < ? OR ? = 0)`
+	result, err := a.exec.ExecContext(ctx, query,
+		signCount, backupState, usedAt,
+		id, signCount, signCount)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to use webauthn credential")
+		return err
+	}
+
+	// An authenticator without a counter always reports zero, the update may
+	// then change nothing when it is repeated within the same second.
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum > 1 || (rowEfNum == 0 && signCount != 0) || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a webAuthnCredentialAccessor) DeleteWebAuthnCredentialOfAccount(
+	ctx context.Context,
+	ofAccountId uint64,
+) error {
+	if ofAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `DELETE FROM webauthn_credentials WHERE of_account_id = ?`
+	_, err := a.exec.ExecContext(ctx, query, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete webauthn credentials of account")
+		return err
+	}
+
+	return nil
+}
+
+func (a webAuthnCredentialAccessor) WithExecutor(
+	exec Executor,
+) WebAuthnCredentialAccessor {
+	return &webAuthnCredentialAccessor{
+		exec:   withDialectOf(a.exec, exec),
+		logger: a.logger,
+	}
+}
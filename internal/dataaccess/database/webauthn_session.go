@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+type WebAuthnCeremony string
+
+const (
+	WebAuthnCeremonyRegistration WebAuthnCeremony = "registration"
+	WebAuthnCeremonyAssertion    WebAuthnCeremony = "assertion"
+)
+
+// The server side state of a WebAuthn ceremony between its begin and finish.
+type WebAuthnSession struct {
+	Id uint64 `json:"id"`
+	// Zero for an assertion where the authenticator picks the account.
+	OfAccountId   uint64           `json:"of_account_id"`
+	HashedSession string           `json:"hashed_session"`
+	Ceremony      WebAuthnCeremony `json:"ceremony"`
+	SessionData   string           `json:"session_data"`
+	ExpiresAt     time.Time        `json:"expires_at"`
+	UsedAt        *time.Time       `json:"used_at"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+type WebAuthnSessionAccessor interface {
+	CreateWebAuthnSession(ctx context.Context, ws WebAuthnSession) (uint64, error)
+
+	GetWebAuthnSessionByHashedSession(ctx context.Context, hashedSession string) (WebAuthnSession, error)
+
+	UseWebAuthnSession(ctx context.Context, id uint64, usedAt time.Time) error
+
+	DeleteWebAuthnSessionOfAccount(ctx context.Context, ofAccountId uint64) error
+
+	WithExecutor(exec Executor) WebAuthnSessionAccessor
+}
+
+type webAuthnSessionAccessor struct {
+	exec   Executor
+	logger *zap.Logger
+}
+
+func NewWebAuthnSessionAccessor(
+	exec Executor,
+	logger *zap.Logger,
+) WebAuthnSessionAccessor {
+	return &webAuthnSessionAccessor{
+		exec:   newDialectExecutor(exec),
+		logger: logger,
+	}
+}
+
+func (a webAuthnSessionAccessor) CreateWebAuthnSession(
+	ctx context.Context,
+	ws WebAuthnSession,
+) (uint64, error) {
+	if ws.HashedSession == "" ||
+		ws.Ceremony == "" ||
+		ws.SessionData == "" {
+		return 0, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).
+		With(zap.Any("of_account_id", ws.OfAccountId)).
+		With(zap.Any("ceremony", ws.Ceremony))
+	ofAccountId := sql.NullInt64{
+		Int64: int64(ws.OfAccountId),
+		Valid: ws.OfAccountId != 0,
+	}
+	const query = `INSERT INTO webauthn_sessions
+			(of_account_id, hashed_session, ceremony, session_data, expires_at)
+			VALUES (?, ?, ?, ?, ?)`
+	id, err := insertReturningId(ctx, a.exec, query,
+		ofAccountId,
+		strings.TrimSpace(ws.HashedSession),
+		ws.Ceremony,
+		ws.SessionData,
+		ws.ExpiresAt,
+	)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to create webauthn session")
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (a webAuthnSessionAccessor) GetWebAuthnSessionByHashedSession(
+	ctx context.Context,
+	hashedSession string,
+) (WebAuthnSession, error) {
+	if hashedSession == "" {
+		return WebAuthnSession{}, ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger)
+	const query = `SELECT id, of_account_id, hashed_session, ceremony, session_data,
+			expires_at, used_at, created_at, updated_at
+			FROM webauthn_sessions WHERE hashed_session = ?`
+	row := a.exec.QueryRowContext(ctx, query, hashedSession)
+
+	var out WebAuthnSession
+	var ofAccountId sql.NullInt64
+	err := row.Scan(&out.Id,
+		&ofAccountId,
+		&out.HashedSession,
+		&out.Ceremony,
+		&out.SessionData,
+		&out.ExpiresAt,
+		&out.UsedAt,
+		&out.CreatedAt,
+		&out.UpdatedAt)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to get webauthn session")
+		return WebAuthnSession{}, err
+	}
+	out.OfAccountId = uint64(ofAccountId.Int64)
+
+	return out, nil
+}
+
+// Only marks a session that is still unused, so a session finishes one
+// ceremony even under concurrent requests.
+func (a webAuthnSessionAccessor) UseWebAuthnSession(
+	ctx context.Context,
+	id uint64,
+	usedAt time.Time,
+) error {
+	if id == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("id", id))
+	const query = `UPDATE webauthn_sessions SET
+			used_at = ?
+			WHERE id = ? AND used_at IS NULL`
+	result, err := a.exec.ExecContext(ctx, query, usedAt, id)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to use webauthn session")
+		return err
+	}
+
+	rowEfNum, err := result.RowsAffected()
+	if rowEfNum != 1 || err != nil {
+		errMsg := "failed to effect row"
+		logger.With(zap.Int64("rowEfNum", rowEfNum)).
+			With(zap.Error(err)).
+			Error(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+func (a webAuthnSessionAccessor) DeleteWebAuthnSessionOfAccount(
+	ctx context.Context,
+	ofAccountId uint64,
+) error {
+	if ofAccountId == 0 {
+		return ErrLackOfInfor
+	}
+
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", ofAccountId))
+	const query = `DELETE FROM webauthn_sessions WHERE of_account_id = ?`
+	_, err := a.exec.ExecContext(ctx, query, ofAccountId)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to delete webauthn sessions of account")
+		return err
+	}
+
+	return nil
+}
+
+func (a webAuthnSessionAccessor) WithExecutor(
+	exec Executor,
+) WebAuthnSessionAccessor {
+	return &webAuthnSessionAccessor{
+		exec:   withDialectOf(a.exec, exec),
+		logger: a.logger,
+	}
+}
@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+// Appends every message to a local file as one JSON object per line, so tests
+// and developers can pick the delivered tokens up without a real provider.
+type fileNotifier struct {
+	filePath string
+	mu       *sync.Mutex
+	logger   *zap.Logger
+}
+
+func NewFileNotifier(filePath string, logger *zap.Logger) Notifier {
+	return &fileNotifier{
+		filePath: filePath,
+		mu:       &sync.Mutex{},
+		logger:   logger,
+	}
+}
+
+func (n fileNotifier) Send(ctx context.Context, message Message) error {
+	logger := utils.LoggerWithContext(ctx, n.logger).With(zap.String("file_path", n.filePath))
+	if message.CreatedAt.IsZero() {
+		message.CreatedAt = time.Now()
+	}
+
+	line, err := json.Marshal(message)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to marshal notification")
+		return err
+	}
+	line = append(line, '\n')
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		logger.With(zap.Error(err)).Error("failed to open notification file")
+		return err
+	}
+	defer file.Close()
+
+	if _, err = file.Write(line); err != nil {
+		logger.With(zap.Error(err)).Error("failed to write notification")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+)
+
+// Writes every message, secrets included, to the log. Meant for development
+// and offline tests only.
+type logNotifier struct {
+	logger *zap.Logger
+}
+
+func NewLogNotifier(logger *zap.Logger) Notifier {
+	return &logNotifier{
+		logger: logger,
+	}
+}
+
+func (n logNotifier) Send(ctx context.Context, message Message) error {
+	if message.CreatedAt.IsZero() {
+		message.CreatedAt = time.Now()
+	}
+
+	utils.LoggerWithContext(ctx, n.logger).
+		With(zap.Any("channel", message.Channel)).
+		With(zap.String("recipient", message.Recipient)).
+		With(zap.String("subject", message.Subject)).
+		With(zap.String("body", message.Body)).
+		With(zap.Time("created_at", message.CreatedAt)).
+		Info("sent notification")
+	return nil
+}
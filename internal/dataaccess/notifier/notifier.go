@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"go.uber.org/zap"
+)
+
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelSMS   Channel = "sms"
+)
+
+type Message struct {
+	Channel   Channel   `json:"channel"`
+	Recipient string    `json:"recipient"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Delivers messages, such as one-time tokens, to the account holders.
+type Notifier interface {
+	Send(ctx context.Context, message Message) error
+}
+
+func NewNotifier(
+	notifierConfig configs.Notifier,
+	logger *zap.Logger,
+) (Notifier, error) {
+	switch notifierConfig.Type {
+	case configs.NotifierTypeLog, "":
+		return NewLogNotifier(logger), nil
+	case configs.NotifierTypeFile:
+		if notifierConfig.FilePath == "" {
+			return nil, fmt.Errorf("Failed to create file notifier: file_path is required")
+		}
+		return NewFileNotifier(notifierConfig.FilePath, logger), nil
+	default:
+		return nil, fmt.Errorf("Failed to create notifier: unsupported type %q", notifierConfig.Type)
+	}
+}
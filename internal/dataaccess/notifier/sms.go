@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"go.uber.org/zap"
+)
+
+// Delivers text messages to E.164 phone numbers. A carrier integration plugs
+// in here.
+type SMSSender interface {
+	SendSMS(ctx context.Context, phoneNumber string, body string) error
+}
+
+func NewSMSSender(
+	smsSenderConfig configs.SMSSender,
+	logger *zap.Logger,
+) (SMSSender, error) {
+	switch smsSenderConfig.Type {
+	case configs.SMSSenderTypeLog, "":
+		return &notifierSMSSender{notifier: NewLogNotifier(logger)}, nil
+	case configs.SMSSenderTypeFile:
+		if smsSenderConfig.FilePath == "" {
+			return nil, fmt.Errorf("Failed to create file SMS sender: file_path is required")
+		}
+		return &notifierSMSSender{notifier: NewFileNotifier(smsSenderConfig.FilePath, logger)}, nil
+	default:
+		return nil, fmt.Errorf("Failed to create SMS sender: unsupported type %q", smsSenderConfig.Type)
+	}
+}
+
+// Hands the texts to a notifier, so they land in the log or a local file.
+type notifierSMSSender struct {
+	notifier Notifier
+}
+
+func (s notifierSMSSender) SendSMS(ctx context.Context, phoneNumber string, body string) error {
+	return s.notifier.Send(ctx, Message{
+		Channel:   ChannelSMS,
+		Recipient: phoneNumber,
+		Body:      body,
+	})
+}
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -71,13 +72,284 @@ func (AccountInfo_Role) EnumDescriptor() ([]byte, []int) {
 	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type AccountInfo_Status int32
+
+const (
+	AccountInfo_STATUS_UNSPECIFIED AccountInfo_Status = 0
+	AccountInfo_PENDING            AccountInfo_Status = 1
+	AccountInfo_ACTIVE             AccountInfo_Status = 2
+	AccountInfo_SUSPENDED          AccountInfo_Status = 3
+	AccountInfo_LOCKED             AccountInfo_Status = 4
+	AccountInfo_DEACTIVATED        AccountInfo_Status = 5
+)
+
+// Enum value maps for AccountInfo_Status.
+var (
+	AccountInfo_Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "PENDING",
+		2: "ACTIVE",
+		3: "SUSPENDED",
+		4: "LOCKED",
+		5: "DEACTIVATED",
+	}
+	AccountInfo_Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"PENDING":            1,
+		"ACTIVE":             2,
+		"SUSPENDED":          3,
+		"LOCKED":             4,
+		"DEACTIVATED":        5,
+	}
+)
+
+func (x AccountInfo_Status) Enum() *AccountInfo_Status {
+	p := new(AccountInfo_Status)
+	*p = x
+	return p
+}
+
+func (x AccountInfo_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccountInfo_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_account_service_account_service_proto_enumTypes[1].Descriptor()
+}
+
+func (AccountInfo_Status) Type() protoreflect.EnumType {
+	return &file_api_account_service_account_service_proto_enumTypes[1]
+}
+
+func (x AccountInfo_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccountInfo_Status.Descriptor instead.
+func (AccountInfo_Status) EnumDescriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{0, 1}
+}
+
+type ListAccountsRequest_Order int32
+
+const (
+	ListAccountsRequest_ID_ASCENDING  ListAccountsRequest_Order = 0
+	ListAccountsRequest_ID_DESCENDING ListAccountsRequest_Order = 1
+)
+
+// Enum value maps for ListAccountsRequest_Order.
+var (
+	ListAccountsRequest_Order_name = map[int32]string{
+		0: "ID_ASCENDING",
+		1: "ID_DESCENDING",
+	}
+	ListAccountsRequest_Order_value = map[string]int32{
+		"ID_ASCENDING":  0,
+		"ID_DESCENDING": 1,
+	}
+)
+
+func (x ListAccountsRequest_Order) Enum() *ListAccountsRequest_Order {
+	p := new(ListAccountsRequest_Order)
+	*p = x
+	return p
+}
+
+func (x ListAccountsRequest_Order) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListAccountsRequest_Order) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_account_service_account_service_proto_enumTypes[2].Descriptor()
+}
+
+func (ListAccountsRequest_Order) Type() protoreflect.EnumType {
+	return &file_api_account_service_account_service_proto_enumTypes[2]
+}
+
+func (x ListAccountsRequest_Order) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListAccountsRequest_Order.Descriptor instead.
+func (ListAccountsRequest_Order) EnumDescriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{7, 0}
+}
+
+type ImportAccountsRequest_Format int32
+
+const (
+	ImportAccountsRequest_FORMAT_UNSPECIFIED ImportAccountsRequest_Format = 0
+	// With a header row naming the columns: username, fullname, email,
+	// phone_number, role, status, and password or hashed_password.
+	ImportAccountsRequest_CSV ImportAccountsRequest_Format = 1
+	// One object per line, keyed by the same names as the CSV columns.
+	ImportAccountsRequest_JSONL ImportAccountsRequest_Format = 2
+)
+
+// Enum value maps for ImportAccountsRequest_Format.
+var (
+	ImportAccountsRequest_Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "CSV",
+		2: "JSONL",
+	}
+	ImportAccountsRequest_Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED": 0,
+		"CSV":                1,
+		"JSONL":              2,
+	}
+)
+
+func (x ImportAccountsRequest_Format) Enum() *ImportAccountsRequest_Format {
+	p := new(ImportAccountsRequest_Format)
+	*p = x
+	return p
+}
+
+func (x ImportAccountsRequest_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportAccountsRequest_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_account_service_account_service_proto_enumTypes[3].Descriptor()
+}
+
+func (ImportAccountsRequest_Format) Type() protoreflect.EnumType {
+	return &file_api_account_service_account_service_proto_enumTypes[3]
+}
+
+func (x ImportAccountsRequest_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportAccountsRequest_Format.Descriptor instead.
+func (ImportAccountsRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{11, 0}
+}
+
+type ImportAccountsResponse_RowResult_Outcome int32
+
+const (
+	ImportAccountsResponse_RowResult_OUTCOME_UNSPECIFIED ImportAccountsResponse_RowResult_Outcome = 0
+	ImportAccountsResponse_RowResult_CREATED             ImportAccountsResponse_RowResult_Outcome = 1
+	// The row passed the validation of a dry run.
+	ImportAccountsResponse_RowResult_VALID ImportAccountsResponse_RowResult_Outcome = 2
+	// The username is taken, or used by an earlier row of the import.
+	ImportAccountsResponse_RowResult_DUPLICATE ImportAccountsResponse_RowResult_Outcome = 3
+	ImportAccountsResponse_RowResult_INVALID   ImportAccountsResponse_RowResult_Outcome = 4
+	// The row was valid, yet its account could not be created.
+	ImportAccountsResponse_RowResult_FAILED ImportAccountsResponse_RowResult_Outcome = 5
+)
+
+// Enum value maps for ImportAccountsResponse_RowResult_Outcome.
+var (
+	ImportAccountsResponse_RowResult_Outcome_name = map[int32]string{
+		0: "OUTCOME_UNSPECIFIED",
+		1: "CREATED",
+		2: "VALID",
+		3: "DUPLICATE",
+		4: "INVALID",
+		5: "FAILED",
+	}
+	ImportAccountsResponse_RowResult_Outcome_value = map[string]int32{
+		"OUTCOME_UNSPECIFIED": 0,
+		"CREATED":             1,
+		"VALID":               2,
+		"DUPLICATE":           3,
+		"INVALID":             4,
+		"FAILED":              5,
+	}
+)
+
+func (x ImportAccountsResponse_RowResult_Outcome) Enum() *ImportAccountsResponse_RowResult_Outcome {
+	p := new(ImportAccountsResponse_RowResult_Outcome)
+	*p = x
+	return p
+}
+
+func (x ImportAccountsResponse_RowResult_Outcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportAccountsResponse_RowResult_Outcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_account_service_account_service_proto_enumTypes[4].Descriptor()
+}
+
+func (ImportAccountsResponse_RowResult_Outcome) Type() protoreflect.EnumType {
+	return &file_api_account_service_account_service_proto_enumTypes[4]
+}
+
+func (x ImportAccountsResponse_RowResult_Outcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportAccountsResponse_RowResult_Outcome.Descriptor instead.
+func (ImportAccountsResponse_RowResult_Outcome) EnumDescriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{12, 0, 0}
+}
+
+type SearchAccountsResponse_Highlight_Field int32
+
+const (
+	SearchAccountsResponse_Highlight_FIELD_UNSPECIFIED SearchAccountsResponse_Highlight_Field = 0
+	SearchAccountsResponse_Highlight_USERNAME          SearchAccountsResponse_Highlight_Field = 1
+	SearchAccountsResponse_Highlight_FULLNAME          SearchAccountsResponse_Highlight_Field = 2
+	SearchAccountsResponse_Highlight_EMAIL             SearchAccountsResponse_Highlight_Field = 3
+)
+
+// Enum value maps for SearchAccountsResponse_Highlight_Field.
+var (
+	SearchAccountsResponse_Highlight_Field_name = map[int32]string{
+		0: "FIELD_UNSPECIFIED",
+		1: "USERNAME",
+		2: "FULLNAME",
+		3: "EMAIL",
+	}
+	SearchAccountsResponse_Highlight_Field_value = map[string]int32{
+		"FIELD_UNSPECIFIED": 0,
+		"USERNAME":          1,
+		"FULLNAME":          2,
+		"EMAIL":             3,
+	}
+)
+
+func (x SearchAccountsResponse_Highlight_Field) Enum() *SearchAccountsResponse_Highlight_Field {
+	p := new(SearchAccountsResponse_Highlight_Field)
+	*p = x
+	return p
+}
+
+func (x SearchAccountsResponse_Highlight_Field) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchAccountsResponse_Highlight_Field) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_account_service_account_service_proto_enumTypes[5].Descriptor()
+}
+
+func (SearchAccountsResponse_Highlight_Field) Type() protoreflect.EnumType {
+	return &file_api_account_service_account_service_proto_enumTypes[5]
+}
+
+func (x SearchAccountsResponse_Highlight_Field) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchAccountsResponse_Highlight_Field.Descriptor instead.
+func (SearchAccountsResponse_Highlight_Field) EnumDescriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{14, 0, 0}
+}
+
 type AccountInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Fullname      string                 `protobuf:"bytes,2,opt,name=fullname,proto3" json:"fullname,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	PhoneNumber   string                 `protobuf:"bytes,4,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
-	Role          AccountInfo_Role       `protobuf:"varint,5,opt,name=role,proto3,enum=fiagram.account_service.AccountInfo_Role" json:"role,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Username    string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Fullname    string                 `protobuf:"bytes,2,opt,name=fullname,proto3" json:"fullname,omitempty"`
+	Email       string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	PhoneNumber string                 `protobuf:"bytes,4,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	Role        AccountInfo_Role       `protobuf:"varint,5,opt,name=role,proto3,enum=fiagram.account_service.AccountInfo_Role" json:"role,omitempty"`
+	// Ignored by UpdateAccountInfo, the status only changes through its own RPCs.
+	Status        AccountInfo_Status `protobuf:"varint,6,opt,name=status,proto3,enum=fiagram.account_service.AccountInfo_Status" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -147,6 +419,13 @@ func (x *AccountInfo) GetRole() AccountInfo_Role {
 	return AccountInfo_NONE
 }
 
+func (x *AccountInfo) GetStatus() AccountInfo_Status {
+	if x != nil {
+		return x.Status
+	}
+	return AccountInfo_STATUS_UNSPECIFIED
+}
+
 type CreateAccountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountInfo   *AccountInfo           `protobuf:"bytes,1,opt,name=account_info,json=accountInfo,proto3" json:"account_info,omitempty"`
@@ -288,11 +567,15 @@ func (x *GetAccountRequest) GetAccountId() uint64 {
 }
 
 type GetAccountResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Account       *AccountInfo           `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Account         *AccountInfo           `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	IsEmailVerified bool                   `protobuf:"varint,3,opt,name=is_email_verified,json=isEmailVerified,proto3" json:"is_email_verified,omitempty"`
+	EmailVerifiedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=email_verified_at,json=emailVerifiedAt,proto3" json:"email_verified_at,omitempty"`
+	IsPhoneVerified bool                   `protobuf:"varint,5,opt,name=is_phone_verified,json=isPhoneVerified,proto3" json:"is_phone_verified,omitempty"`
+	PhoneVerifiedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=phone_verified_at,json=phoneVerifiedAt,proto3" json:"phone_verified_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetAccountResponse) Reset() {
@@ -339,6 +622,34 @@ func (x *GetAccountResponse) GetAccount() *AccountInfo {
 	return nil
 }
 
+func (x *GetAccountResponse) GetIsEmailVerified() bool {
+	if x != nil {
+		return x.IsEmailVerified
+	}
+	return false
+}
+
+func (x *GetAccountResponse) GetEmailVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EmailVerifiedAt
+	}
+	return nil
+}
+
+func (x *GetAccountResponse) GetIsPhoneVerified() bool {
+	if x != nil {
+		return x.IsPhoneVerified
+	}
+	return false
+}
+
+func (x *GetAccountResponse) GetPhoneVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PhoneVerifiedAt
+	}
+	return nil
+}
+
 type GetAccountAllRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Empty         *emptypb.Empty         `protobuf:"bytes,1,opt,name=empty,proto3" json:"empty,omitempty"`
@@ -435,27 +746,38 @@ func (x *GetAccountAllResponse) GetAccountInfoList() []*AccountInfo {
 	return nil
 }
 
-type GetAccountListRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountIdList []uint64               `protobuf:"varint,1,rep,packed,name=account_id_list,json=accountIdList,proto3" json:"account_id_list,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetAccountListRequest) Reset() {
-	*x = GetAccountListRequest{}
+type ListAccountsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Zero asks for the default size, sizes above the maximum are capped.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Empty for the first page, otherwise the next_page_token of the previous
+	// page. The filters and the order must stay the same across pages.
+	PageToken string              `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Role      *AccountInfo_Role   `protobuf:"varint,3,opt,name=role,proto3,enum=fiagram.account_service.AccountInfo_Role,oneof" json:"role,omitempty"`
+	Status    *AccountInfo_Status `protobuf:"varint,4,opt,name=status,proto3,enum=fiagram.account_service.AccountInfo_Status,oneof" json:"status,omitempty"`
+	// Both bounds are exclusive, an unset bound leaves that side open.
+	CreatedAfter   *timestamppb.Timestamp    `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore  *timestamppb.Timestamp    `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	UsernamePrefix string                    `protobuf:"bytes,7,opt,name=username_prefix,json=usernamePrefix,proto3" json:"username_prefix,omitempty"`
+	Order          ListAccountsRequest_Order `protobuf:"varint,8,opt,name=order,proto3,enum=fiagram.account_service.ListAccountsRequest_Order" json:"order,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListAccountsRequest) Reset() {
+	*x = ListAccountsRequest{}
 	mi := &file_api_account_service_account_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAccountListRequest) String() string {
+func (x *ListAccountsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAccountListRequest) ProtoMessage() {}
+func (*ListAccountsRequest) ProtoMessage() {}
 
-func (x *GetAccountListRequest) ProtoReflect() protoreflect.Message {
+func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_account_service_account_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -467,40 +789,91 @@ func (x *GetAccountListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAccountListRequest.ProtoReflect.Descriptor instead.
-func (*GetAccountListRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
 	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetAccountListRequest) GetAccountIdList() []uint64 {
+func (x *ListAccountsRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.AccountIdList
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAccountsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListAccountsRequest) GetRole() AccountInfo_Role {
+	if x != nil && x.Role != nil {
+		return *x.Role
+	}
+	return AccountInfo_NONE
+}
+
+func (x *ListAccountsRequest) GetStatus() AccountInfo_Status {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return AccountInfo_STATUS_UNSPECIFIED
+}
+
+func (x *ListAccountsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
 	}
 	return nil
 }
 
-type GetAccountListResponse struct {
+func (x *ListAccountsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListAccountsRequest) GetUsernamePrefix() string {
+	if x != nil {
+		return x.UsernamePrefix
+	}
+	return ""
+}
+
+func (x *ListAccountsRequest) GetOrder() ListAccountsRequest_Order {
+	if x != nil {
+		return x.Order
+	}
+	return ListAccountsRequest_ID_ASCENDING
+}
+
+type ListAccountsResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	AccountIdList   []uint64               `protobuf:"varint,1,rep,packed,name=account_id_list,json=accountIdList,proto3" json:"account_id_list,omitempty"`
 	AccountInfoList []*AccountInfo         `protobuf:"bytes,2,rep,name=account_info_list,json=accountInfoList,proto3" json:"account_info_list,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAccountListResponse) Reset() {
-	*x = GetAccountListResponse{}
+func (x *ListAccountsResponse) Reset() {
+	*x = ListAccountsResponse{}
 	mi := &file_api_account_service_account_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAccountListResponse) String() string {
+func (x *ListAccountsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAccountListResponse) ProtoMessage() {}
+func (*ListAccountsResponse) ProtoMessage() {}
 
-func (x *GetAccountListResponse) ProtoReflect() protoreflect.Message {
+func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_account_service_account_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -512,47 +885,59 @@ func (x *GetAccountListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAccountListResponse.ProtoReflect.Descriptor instead.
-func (*GetAccountListResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
 	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetAccountListResponse) GetAccountIdList() []uint64 {
+func (x *ListAccountsResponse) GetAccountIdList() []uint64 {
 	if x != nil {
 		return x.AccountIdList
 	}
 	return nil
 }
 
-func (x *GetAccountListResponse) GetAccountInfoList() []*AccountInfo {
+func (x *ListAccountsResponse) GetAccountInfoList() []*AccountInfo {
 	if x != nil {
 		return x.AccountInfoList
 	}
 	return nil
 }
 
-type UpdateAccountInfoRequest struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	AccountId          uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	UpdatedAccountInfo *AccountInfo           `protobuf:"bytes,2,opt,name=updated_account_info,json=updatedAccountInfo,proto3" json:"updated_account_info,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *ListAccountsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
 }
 
-func (x *UpdateAccountInfoRequest) Reset() {
-	*x = UpdateAccountInfoRequest{}
+type StreamAccountsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Role   *AccountInfo_Role      `protobuf:"varint,1,opt,name=role,proto3,enum=fiagram.account_service.AccountInfo_Role,oneof" json:"role,omitempty"`
+	Status *AccountInfo_Status    `protobuf:"varint,2,opt,name=status,proto3,enum=fiagram.account_service.AccountInfo_Status,oneof" json:"status,omitempty"`
+	// Both bounds are exclusive, an unset bound leaves that side open.
+	CreatedAfter   *timestamppb.Timestamp    `protobuf:"bytes,3,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore  *timestamppb.Timestamp    `protobuf:"bytes,4,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	UsernamePrefix string                    `protobuf:"bytes,5,opt,name=username_prefix,json=usernamePrefix,proto3" json:"username_prefix,omitempty"`
+	Order          ListAccountsRequest_Order `protobuf:"varint,6,opt,name=order,proto3,enum=fiagram.account_service.ListAccountsRequest_Order" json:"order,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StreamAccountsRequest) Reset() {
+	*x = StreamAccountsRequest{}
 	mi := &file_api_account_service_account_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAccountInfoRequest) String() string {
+func (x *StreamAccountsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAccountInfoRequest) ProtoMessage() {}
+func (*StreamAccountsRequest) ProtoMessage() {}
 
-func (x *UpdateAccountInfoRequest) ProtoReflect() protoreflect.Message {
+func (x *StreamAccountsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_account_service_account_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -564,46 +949,75 @@ func (x *UpdateAccountInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAccountInfoRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAccountInfoRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use StreamAccountsRequest.ProtoReflect.Descriptor instead.
+func (*StreamAccountsRequest) Descriptor() ([]byte, []int) {
 	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *UpdateAccountInfoRequest) GetAccountId() uint64 {
-	if x != nil {
-		return x.AccountId
+func (x *StreamAccountsRequest) GetRole() AccountInfo_Role {
+	if x != nil && x.Role != nil {
+		return *x.Role
 	}
-	return 0
+	return AccountInfo_NONE
 }
 
-func (x *UpdateAccountInfoRequest) GetUpdatedAccountInfo() *AccountInfo {
+func (x *StreamAccountsRequest) GetStatus() AccountInfo_Status {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return AccountInfo_STATUS_UNSPECIFIED
+}
+
+func (x *StreamAccountsRequest) GetCreatedAfter() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UpdatedAccountInfo
+		return x.CreatedAfter
 	}
 	return nil
 }
 
-type UpdateAccountInfoResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *StreamAccountsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
 }
 
-func (x *UpdateAccountInfoResponse) Reset() {
-	*x = UpdateAccountInfoResponse{}
+func (x *StreamAccountsRequest) GetUsernamePrefix() string {
+	if x != nil {
+		return x.UsernamePrefix
+	}
+	return ""
+}
+
+func (x *StreamAccountsRequest) GetOrder() ListAccountsRequest_Order {
+	if x != nil {
+		return x.Order
+	}
+	return ListAccountsRequest_ID_ASCENDING
+}
+
+type StreamAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AccountInfo   *AccountInfo           `protobuf:"bytes,2,opt,name=account_info,json=accountInfo,proto3" json:"account_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAccountsResponse) Reset() {
+	*x = StreamAccountsResponse{}
 	mi := &file_api_account_service_account_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAccountInfoResponse) String() string {
+func (x *StreamAccountsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAccountInfoResponse) ProtoMessage() {}
+func (*StreamAccountsResponse) ProtoMessage() {}
 
-func (x *UpdateAccountInfoResponse) ProtoReflect() protoreflect.Message {
+func (x *StreamAccountsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_account_service_account_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -615,40 +1029,52 @@ func (x *UpdateAccountInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAccountInfoResponse.ProtoReflect.Descriptor instead.
-func (*UpdateAccountInfoResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use StreamAccountsResponse.ProtoReflect.Descriptor instead.
+func (*StreamAccountsResponse) Descriptor() ([]byte, []int) {
 	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *UpdateAccountInfoResponse) GetAccountId() uint64 {
+func (x *StreamAccountsResponse) GetAccountId() uint64 {
 	if x != nil {
 		return x.AccountId
 	}
 	return 0
 }
 
-type UpdateAccountPasswordRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+func (x *StreamAccountsResponse) GetAccountInfo() *AccountInfo {
+	if x != nil {
+		return x.AccountInfo
+	}
+	return nil
+}
+
+type ImportAccountsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Read from the first message only.
+	Format ImportAccountsRequest_Format `protobuf:"varint,1,opt,name=format,proto3,enum=fiagram.account_service.ImportAccountsRequest_Format" json:"format,omitempty"`
+	// Read from the first message only. Validates the rows without creating
+	// any account.
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// The next chunk of the file. A row may span chunks.
+	Chunk         []byte `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAccountPasswordRequest) Reset() {
-	*x = UpdateAccountPasswordRequest{}
+func (x *ImportAccountsRequest) Reset() {
+	*x = ImportAccountsRequest{}
 	mi := &file_api_account_service_account_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAccountPasswordRequest) String() string {
+func (x *ImportAccountsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAccountPasswordRequest) ProtoMessage() {}
+func (*ImportAccountsRequest) ProtoMessage() {}
 
-func (x *UpdateAccountPasswordRequest) ProtoReflect() protoreflect.Message {
+func (x *ImportAccountsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_account_service_account_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -660,46 +1086,59 @@ func (x *UpdateAccountPasswordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAccountPasswordRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAccountPasswordRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImportAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ImportAccountsRequest) Descriptor() ([]byte, []int) {
 	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *UpdateAccountPasswordRequest) GetAccountId() uint64 {
+func (x *ImportAccountsRequest) GetFormat() ImportAccountsRequest_Format {
 	if x != nil {
-		return x.AccountId
+		return x.Format
 	}
-	return 0
+	return ImportAccountsRequest_FORMAT_UNSPECIFIED
 }
 
-func (x *UpdateAccountPasswordRequest) GetPassword() string {
+func (x *ImportAccountsRequest) GetDryRun() bool {
 	if x != nil {
-		return x.Password
+		return x.DryRun
 	}
-	return ""
+	return false
 }
 
-type UpdateAccountPasswordResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ImportAccountsRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
 }
 
-func (x *UpdateAccountPasswordResponse) Reset() {
-	*x = UpdateAccountPasswordResponse{}
+type ImportAccountsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One per row, in the order of the rows.
+	Results        []*ImportAccountsResponse_RowResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	CreatedCount   uint32                              `protobuf:"varint,2,opt,name=created_count,json=createdCount,proto3" json:"created_count,omitempty"`
+	ValidCount     uint32                              `protobuf:"varint,3,opt,name=valid_count,json=validCount,proto3" json:"valid_count,omitempty"`
+	DuplicateCount uint32                              `protobuf:"varint,4,opt,name=duplicate_count,json=duplicateCount,proto3" json:"duplicate_count,omitempty"`
+	InvalidCount   uint32                              `protobuf:"varint,5,opt,name=invalid_count,json=invalidCount,proto3" json:"invalid_count,omitempty"`
+	FailedCount    uint32                              `protobuf:"varint,6,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ImportAccountsResponse) Reset() {
+	*x = ImportAccountsResponse{}
 	mi := &file_api_account_service_account_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAccountPasswordResponse) String() string {
+func (x *ImportAccountsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAccountPasswordResponse) ProtoMessage() {}
+func (*ImportAccountsResponse) ProtoMessage() {}
 
-func (x *UpdateAccountPasswordResponse) ProtoReflect() protoreflect.Message {
+func (x *ImportAccountsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_account_service_account_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -711,39 +1150,81 @@ func (x *UpdateAccountPasswordResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAccountPasswordResponse.ProtoReflect.Descriptor instead.
-func (*UpdateAccountPasswordResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImportAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ImportAccountsResponse) Descriptor() ([]byte, []int) {
 	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *UpdateAccountPasswordResponse) GetAccountId() uint64 {
+func (x *ImportAccountsResponse) GetResults() []*ImportAccountsResponse_RowResult {
 	if x != nil {
-		return x.AccountId
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ImportAccountsResponse) GetCreatedCount() uint32 {
+	if x != nil {
+		return x.CreatedCount
 	}
 	return 0
 }
 
-type DeleteAccountRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+func (x *ImportAccountsResponse) GetValidCount() uint32 {
+	if x != nil {
+		return x.ValidCount
+	}
+	return 0
+}
+
+func (x *ImportAccountsResponse) GetDuplicateCount() uint32 {
+	if x != nil {
+		return x.DuplicateCount
+	}
+	return 0
+}
+
+func (x *ImportAccountsResponse) GetInvalidCount() uint32 {
+	if x != nil {
+		return x.InvalidCount
+	}
+	return 0
+}
+
+func (x *ImportAccountsResponse) GetFailedCount() uint32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+type SearchAccountsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Free text, whose words are matched against the starts of the words of
+	// the username, fullname and email.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Zero asks for the default size, sizes above the maximum are capped.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Empty for the first page, otherwise the next_page_token of the previous
+	// page of the same search.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAccountRequest) Reset() {
-	*x = DeleteAccountRequest{}
+func (x *SearchAccountsRequest) Reset() {
+	*x = SearchAccountsRequest{}
 	mi := &file_api_account_service_account_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAccountRequest) String() string {
+func (x *SearchAccountsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAccountRequest) ProtoMessage() {}
+func (*SearchAccountsRequest) ProtoMessage() {}
 
-func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+func (x *SearchAccountsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_account_service_account_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -755,39 +1236,55 @@ func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
-func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchAccountsRequest.ProtoReflect.Descriptor instead.
+func (*SearchAccountsRequest) Descriptor() ([]byte, []int) {
 	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *DeleteAccountRequest) GetAccountId() uint64 {
+func (x *SearchAccountsRequest) GetQuery() string {
 	if x != nil {
-		return x.AccountId
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchAccountsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
 	}
 	return 0
 }
 
-type DeleteAccountResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+func (x *SearchAccountsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchAccountsResponse struct {
+	state   protoimpl.MessageState           `protogen:"open.v1"`
+	Results []*SearchAccountsResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAccountResponse) Reset() {
-	*x = DeleteAccountResponse{}
+func (x *SearchAccountsResponse) Reset() {
+	*x = SearchAccountsResponse{}
 	mi := &file_api_account_service_account_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAccountResponse) String() string {
+func (x *SearchAccountsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAccountResponse) ProtoMessage() {}
+func (*SearchAccountsResponse) ProtoMessage() {}
 
-func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
+func (x *SearchAccountsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_account_service_account_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -799,39 +1296,46 @@ func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAccountResponse.ProtoReflect.Descriptor instead.
-func (*DeleteAccountResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchAccountsResponse.ProtoReflect.Descriptor instead.
+func (*SearchAccountsResponse) Descriptor() ([]byte, []int) {
 	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *DeleteAccountResponse) GetAccountId() uint64 {
+func (x *SearchAccountsResponse) GetResults() []*SearchAccountsResponse_Result {
 	if x != nil {
-		return x.AccountId
+		return x.Results
 	}
-	return 0
+	return nil
 }
 
-type DeleteAccountByUsernameRequest struct {
+func (x *SearchAccountsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetAccountListRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	AccountIdList []uint64               `protobuf:"varint,1,rep,packed,name=account_id_list,json=accountIdList,proto3" json:"account_id_list,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAccountByUsernameRequest) Reset() {
-	*x = DeleteAccountByUsernameRequest{}
+func (x *GetAccountListRequest) Reset() {
+	*x = GetAccountListRequest{}
 	mi := &file_api_account_service_account_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAccountByUsernameRequest) String() string {
+func (x *GetAccountListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAccountByUsernameRequest) ProtoMessage() {}
+func (*GetAccountListRequest) ProtoMessage() {}
 
-func (x *DeleteAccountByUsernameRequest) ProtoReflect() protoreflect.Message {
+func (x *GetAccountListRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_account_service_account_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -843,39 +1347,40 @@ func (x *DeleteAccountByUsernameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAccountByUsernameRequest.ProtoReflect.Descriptor instead.
-func (*DeleteAccountByUsernameRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAccountListRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountListRequest) Descriptor() ([]byte, []int) {
 	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *DeleteAccountByUsernameRequest) GetUsername() string {
+func (x *GetAccountListRequest) GetAccountIdList() []uint64 {
 	if x != nil {
-		return x.Username
+		return x.AccountIdList
 	}
-	return ""
+	return nil
 }
 
-type DeleteAccountByUsernameResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GetAccountListResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountIdList   []uint64               `protobuf:"varint,1,rep,packed,name=account_id_list,json=accountIdList,proto3" json:"account_id_list,omitempty"`
+	AccountInfoList []*AccountInfo         `protobuf:"bytes,2,rep,name=account_info_list,json=accountInfoList,proto3" json:"account_info_list,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *DeleteAccountByUsernameResponse) Reset() {
-	*x = DeleteAccountByUsernameResponse{}
+func (x *GetAccountListResponse) Reset() {
+	*x = GetAccountListResponse{}
 	mi := &file_api_account_service_account_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAccountByUsernameResponse) String() string {
+func (x *GetAccountListResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAccountByUsernameResponse) ProtoMessage() {}
+func (*GetAccountListResponse) ProtoMessage() {}
 
-func (x *DeleteAccountByUsernameResponse) ProtoReflect() protoreflect.Message {
+func (x *GetAccountListResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_account_service_account_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -887,40 +1392,47 @@ func (x *DeleteAccountByUsernameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAccountByUsernameResponse.ProtoReflect.Descriptor instead.
-func (*DeleteAccountByUsernameResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAccountListResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountListResponse) Descriptor() ([]byte, []int) {
 	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *DeleteAccountByUsernameResponse) GetUsername() string {
+func (x *GetAccountListResponse) GetAccountIdList() []uint64 {
 	if x != nil {
-		return x.Username
+		return x.AccountIdList
 	}
-	return ""
+	return nil
 }
 
-type CheckAccountValidRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetAccountListResponse) GetAccountInfoList() []*AccountInfo {
+	if x != nil {
+		return x.AccountInfoList
+	}
+	return nil
 }
 
-func (x *CheckAccountValidRequest) Reset() {
-	*x = CheckAccountValidRequest{}
+type UpdateAccountInfoRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AccountId          uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	UpdatedAccountInfo *AccountInfo           `protobuf:"bytes,2,opt,name=updated_account_info,json=updatedAccountInfo,proto3" json:"updated_account_info,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UpdateAccountInfoRequest) Reset() {
+	*x = UpdateAccountInfoRequest{}
 	mi := &file_api_account_service_account_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckAccountValidRequest) String() string {
+func (x *UpdateAccountInfoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckAccountValidRequest) ProtoMessage() {}
+func (*UpdateAccountInfoRequest) ProtoMessage() {}
 
-func (x *CheckAccountValidRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateAccountInfoRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_account_service_account_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -932,46 +1444,46 @@ func (x *CheckAccountValidRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckAccountValidRequest.ProtoReflect.Descriptor instead.
-func (*CheckAccountValidRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateAccountInfoRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAccountInfoRequest) Descriptor() ([]byte, []int) {
 	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *CheckAccountValidRequest) GetUsername() string {
+func (x *UpdateAccountInfoRequest) GetAccountId() uint64 {
 	if x != nil {
-		return x.Username
+		return x.AccountId
 	}
-	return ""
+	return 0
 }
 
-func (x *CheckAccountValidRequest) GetPassword() string {
+func (x *UpdateAccountInfoRequest) GetUpdatedAccountInfo() *AccountInfo {
 	if x != nil {
-		return x.Password
+		return x.UpdatedAccountInfo
 	}
-	return ""
+	return nil
 }
 
-type CheckAccountValidResponse struct {
+type UpdateAccountInfoResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckAccountValidResponse) Reset() {
-	*x = CheckAccountValidResponse{}
+func (x *UpdateAccountInfoResponse) Reset() {
+	*x = UpdateAccountInfoResponse{}
 	mi := &file_api_account_service_account_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckAccountValidResponse) String() string {
+func (x *UpdateAccountInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckAccountValidResponse) ProtoMessage() {}
+func (*UpdateAccountInfoResponse) ProtoMessage() {}
 
-func (x *CheckAccountValidResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateAccountInfoResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_account_service_account_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -983,40 +1495,3186 @@ func (x *CheckAccountValidResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckAccountValidResponse.ProtoReflect.Descriptor instead.
-func (*CheckAccountValidResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateAccountInfoResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAccountInfoResponse) Descriptor() ([]byte, []int) {
 	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *CheckAccountValidResponse) GetAccountId() uint64 {
+func (x *UpdateAccountInfoResponse) GetAccountId() uint64 {
 	if x != nil {
 		return x.AccountId
 	}
 	return 0
 }
 
-type IsUsernameTakenRequest struct {
+type UpdateAccountPasswordRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IsUsernameTakenRequest) Reset() {
-	*x = IsUsernameTakenRequest{}
+func (x *UpdateAccountPasswordRequest) Reset() {
+	*x = UpdateAccountPasswordRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAccountPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAccountPasswordRequest) ProtoMessage() {}
+
+func (x *UpdateAccountPasswordRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_account_service_account_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAccountPasswordRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAccountPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateAccountPasswordRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *UpdateAccountPasswordRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type UpdateAccountPasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAccountPasswordResponse) Reset() {
+	*x = UpdateAccountPasswordResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAccountPasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAccountPasswordResponse) ProtoMessage() {}
+
+func (x *UpdateAccountPasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAccountPasswordResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAccountPasswordResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateAccountPasswordResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type ChangePasswordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	CurrentPassword string                 `protobuf:"bytes,2,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	NewPassword     string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ChangePasswordRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *ChangePasswordRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ChangePasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordResponse) ProtoMessage() {}
+
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ChangePasswordResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type RequestPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RequestPasswordResetRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type RequestPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetResponse) Reset() {
+	*x = RequestPasswordResetResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetResponse) ProtoMessage() {}
+
+func (x *RequestPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RequestPasswordResetResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type ConfirmPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPasswordResetRequest) Reset() {
+	*x = ConfirmPasswordResetRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPasswordResetRequest) ProtoMessage() {}
+
+func (x *ConfirmPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ConfirmPasswordResetRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ConfirmPasswordResetRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ConfirmPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPasswordResetResponse) Reset() {
+	*x = ConfirmPasswordResetResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPasswordResetResponse) ProtoMessage() {}
+
+func (x *ConfirmPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ConfirmPasswordResetResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type DeleteAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteAccountRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type DeleteAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountResponse) Reset() {
+	*x = DeleteAccountResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountResponse) ProtoMessage() {}
+
+func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAccountResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteAccountResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type DeleteAccountByUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountByUsernameRequest) Reset() {
+	*x = DeleteAccountByUsernameRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountByUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountByUsernameRequest) ProtoMessage() {}
+
+func (x *DeleteAccountByUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountByUsernameRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountByUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteAccountByUsernameRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type DeleteAccountByUsernameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountByUsernameResponse) Reset() {
+	*x = DeleteAccountByUsernameResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountByUsernameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountByUsernameResponse) ProtoMessage() {}
+
+func (x *DeleteAccountByUsernameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountByUsernameResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAccountByUsernameResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteAccountByUsernameResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type RestoreAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreAccountRequest) Reset() {
+	*x = RestoreAccountRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreAccountRequest) ProtoMessage() {}
+
+func (x *RestoreAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreAccountRequest.ProtoReflect.Descriptor instead.
+func (*RestoreAccountRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RestoreAccountRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type RestoreAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreAccountResponse) Reset() {
+	*x = RestoreAccountResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreAccountResponse) ProtoMessage() {}
+
+func (x *RestoreAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreAccountResponse.ProtoReflect.Descriptor instead.
+func (*RestoreAccountResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RestoreAccountResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type CheckAccountValidRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAccountValidRequest) Reset() {
+	*x = CheckAccountValidRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAccountValidRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAccountValidRequest) ProtoMessage() {}
+
+func (x *CheckAccountValidRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAccountValidRequest.ProtoReflect.Descriptor instead.
+func (*CheckAccountValidRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CheckAccountValidRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CheckAccountValidRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type CheckAccountValidResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAccountValidResponse) Reset() {
+	*x = CheckAccountValidResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAccountValidResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAccountValidResponse) ProtoMessage() {}
+
+func (x *CheckAccountValidResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAccountValidResponse.ProtoReflect.Descriptor instead.
+func (*CheckAccountValidResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CheckAccountValidResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type IsUsernameTakenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsUsernameTakenRequest) Reset() {
+	*x = IsUsernameTakenRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsUsernameTakenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsUsernameTakenRequest) ProtoMessage() {}
+
+func (x *IsUsernameTakenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsUsernameTakenRequest.ProtoReflect.Descriptor instead.
+func (*IsUsernameTakenRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *IsUsernameTakenRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type IsUsernameTakenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsTaken       bool                   `protobuf:"varint,1,opt,name=is_taken,json=isTaken,proto3" json:"is_taken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsUsernameTakenResponse) Reset() {
+	*x = IsUsernameTakenResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsUsernameTakenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsUsernameTakenResponse) ProtoMessage() {}
+
+func (x *IsUsernameTakenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsUsernameTakenResponse.ProtoReflect.Descriptor instead.
+func (*IsUsernameTakenResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *IsUsernameTakenResponse) GetIsTaken() bool {
+	if x != nil {
+		return x.IsTaken
+	}
+	return false
+}
+
+type IssueRefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueRefreshTokenRequest) Reset() {
+	*x = IssueRefreshTokenRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueRefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueRefreshTokenRequest) ProtoMessage() {}
+
+func (x *IssueRefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueRefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*IssueRefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *IssueRefreshTokenRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type IssueRefreshTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueRefreshTokenResponse) Reset() {
+	*x = IssueRefreshTokenResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueRefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueRefreshTokenResponse) ProtoMessage() {}
+
+func (x *IssueRefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueRefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*IssueRefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *IssueRefreshTokenResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *IssueRefreshTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type RotateRefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateRefreshTokenRequest) Reset() {
+	*x = RotateRefreshTokenRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateRefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateRefreshTokenRequest) ProtoMessage() {}
+
+func (x *RotateRefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateRefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RotateRefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RotateRefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RotateRefreshTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateRefreshTokenResponse) Reset() {
+	*x = RotateRefreshTokenResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateRefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateRefreshTokenResponse) ProtoMessage() {}
+
+func (x *RotateRefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateRefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RotateRefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RotateRefreshTokenResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *RotateRefreshTokenResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *RotateRefreshTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type RevokeRefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeRefreshTokenRequest) Reset() {
+	*x = RevokeRefreshTokenRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeRefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeRefreshTokenRequest) ProtoMessage() {}
+
+func (x *RevokeRefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeRefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeRefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RevokeRefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RevokeRefreshTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Empty         *emptypb.Empty         `protobuf:"bytes,1,opt,name=empty,proto3" json:"empty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeRefreshTokenResponse) Reset() {
+	*x = RevokeRefreshTokenResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeRefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeRefreshTokenResponse) ProtoMessage() {}
+
+func (x *RevokeRefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeRefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeRefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RevokeRefreshTokenResponse) GetEmpty() *emptypb.Empty {
+	if x != nil {
+		return x.Empty
+	}
+	return nil
+}
+
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *LoginRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type LoginResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	AccountId             uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TokenType             string                 `protobuf:"bytes,2,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`
+	AccessToken           string                 `protobuf:"bytes,3,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	AccessTokenExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=access_token_expires_at,json=accessTokenExpiresAt,proto3" json:"access_token_expires_at,omitempty"`
+	RefreshToken          string                 `protobuf:"bytes,5,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	RefreshTokenExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=refresh_token_expires_at,json=refreshTokenExpiresAt,proto3" json:"refresh_token_expires_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *LoginResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *LoginResponse) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetAccessTokenExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AccessTokenExpiresAt
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetRefreshTokenExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshTokenExpiresAt
+	}
+	return nil
+}
+
+type VerifySecondFactorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeId   string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifySecondFactorRequest) Reset() {
+	*x = VerifySecondFactorRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifySecondFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifySecondFactorRequest) ProtoMessage() {}
+
+func (x *VerifySecondFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifySecondFactorRequest.ProtoReflect.Descriptor instead.
+func (*VerifySecondFactorRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *VerifySecondFactorRequest) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *VerifySecondFactorRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type VerifySecondFactorResponse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	AccountId              uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TokenType              string                 `protobuf:"bytes,2,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`
+	AccessToken            string                 `protobuf:"bytes,3,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	AccessTokenExpiresAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=access_token_expires_at,json=accessTokenExpiresAt,proto3" json:"access_token_expires_at,omitempty"`
+	RefreshToken           string                 `protobuf:"bytes,5,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	RefreshTokenExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=refresh_token_expires_at,json=refreshTokenExpiresAt,proto3" json:"refresh_token_expires_at,omitempty"`
+	IsRecoveryCodeUsed     bool                   `protobuf:"varint,7,opt,name=is_recovery_code_used,json=isRecoveryCodeUsed,proto3" json:"is_recovery_code_used,omitempty"`
+	RemainingRecoveryCodes uint32                 `protobuf:"varint,8,opt,name=remaining_recovery_codes,json=remainingRecoveryCodes,proto3" json:"remaining_recovery_codes,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *VerifySecondFactorResponse) Reset() {
+	*x = VerifySecondFactorResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifySecondFactorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifySecondFactorResponse) ProtoMessage() {}
+
+func (x *VerifySecondFactorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifySecondFactorResponse.ProtoReflect.Descriptor instead.
+func (*VerifySecondFactorResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *VerifySecondFactorResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *VerifySecondFactorResponse) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *VerifySecondFactorResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *VerifySecondFactorResponse) GetAccessTokenExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AccessTokenExpiresAt
+	}
+	return nil
+}
+
+func (x *VerifySecondFactorResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *VerifySecondFactorResponse) GetRefreshTokenExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshTokenExpiresAt
+	}
+	return nil
+}
+
+func (x *VerifySecondFactorResponse) GetIsRecoveryCodeUsed() bool {
+	if x != nil {
+		return x.IsRecoveryCodeUsed
+	}
+	return false
+}
+
+func (x *VerifySecondFactorResponse) GetRemainingRecoveryCodes() uint32 {
+	if x != nil {
+		return x.RemainingRecoveryCodes
+	}
+	return 0
+}
+
+type JsonWebKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kty           string                 `protobuf:"bytes,1,opt,name=kty,proto3" json:"kty,omitempty"`
+	Kid           string                 `protobuf:"bytes,2,opt,name=kid,proto3" json:"kid,omitempty"`
+	Use           string                 `protobuf:"bytes,3,opt,name=use,proto3" json:"use,omitempty"`
+	Alg           string                 `protobuf:"bytes,4,opt,name=alg,proto3" json:"alg,omitempty"`
+	Crv           string                 `protobuf:"bytes,5,opt,name=crv,proto3" json:"crv,omitempty"`
+	X             string                 `protobuf:"bytes,6,opt,name=x,proto3" json:"x,omitempty"`
+	Y             string                 `protobuf:"bytes,7,opt,name=y,proto3" json:"y,omitempty"`
+	N             string                 `protobuf:"bytes,8,opt,name=n,proto3" json:"n,omitempty"`
+	E             string                 `protobuf:"bytes,9,opt,name=e,proto3" json:"e,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JsonWebKey) Reset() {
+	*x = JsonWebKey{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JsonWebKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JsonWebKey) ProtoMessage() {}
+
+func (x *JsonWebKey) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JsonWebKey.ProtoReflect.Descriptor instead.
+func (*JsonWebKey) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *JsonWebKey) GetKty() string {
+	if x != nil {
+		return x.Kty
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetKid() string {
+	if x != nil {
+		return x.Kid
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetUse() string {
+	if x != nil {
+		return x.Use
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetAlg() string {
+	if x != nil {
+		return x.Alg
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetCrv() string {
+	if x != nil {
+		return x.Crv
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetY() string {
+	if x != nil {
+		return x.Y
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetN() string {
+	if x != nil {
+		return x.N
+	}
+	return ""
+}
+
+func (x *JsonWebKey) GetE() string {
+	if x != nil {
+		return x.E
+	}
+	return ""
+}
+
+type GetJWKSRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Empty         *emptypb.Empty         `protobuf:"bytes,1,opt,name=empty,proto3" json:"empty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJWKSRequest) Reset() {
+	*x = GetJWKSRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJWKSRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJWKSRequest) ProtoMessage() {}
+
+func (x *GetJWKSRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJWKSRequest.ProtoReflect.Descriptor instead.
+func (*GetJWKSRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetJWKSRequest) GetEmpty() *emptypb.Empty {
+	if x != nil {
+		return x.Empty
+	}
+	return nil
+}
+
+type GetJWKSResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*JsonWebKey          `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJWKSResponse) Reset() {
+	*x = GetJWKSResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJWKSResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJWKSResponse) ProtoMessage() {}
+
+func (x *GetJWKSResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJWKSResponse.ProtoReflect.Descriptor instead.
+func (*GetJWKSResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetJWKSResponse) GetKeys() []*JsonWebKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type UnlockAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockAccountRequest) Reset() {
+	*x = UnlockAccountRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockAccountRequest) ProtoMessage() {}
+
+func (x *UnlockAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockAccountRequest.ProtoReflect.Descriptor instead.
+func (*UnlockAccountRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *UnlockAccountRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type UnlockAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockAccountResponse) Reset() {
+	*x = UnlockAccountResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockAccountResponse) ProtoMessage() {}
+
+func (x *UnlockAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockAccountResponse.ProtoReflect.Descriptor instead.
+func (*UnlockAccountResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *UnlockAccountResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type SuspendAccountRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountId      uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Reason         string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	ActorAccountId uint64                 `protobuf:"varint,3,opt,name=actor_account_id,json=actorAccountId,proto3" json:"actor_account_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SuspendAccountRequest) Reset() {
+	*x = SuspendAccountRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendAccountRequest) ProtoMessage() {}
+
+func (x *SuspendAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendAccountRequest.ProtoReflect.Descriptor instead.
+func (*SuspendAccountRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SuspendAccountRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *SuspendAccountRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SuspendAccountRequest) GetActorAccountId() uint64 {
+	if x != nil {
+		return x.ActorAccountId
+	}
+	return 0
+}
+
+type SuspendAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Status        AccountInfo_Status     `protobuf:"varint,2,opt,name=status,proto3,enum=fiagram.account_service.AccountInfo_Status" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendAccountResponse) Reset() {
+	*x = SuspendAccountResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendAccountResponse) ProtoMessage() {}
+
+func (x *SuspendAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendAccountResponse.ProtoReflect.Descriptor instead.
+func (*SuspendAccountResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SuspendAccountResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *SuspendAccountResponse) GetStatus() AccountInfo_Status {
+	if x != nil {
+		return x.Status
+	}
+	return AccountInfo_STATUS_UNSPECIFIED
+}
+
+type ReactivateAccountRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountId      uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Reason         string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	ActorAccountId uint64                 `protobuf:"varint,3,opt,name=actor_account_id,json=actorAccountId,proto3" json:"actor_account_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReactivateAccountRequest) Reset() {
+	*x = ReactivateAccountRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactivateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactivateAccountRequest) ProtoMessage() {}
+
+func (x *ReactivateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactivateAccountRequest.ProtoReflect.Descriptor instead.
+func (*ReactivateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ReactivateAccountRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *ReactivateAccountRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReactivateAccountRequest) GetActorAccountId() uint64 {
+	if x != nil {
+		return x.ActorAccountId
+	}
+	return 0
+}
+
+type ReactivateAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Status        AccountInfo_Status     `protobuf:"varint,2,opt,name=status,proto3,enum=fiagram.account_service.AccountInfo_Status" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactivateAccountResponse) Reset() {
+	*x = ReactivateAccountResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactivateAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactivateAccountResponse) ProtoMessage() {}
+
+func (x *ReactivateAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactivateAccountResponse.ProtoReflect.Descriptor instead.
+func (*ReactivateAccountResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ReactivateAccountResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *ReactivateAccountResponse) GetStatus() AccountInfo_Status {
+	if x != nil {
+		return x.Status
+	}
+	return AccountInfo_STATUS_UNSPECIFIED
+}
+
+type DeactivateAccountRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountId      uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Reason         string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	ActorAccountId uint64                 `protobuf:"varint,3,opt,name=actor_account_id,json=actorAccountId,proto3" json:"actor_account_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeactivateAccountRequest) Reset() {
+	*x = DeactivateAccountRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateAccountRequest) ProtoMessage() {}
+
+func (x *DeactivateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *DeactivateAccountRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *DeactivateAccountRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeactivateAccountRequest) GetActorAccountId() uint64 {
+	if x != nil {
+		return x.ActorAccountId
+	}
+	return 0
+}
+
+type DeactivateAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Status        AccountInfo_Status     `protobuf:"varint,2,opt,name=status,proto3,enum=fiagram.account_service.AccountInfo_Status" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateAccountResponse) Reset() {
+	*x = DeactivateAccountResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateAccountResponse) ProtoMessage() {}
+
+func (x *DeactivateAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeactivateAccountResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *DeactivateAccountResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *DeactivateAccountResponse) GetStatus() AccountInfo_Status {
+	if x != nil {
+		return x.Status
+	}
+	return AccountInfo_STATUS_UNSPECIFIED
+}
+
+type SendEmailVerificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendEmailVerificationRequest) Reset() {
+	*x = SendEmailVerificationRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendEmailVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendEmailVerificationRequest) ProtoMessage() {}
+
+func (x *SendEmailVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendEmailVerificationRequest.ProtoReflect.Descriptor instead.
+func (*SendEmailVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SendEmailVerificationRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type SendEmailVerificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendEmailVerificationResponse) Reset() {
+	*x = SendEmailVerificationResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendEmailVerificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendEmailVerificationResponse) ProtoMessage() {}
+
+func (x *SendEmailVerificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendEmailVerificationResponse.ProtoReflect.Descriptor instead.
+func (*SendEmailVerificationResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *SendEmailVerificationResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *SendEmailVerificationResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type VerifyEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailRequest) ProtoMessage() {}
+
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *VerifyEmailRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *VerifyEmailRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type VerifyEmailResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	EmailVerifiedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=email_verified_at,json=emailVerifiedAt,proto3" json:"email_verified_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VerifyEmailResponse) Reset() {
+	*x = VerifyEmailResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailResponse) ProtoMessage() {}
+
+func (x *VerifyEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailResponse.ProtoReflect.Descriptor instead.
+func (*VerifyEmailResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *VerifyEmailResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *VerifyEmailResponse) GetEmailVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EmailVerifiedAt
+	}
+	return nil
+}
+
+type SendPhoneVerificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendPhoneVerificationRequest) Reset() {
+	*x = SendPhoneVerificationRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendPhoneVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendPhoneVerificationRequest) ProtoMessage() {}
+
+func (x *SendPhoneVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendPhoneVerificationRequest.ProtoReflect.Descriptor instead.
+func (*SendPhoneVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SendPhoneVerificationRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type SendPhoneVerificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendPhoneVerificationResponse) Reset() {
+	*x = SendPhoneVerificationResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendPhoneVerificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendPhoneVerificationResponse) ProtoMessage() {}
+
+func (x *SendPhoneVerificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendPhoneVerificationResponse.ProtoReflect.Descriptor instead.
+func (*SendPhoneVerificationResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SendPhoneVerificationResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *SendPhoneVerificationResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type VerifyPhoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPhoneRequest) Reset() {
+	*x = VerifyPhoneRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPhoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPhoneRequest) ProtoMessage() {}
+
+func (x *VerifyPhoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPhoneRequest.ProtoReflect.Descriptor instead.
+func (*VerifyPhoneRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *VerifyPhoneRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *VerifyPhoneRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type VerifyPhoneResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	PhoneVerifiedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=phone_verified_at,json=phoneVerifiedAt,proto3" json:"phone_verified_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VerifyPhoneResponse) Reset() {
+	*x = VerifyPhoneResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPhoneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPhoneResponse) ProtoMessage() {}
+
+func (x *VerifyPhoneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPhoneResponse.ProtoReflect.Descriptor instead.
+func (*VerifyPhoneResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *VerifyPhoneResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *VerifyPhoneResponse) GetPhoneVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PhoneVerifiedAt
+	}
+	return nil
+}
+
+type EnrollTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPRequest) Reset() {
+	*x = EnrollTOTPRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPRequest) ProtoMessage() {}
+
+func (x *EnrollTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *EnrollTOTPRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type EnrollTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	Uri           string                 `protobuf:"bytes,3,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPResponse) Reset() {
+	*x = EnrollTOTPResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPResponse) ProtoMessage() {}
+
+func (x *EnrollTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *EnrollTOTPResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *EnrollTOTPResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollTOTPResponse) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+type ConfirmTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPRequest) Reset() {
+	*x = ConfirmTOTPRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPRequest) ProtoMessage() {}
+
+func (x *ConfirmTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ConfirmTOTPRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *ConfirmTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPResponse) Reset() {
+	*x = ConfirmTOTPResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPResponse) ProtoMessage() {}
+
+func (x *ConfirmTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ConfirmTOTPResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type DisableTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPRequest) ProtoMessage() {}
+
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *DisableTOTPRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *DisableTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type DisableTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPResponse) Reset() {
+	*x = DisableTOTPResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPResponse) ProtoMessage() {}
+
+func (x *DisableTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPResponse.ProtoReflect.Descriptor instead.
+func (*DisableTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *DisableTOTPResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type GenerateRecoveryCodesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateRecoveryCodesRequest) Reset() {
+	*x = GenerateRecoveryCodesRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateRecoveryCodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateRecoveryCodesRequest) ProtoMessage() {}
+
+func (x *GenerateRecoveryCodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateRecoveryCodesRequest.ProtoReflect.Descriptor instead.
+func (*GenerateRecoveryCodesRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GenerateRecoveryCodesRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type GenerateRecoveryCodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Codes         []string               `protobuf:"bytes,2,rep,name=codes,proto3" json:"codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateRecoveryCodesResponse) Reset() {
+	*x = GenerateRecoveryCodesResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateRecoveryCodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateRecoveryCodesResponse) ProtoMessage() {}
+
+func (x *GenerateRecoveryCodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateRecoveryCodesResponse.ProtoReflect.Descriptor instead.
+func (*GenerateRecoveryCodesResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GenerateRecoveryCodesResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *GenerateRecoveryCodesResponse) GetCodes() []string {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+type BeginWebAuthnRegistrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginWebAuthnRegistrationRequest) Reset() {
+	*x = BeginWebAuthnRegistrationRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginWebAuthnRegistrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginWebAuthnRegistrationRequest) ProtoMessage() {}
+
+func (x *BeginWebAuthnRegistrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginWebAuthnRegistrationRequest.ProtoReflect.Descriptor instead.
+func (*BeginWebAuthnRegistrationRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *BeginWebAuthnRegistrationRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type BeginWebAuthnRegistrationResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	SessionId string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// JSON encoded options for navigator.credentials.create()
+	Options       string                 `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginWebAuthnRegistrationResponse) Reset() {
+	*x = BeginWebAuthnRegistrationResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginWebAuthnRegistrationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginWebAuthnRegistrationResponse) ProtoMessage() {}
+
+func (x *BeginWebAuthnRegistrationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginWebAuthnRegistrationResponse.ProtoReflect.Descriptor instead.
+func (*BeginWebAuthnRegistrationResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *BeginWebAuthnRegistrationResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *BeginWebAuthnRegistrationResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *BeginWebAuthnRegistrationResponse) GetOptions() string {
+	if x != nil {
+		return x.Options
+	}
+	return ""
+}
+
+func (x *BeginWebAuthnRegistrationResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type FinishWebAuthnRegistrationRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// JSON encoded PublicKeyCredential
+	Credential    string `protobuf:"bytes,2,opt,name=credential,proto3" json:"credential,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishWebAuthnRegistrationRequest) Reset() {
+	*x = FinishWebAuthnRegistrationRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishWebAuthnRegistrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishWebAuthnRegistrationRequest) ProtoMessage() {}
+
+func (x *FinishWebAuthnRegistrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishWebAuthnRegistrationRequest.ProtoReflect.Descriptor instead.
+func (*FinishWebAuthnRegistrationRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *FinishWebAuthnRegistrationRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *FinishWebAuthnRegistrationRequest) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+type FinishWebAuthnRegistrationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	CredentialId  string                 `protobuf:"bytes,2,opt,name=credential_id,json=credentialId,proto3" json:"credential_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishWebAuthnRegistrationResponse) Reset() {
+	*x = FinishWebAuthnRegistrationResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishWebAuthnRegistrationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishWebAuthnRegistrationResponse) ProtoMessage() {}
+
+func (x *FinishWebAuthnRegistrationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishWebAuthnRegistrationResponse.ProtoReflect.Descriptor instead.
+func (*FinishWebAuthnRegistrationResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *FinishWebAuthnRegistrationResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *FinishWebAuthnRegistrationResponse) GetCredentialId() string {
+	if x != nil {
+		return x.CredentialId
+	}
+	return ""
+}
+
+type BeginWebAuthnAssertionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional, the authenticator picks a passkey when empty
+	Username      string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginWebAuthnAssertionRequest) Reset() {
+	*x = BeginWebAuthnAssertionRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginWebAuthnAssertionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginWebAuthnAssertionRequest) ProtoMessage() {}
+
+func (x *BeginWebAuthnAssertionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginWebAuthnAssertionRequest.ProtoReflect.Descriptor instead.
+func (*BeginWebAuthnAssertionRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *BeginWebAuthnAssertionRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type BeginWebAuthnAssertionResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// JSON encoded options for navigator.credentials.get()
+	Options       string                 `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginWebAuthnAssertionResponse) Reset() {
+	*x = BeginWebAuthnAssertionResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginWebAuthnAssertionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginWebAuthnAssertionResponse) ProtoMessage() {}
+
+func (x *BeginWebAuthnAssertionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginWebAuthnAssertionResponse.ProtoReflect.Descriptor instead.
+func (*BeginWebAuthnAssertionResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *BeginWebAuthnAssertionResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *BeginWebAuthnAssertionResponse) GetOptions() string {
+	if x != nil {
+		return x.Options
+	}
+	return ""
+}
+
+func (x *BeginWebAuthnAssertionResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type FinishWebAuthnAssertionRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// JSON encoded PublicKeyCredential
+	Credential    string `protobuf:"bytes,2,opt,name=credential,proto3" json:"credential,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishWebAuthnAssertionRequest) Reset() {
+	*x = FinishWebAuthnAssertionRequest{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IsUsernameTakenRequest) String() string {
+func (x *FinishWebAuthnAssertionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IsUsernameTakenRequest) ProtoMessage() {}
+func (*FinishWebAuthnAssertionRequest) ProtoMessage() {}
 
-func (x *IsUsernameTakenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_account_service_account_service_proto_msgTypes[19]
+func (x *FinishWebAuthnAssertionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1027,40 +4685,212 @@ func (x *IsUsernameTakenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IsUsernameTakenRequest.ProtoReflect.Descriptor instead.
-func (*IsUsernameTakenRequest) Descriptor() ([]byte, []int) {
-	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use FinishWebAuthnAssertionRequest.ProtoReflect.Descriptor instead.
+func (*FinishWebAuthnAssertionRequest) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *IsUsernameTakenRequest) GetUsername() string {
+func (x *FinishWebAuthnAssertionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *FinishWebAuthnAssertionRequest) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+type FinishWebAuthnAssertionResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	AccountId             uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TokenType             string                 `protobuf:"bytes,2,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`
+	AccessToken           string                 `protobuf:"bytes,3,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	AccessTokenExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=access_token_expires_at,json=accessTokenExpiresAt,proto3" json:"access_token_expires_at,omitempty"`
+	RefreshToken          string                 `protobuf:"bytes,5,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	RefreshTokenExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=refresh_token_expires_at,json=refreshTokenExpiresAt,proto3" json:"refresh_token_expires_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *FinishWebAuthnAssertionResponse) Reset() {
+	*x = FinishWebAuthnAssertionResponse{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishWebAuthnAssertionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishWebAuthnAssertionResponse) ProtoMessage() {}
+
+func (x *FinishWebAuthnAssertionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishWebAuthnAssertionResponse.ProtoReflect.Descriptor instead.
+func (*FinishWebAuthnAssertionResponse) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *FinishWebAuthnAssertionResponse) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *FinishWebAuthnAssertionResponse) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *FinishWebAuthnAssertionResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *FinishWebAuthnAssertionResponse) GetAccessTokenExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AccessTokenExpiresAt
+	}
+	return nil
+}
+
+func (x *FinishWebAuthnAssertionResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *FinishWebAuthnAssertionResponse) GetRefreshTokenExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshTokenExpiresAt
+	}
+	return nil
+}
+
+type ImportAccountsResponse_RowResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Counts the rows from one, leaving the header of a CSV file out.
+	Row       uint32                                   `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Username  string                                   `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Outcome   ImportAccountsResponse_RowResult_Outcome `protobuf:"varint,3,opt,name=outcome,proto3,enum=fiagram.account_service.ImportAccountsResponse_RowResult_Outcome" json:"outcome,omitempty"`
+	AccountId uint64                                   `protobuf:"varint,4,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Tells why the row was not imported.
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportAccountsResponse_RowResult) Reset() {
+	*x = ImportAccountsResponse_RowResult{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportAccountsResponse_RowResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportAccountsResponse_RowResult) ProtoMessage() {}
+
+func (x *ImportAccountsResponse_RowResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportAccountsResponse_RowResult.ProtoReflect.Descriptor instead.
+func (*ImportAccountsResponse_RowResult) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{12, 0}
+}
+
+func (x *ImportAccountsResponse_RowResult) GetRow() uint32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportAccountsResponse_RowResult) GetUsername() string {
 	if x != nil {
 		return x.Username
 	}
 	return ""
 }
 
-type IsUsernameTakenResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	IsTaken       bool                   `protobuf:"varint,1,opt,name=is_taken,json=isTaken,proto3" json:"is_taken,omitempty"`
+func (x *ImportAccountsResponse_RowResult) GetOutcome() ImportAccountsResponse_RowResult_Outcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return ImportAccountsResponse_RowResult_OUTCOME_UNSPECIFIED
+}
+
+func (x *ImportAccountsResponse_RowResult) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *ImportAccountsResponse_RowResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SearchAccountsResponse_Highlight struct {
+	state protoimpl.MessageState                 `protogen:"open.v1"`
+	Field SearchAccountsResponse_Highlight_Field `protobuf:"varint,1,opt,name=field,proto3,enum=fiagram.account_service.SearchAccountsResponse_Highlight_Field" json:"field,omitempty"`
+	// The field with its matched parts wrapped in <em> tags. The rest of the
+	// field is HTML escaped.
+	Highlighted   string `protobuf:"bytes,2,opt,name=highlighted,proto3" json:"highlighted,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *IsUsernameTakenResponse) Reset() {
-	*x = IsUsernameTakenResponse{}
-	mi := &file_api_account_service_account_service_proto_msgTypes[20]
+func (x *SearchAccountsResponse_Highlight) Reset() {
+	*x = SearchAccountsResponse_Highlight{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IsUsernameTakenResponse) String() string {
+func (x *SearchAccountsResponse_Highlight) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IsUsernameTakenResponse) ProtoMessage() {}
+func (*SearchAccountsResponse_Highlight) ProtoMessage() {}
 
-func (x *IsUsernameTakenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_account_service_account_service_proto_msgTypes[20]
+func (x *SearchAccountsResponse_Highlight) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1071,34 +4901,120 @@ func (x *IsUsernameTakenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IsUsernameTakenResponse.ProtoReflect.Descriptor instead.
-func (*IsUsernameTakenResponse) Descriptor() ([]byte, []int) {
-	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use SearchAccountsResponse_Highlight.ProtoReflect.Descriptor instead.
+func (*SearchAccountsResponse_Highlight) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{14, 0}
 }
 
-func (x *IsUsernameTakenResponse) GetIsTaken() bool {
+func (x *SearchAccountsResponse_Highlight) GetField() SearchAccountsResponse_Highlight_Field {
 	if x != nil {
-		return x.IsTaken
+		return x.Field
 	}
-	return false
+	return SearchAccountsResponse_Highlight_FIELD_UNSPECIFIED
+}
+
+func (x *SearchAccountsResponse_Highlight) GetHighlighted() string {
+	if x != nil {
+		return x.Highlighted
+	}
+	return ""
+}
+
+type SearchAccountsResponse_Result struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AccountId   uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AccountInfo *AccountInfo           `protobuf:"bytes,2,opt,name=account_info,json=accountInfo,proto3" json:"account_info,omitempty"`
+	// Higher is more relevant. Scores compare within one search only.
+	Score         float64                             `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	Highlights    []*SearchAccountsResponse_Highlight `protobuf:"bytes,4,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchAccountsResponse_Result) Reset() {
+	*x = SearchAccountsResponse_Result{}
+	mi := &file_api_account_service_account_service_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchAccountsResponse_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAccountsResponse_Result) ProtoMessage() {}
+
+func (x *SearchAccountsResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_api_account_service_account_service_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAccountsResponse_Result.ProtoReflect.Descriptor instead.
+func (*SearchAccountsResponse_Result) Descriptor() ([]byte, []int) {
+	return file_api_account_service_account_service_proto_rawDescGZIP(), []int{14, 1}
+}
+
+func (x *SearchAccountsResponse_Result) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *SearchAccountsResponse_Result) GetAccountInfo() *AccountInfo {
+	if x != nil {
+		return x.AccountInfo
+	}
+	return nil
+}
+
+func (x *SearchAccountsResponse_Result) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchAccountsResponse_Result) GetHighlights() []*SearchAccountsResponse_Highlight {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
 }
 
 var File_api_account_service_account_service_proto protoreflect.FileDescriptor
 
 const file_api_account_service_account_service_proto_rawDesc = "" +
 	"\n" +
-	")api/account_service/account_service.proto\x12\x17fiagram.account_service\x1a\x1bgoogle/protobuf/empty.proto\"\xe6\x01\n" +
+	")api/account_service/account_service.proto\x12\x17fiagram.account_service\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x92\x03\n" +
 	"\vAccountInfo\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
 	"\bfullname\x18\x02 \x01(\tR\bfullname\x12\x14\n" +
 	"\x05email\x18\x03 \x01(\tR\x05email\x12!\n" +
 	"\fphone_number\x18\x04 \x01(\tR\vphoneNumber\x12=\n" +
-	"\x04role\x18\x05 \x01(\x0e2).fiagram.account_service.AccountInfo.RoleR\x04role\"'\n" +
+	"\x04role\x18\x05 \x01(\x0e2).fiagram.account_service.AccountInfo.RoleR\x04role\x12C\n" +
+	"\x06status\x18\x06 \x01(\x0e2+.fiagram.account_service.AccountInfo.StatusR\x06status\"'\n" +
 	"\x04Role\x12\b\n" +
 	"\x04NONE\x10\x00\x12\t\n" +
 	"\x05ADMIN\x10\x01\x12\n" +
 	"\n" +
-	"\x06MEMBER\x10\x02\"{\n" +
+	"\x06MEMBER\x10\x02\"e\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aPENDING\x10\x01\x12\n" +
+	"\n" +
+	"\x06ACTIVE\x10\x02\x12\r\n" +
+	"\tSUSPENDED\x10\x03\x12\n" +
+	"\n" +
+	"\x06LOCKED\x10\x04\x12\x0f\n" +
+	"\vDEACTIVATED\x10\x05\"{\n" +
 	"\x14CreateAccountRequest\x12G\n" +
 	"\faccount_info\x18\x01 \x01(\v2$.fiagram.account_service.AccountInfoR\vaccountInfo\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\"6\n" +
@@ -1107,16 +5023,107 @@ const file_api_account_service_account_service_proto_rawDesc = "" +
 	"account_id\x18\x01 \x01(\x04R\taccountId\"2\n" +
 	"\x11GetAccountRequest\x12\x1d\n" +
 	"\n" +
-	"account_id\x18\x01 \x01(\x04R\taccountId\"s\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"\xdb\x02\n" +
 	"\x12GetAccountResponse\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\x04R\taccountId\x12>\n" +
-	"\aaccount\x18\x02 \x01(\v2$.fiagram.account_service.AccountInfoR\aaccount\"D\n" +
+	"\aaccount\x18\x02 \x01(\v2$.fiagram.account_service.AccountInfoR\aaccount\x12*\n" +
+	"\x11is_email_verified\x18\x03 \x01(\bR\x0fisEmailVerified\x12F\n" +
+	"\x11email_verified_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0femailVerifiedAt\x12*\n" +
+	"\x11is_phone_verified\x18\x05 \x01(\bR\x0fisPhoneVerified\x12F\n" +
+	"\x11phone_verified_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0fphoneVerifiedAt\"D\n" +
 	"\x14GetAccountAllRequest\x12,\n" +
 	"\x05empty\x18\x01 \x01(\v2\x16.google.protobuf.EmptyR\x05empty\"\x91\x01\n" +
 	"\x15GetAccountAllResponse\x12&\n" +
 	"\x0faccount_id_list\x18\x01 \x03(\x04R\raccountIdList\x12P\n" +
-	"\x11account_info_list\x18\x02 \x03(\v2$.fiagram.account_service.AccountInfoR\x0faccountInfoList\"?\n" +
+	"\x11account_info_list\x18\x02 \x03(\v2$.fiagram.account_service.AccountInfoR\x0faccountInfoList\"\x98\x04\n" +
+	"\x13ListAccountsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12B\n" +
+	"\x04role\x18\x03 \x01(\x0e2).fiagram.account_service.AccountInfo.RoleH\x00R\x04role\x88\x01\x01\x12H\n" +
+	"\x06status\x18\x04 \x01(\x0e2+.fiagram.account_service.AccountInfo.StatusH\x01R\x06status\x88\x01\x01\x12?\n" +
+	"\rcreated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12'\n" +
+	"\x0fusername_prefix\x18\a \x01(\tR\x0eusernamePrefix\x12H\n" +
+	"\x05order\x18\b \x01(\x0e22.fiagram.account_service.ListAccountsRequest.OrderR\x05order\",\n" +
+	"\x05Order\x12\x10\n" +
+	"\fID_ASCENDING\x10\x00\x12\x11\n" +
+	"\rID_DESCENDING\x10\x01B\a\n" +
+	"\x05_roleB\t\n" +
+	"\a_status\"\xb8\x01\n" +
+	"\x14ListAccountsResponse\x12&\n" +
+	"\x0faccount_id_list\x18\x01 \x03(\x04R\raccountIdList\x12P\n" +
+	"\x11account_info_list\x18\x02 \x03(\v2$.fiagram.account_service.AccountInfoR\x0faccountInfoList\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\xb0\x03\n" +
+	"\x15StreamAccountsRequest\x12B\n" +
+	"\x04role\x18\x01 \x01(\x0e2).fiagram.account_service.AccountInfo.RoleH\x00R\x04role\x88\x01\x01\x12H\n" +
+	"\x06status\x18\x02 \x01(\x0e2+.fiagram.account_service.AccountInfo.StatusH\x01R\x06status\x88\x01\x01\x12?\n" +
+	"\rcreated_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12'\n" +
+	"\x0fusername_prefix\x18\x05 \x01(\tR\x0eusernamePrefix\x12H\n" +
+	"\x05order\x18\x06 \x01(\x0e22.fiagram.account_service.ListAccountsRequest.OrderR\x05orderB\a\n" +
+	"\x05_roleB\t\n" +
+	"\a_status\"\x80\x01\n" +
+	"\x16StreamAccountsResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12G\n" +
+	"\faccount_info\x18\x02 \x01(\v2$.fiagram.account_service.AccountInfoR\vaccountInfo\"\xcb\x01\n" +
+	"\x15ImportAccountsRequest\x12M\n" +
+	"\x06format\x18\x01 \x01(\x0e25.fiagram.account_service.ImportAccountsRequest.FormatR\x06format\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x14\n" +
+	"\x05chunk\x18\x03 \x01(\fR\x05chunk\"4\n" +
+	"\x06Format\x12\x16\n" +
+	"\x12FORMAT_UNSPECIFIED\x10\x00\x12\a\n" +
+	"\x03CSV\x10\x01\x12\t\n" +
+	"\x05JSONL\x10\x02\"\xda\x04\n" +
+	"\x16ImportAccountsResponse\x12S\n" +
+	"\aresults\x18\x01 \x03(\v29.fiagram.account_service.ImportAccountsResponse.RowResultR\aresults\x12#\n" +
+	"\rcreated_count\x18\x02 \x01(\rR\fcreatedCount\x12\x1f\n" +
+	"\vvalid_count\x18\x03 \x01(\rR\n" +
+	"validCount\x12'\n" +
+	"\x0fduplicate_count\x18\x04 \x01(\rR\x0eduplicateCount\x12#\n" +
+	"\rinvalid_count\x18\x05 \x01(\rR\finvalidCount\x12!\n" +
+	"\ffailed_count\x18\x06 \x01(\rR\vfailedCount\x1a\xb3\x02\n" +
+	"\tRowResult\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\rR\x03row\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12[\n" +
+	"\aoutcome\x18\x03 \x01(\x0e2A.fiagram.account_service.ImportAccountsResponse.RowResult.OutcomeR\aoutcome\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x04 \x01(\x04R\taccountId\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"b\n" +
+	"\aOutcome\x12\x17\n" +
+	"\x13OUTCOME_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aCREATED\x10\x01\x12\t\n" +
+	"\x05VALID\x10\x02\x12\r\n" +
+	"\tDUPLICATE\x10\x03\x12\v\n" +
+	"\aINVALID\x10\x04\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x05\"i\n" +
+	"\x15SearchAccountsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\xc4\x04\n" +
+	"\x16SearchAccountsResponse\x12P\n" +
+	"\aresults\x18\x01 \x03(\v26.fiagram.account_service.SearchAccountsResponse.ResultR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x1a\xcb\x01\n" +
+	"\tHighlight\x12U\n" +
+	"\x05field\x18\x01 \x01(\x0e2?.fiagram.account_service.SearchAccountsResponse.Highlight.FieldR\x05field\x12 \n" +
+	"\vhighlighted\x18\x02 \x01(\tR\vhighlighted\"E\n" +
+	"\x05Field\x12\x15\n" +
+	"\x11FIELD_UNSPECIFIED\x10\x00\x12\f\n" +
+	"\bUSERNAME\x10\x01\x12\f\n" +
+	"\bFULLNAME\x10\x02\x12\t\n" +
+	"\x05EMAIL\x10\x03\x1a\xe1\x01\n" +
+	"\x06Result\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12G\n" +
+	"\faccount_info\x18\x02 \x01(\v2$.fiagram.account_service.AccountInfoR\vaccountInfo\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12Y\n" +
+	"\n" +
+	"highlights\x18\x04 \x03(\v29.fiagram.account_service.SearchAccountsResponse.HighlightR\n" +
+	"highlights\"?\n" +
 	"\x15GetAccountListRequest\x12&\n" +
 	"\x0faccount_id_list\x18\x01 \x03(\x04R\raccountIdList\"\x92\x01\n" +
 	"\x16GetAccountListResponse\x12&\n" +
@@ -1135,6 +5142,24 @@ const file_api_account_service_account_service_proto_rawDesc = "" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\">\n" +
 	"\x1dUpdateAccountPasswordResponse\x12\x1d\n" +
 	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"\x84\x01\n" +
+	"\x15ChangePasswordRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12)\n" +
+	"\x10current_password\x18\x02 \x01(\tR\x0fcurrentPassword\x12!\n" +
+	"\fnew_password\x18\x03 \x01(\tR\vnewPassword\"7\n" +
+	"\x16ChangePasswordResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"9\n" +
+	"\x1bRequestPasswordResetRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\":\n" +
+	"\x1cRequestPasswordResetResponse\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"V\n" +
+	"\x1bConfirmPasswordResetRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"=\n" +
+	"\x1cConfirmPasswordResetResponse\x12\x1d\n" +
+	"\n" +
 	"account_id\x18\x01 \x01(\x04R\taccountId\"5\n" +
 	"\x14DeleteAccountRequest\x12\x1d\n" +
 	"\n" +
@@ -1145,7 +5170,13 @@ const file_api_account_service_account_service_proto_rawDesc = "" +
 	"\x1eDeleteAccountByUsernameRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\"=\n" +
 	"\x1fDeleteAccountByUsernameResponse\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\"R\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"6\n" +
+	"\x15RestoreAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"7\n" +
+	"\x16RestoreAccountResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"R\n" +
 	"\x18CheckAccountValidRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\":\n" +
@@ -1155,19 +5186,246 @@ const file_api_account_service_account_service_proto_rawDesc = "" +
 	"\x16IsUsernameTakenRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\"4\n" +
 	"\x17IsUsernameTakenResponse\x12\x19\n" +
-	"\bis_taken\x18\x01 \x01(\bR\aisTaken2\xd4\t\n" +
+	"\bis_taken\x18\x01 \x01(\bR\aisTaken\"9\n" +
+	"\x18IssueRefreshTokenRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"{\n" +
+	"\x19IssueRefreshTokenResponse\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"@\n" +
+	"\x19RotateRefreshTokenRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\x9b\x01\n" +
+	"\x1aRotateRefreshTokenResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"@\n" +
+	"\x19RevokeRefreshTokenRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"J\n" +
+	"\x1aRevokeRefreshTokenResponse\x12,\n" +
+	"\x05empty\x18\x01 \x01(\v2\x16.google.protobuf.EmptyR\x05empty\"F\n" +
+	"\fLoginRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xbd\x02\n" +
+	"\rLoginResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\x02 \x01(\tR\ttokenType\x12!\n" +
+	"\faccess_token\x18\x03 \x01(\tR\vaccessToken\x12Q\n" +
+	"\x17access_token_expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x14accessTokenExpiresAt\x12#\n" +
+	"\rrefresh_token\x18\x05 \x01(\tR\frefreshToken\x12S\n" +
+	"\x18refresh_token_expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x15refreshTokenExpiresAt\"R\n" +
+	"\x19VerifySecondFactorRequest\x12!\n" +
+	"\fchallenge_id\x18\x01 \x01(\tR\vchallengeId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\xb7\x03\n" +
+	"\x1aVerifySecondFactorResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\x02 \x01(\tR\ttokenType\x12!\n" +
+	"\faccess_token\x18\x03 \x01(\tR\vaccessToken\x12Q\n" +
+	"\x17access_token_expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x14accessTokenExpiresAt\x12#\n" +
+	"\rrefresh_token\x18\x05 \x01(\tR\frefreshToken\x12S\n" +
+	"\x18refresh_token_expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x15refreshTokenExpiresAt\x121\n" +
+	"\x15is_recovery_code_used\x18\a \x01(\bR\x12isRecoveryCodeUsed\x128\n" +
+	"\x18remaining_recovery_codes\x18\b \x01(\rR\x16remainingRecoveryCodes\"\x9e\x01\n" +
+	"\n" +
+	"JsonWebKey\x12\x10\n" +
+	"\x03kty\x18\x01 \x01(\tR\x03kty\x12\x10\n" +
+	"\x03kid\x18\x02 \x01(\tR\x03kid\x12\x10\n" +
+	"\x03use\x18\x03 \x01(\tR\x03use\x12\x10\n" +
+	"\x03alg\x18\x04 \x01(\tR\x03alg\x12\x10\n" +
+	"\x03crv\x18\x05 \x01(\tR\x03crv\x12\f\n" +
+	"\x01x\x18\x06 \x01(\tR\x01x\x12\f\n" +
+	"\x01y\x18\a \x01(\tR\x01y\x12\f\n" +
+	"\x01n\x18\b \x01(\tR\x01n\x12\f\n" +
+	"\x01e\x18\t \x01(\tR\x01e\">\n" +
+	"\x0eGetJWKSRequest\x12,\n" +
+	"\x05empty\x18\x01 \x01(\v2\x16.google.protobuf.EmptyR\x05empty\"J\n" +
+	"\x0fGetJWKSResponse\x127\n" +
+	"\x04keys\x18\x01 \x03(\v2#.fiagram.account_service.JsonWebKeyR\x04keys\"2\n" +
+	"\x14UnlockAccountRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"3\n" +
+	"\x15UnlockAccountResponse\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"x\n" +
+	"\x15SuspendAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12(\n" +
+	"\x10actor_account_id\x18\x03 \x01(\x04R\x0eactorAccountId\"|\n" +
+	"\x16SuspendAccountResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12C\n" +
+	"\x06status\x18\x02 \x01(\x0e2+.fiagram.account_service.AccountInfo.StatusR\x06status\"{\n" +
+	"\x18ReactivateAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12(\n" +
+	"\x10actor_account_id\x18\x03 \x01(\x04R\x0eactorAccountId\"\x7f\n" +
+	"\x19ReactivateAccountResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12C\n" +
+	"\x06status\x18\x02 \x01(\x0e2+.fiagram.account_service.AccountInfo.StatusR\x06status\"{\n" +
+	"\x18DeactivateAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12(\n" +
+	"\x10actor_account_id\x18\x03 \x01(\x04R\x0eactorAccountId\"\x7f\n" +
+	"\x19DeactivateAccountResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12C\n" +
+	"\x06status\x18\x02 \x01(\x0e2+.fiagram.account_service.AccountInfo.StatusR\x06status\"=\n" +
+	"\x1cSendEmailVerificationRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"y\n" +
+	"\x1dSendEmailVerificationResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"G\n" +
+	"\x12VerifyEmailRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"|\n" +
+	"\x13VerifyEmailResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12F\n" +
+	"\x11email_verified_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x0femailVerifiedAt\"=\n" +
+	"\x1cSendPhoneVerificationRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"y\n" +
+	"\x1dSendPhoneVerificationResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"G\n" +
+	"\x12VerifyPhoneRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"|\n" +
+	"\x13VerifyPhoneResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12F\n" +
+	"\x11phone_verified_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x0fphoneVerifiedAt\"2\n" +
+	"\x11EnrollTOTPRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"]\n" +
+	"\x12EnrollTOTPResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\x10\n" +
+	"\x03uri\x18\x03 \x01(\tR\x03uri\"G\n" +
+	"\x12ConfirmTOTPRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"4\n" +
+	"\x13ConfirmTOTPResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"G\n" +
+	"\x12DisableTOTPRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"4\n" +
+	"\x13DisableTOTPResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"=\n" +
+	"\x1cGenerateRecoveryCodesRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"T\n" +
+	"\x1dGenerateRecoveryCodesResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x14\n" +
+	"\x05codes\x18\x02 \x03(\tR\x05codes\"A\n" +
+	" BeginWebAuthnRegistrationRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"\xb6\x01\n" +
+	"!BeginWebAuthnRegistrationResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x18\n" +
+	"\aoptions\x18\x03 \x01(\tR\aoptions\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"b\n" +
+	"!FinishWebAuthnRegistrationRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1e\n" +
+	"\n" +
+	"credential\x18\x02 \x01(\tR\n" +
+	"credential\"h\n" +
+	"\"FinishWebAuthnRegistrationResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12#\n" +
+	"\rcredential_id\x18\x02 \x01(\tR\fcredentialId\";\n" +
+	"\x1dBeginWebAuthnAssertionRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"\x94\x01\n" +
+	"\x1eBeginWebAuthnAssertionResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x18\n" +
+	"\aoptions\x18\x02 \x01(\tR\aoptions\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"_\n" +
+	"\x1eFinishWebAuthnAssertionRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1e\n" +
+	"\n" +
+	"credential\x18\x02 \x01(\tR\n" +
+	"credential\"\xcf\x02\n" +
+	"\x1fFinishWebAuthnAssertionResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\x02 \x01(\tR\ttokenType\x12!\n" +
+	"\faccess_token\x18\x03 \x01(\tR\vaccessToken\x12Q\n" +
+	"\x17access_token_expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x14accessTokenExpiresAt\x12#\n" +
+	"\rrefresh_token\x18\x05 \x01(\tR\frefreshToken\x12S\n" +
+	"\x18refresh_token_expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x15refreshTokenExpiresAt2\xcb&\n" +
 	"\x0eAccountService\x12p\n" +
 	"\rCreateAccount\x12-.fiagram.account_service.CreateAccountRequest\x1a..fiagram.account_service.CreateAccountResponse\"\x00\x12|\n" +
 	"\x11CheckAccountValid\x121.fiagram.account_service.CheckAccountValidRequest\x1a2.fiagram.account_service.CheckAccountValidResponse\"\x00\x12v\n" +
 	"\x0fIsUsernameTaken\x12/.fiagram.account_service.IsUsernameTakenRequest\x1a0.fiagram.account_service.IsUsernameTakenResponse\"\x00\x12g\n" +
 	"\n" +
-	"GetAccount\x12*.fiagram.account_service.GetAccountRequest\x1a+.fiagram.account_service.GetAccountResponse\"\x00\x12p\n" +
-	"\rGetAccountAll\x12-.fiagram.account_service.GetAccountAllRequest\x1a..fiagram.account_service.GetAccountAllResponse\"\x00\x12s\n" +
+	"GetAccount\x12*.fiagram.account_service.GetAccountRequest\x1a+.fiagram.account_service.GetAccountResponse\"\x00\x12s\n" +
+	"\rGetAccountAll\x12-.fiagram.account_service.GetAccountAllRequest\x1a..fiagram.account_service.GetAccountAllResponse\"\x03\x88\x02\x01\x12m\n" +
+	"\fListAccounts\x12,.fiagram.account_service.ListAccountsRequest\x1a-.fiagram.account_service.ListAccountsResponse\"\x00\x12u\n" +
+	"\x0eStreamAccounts\x12..fiagram.account_service.StreamAccountsRequest\x1a/.fiagram.account_service.StreamAccountsResponse\"\x000\x01\x12u\n" +
+	"\x0eImportAccounts\x12..fiagram.account_service.ImportAccountsRequest\x1a/.fiagram.account_service.ImportAccountsResponse\"\x00(\x01\x12s\n" +
+	"\x0eSearchAccounts\x12..fiagram.account_service.SearchAccountsRequest\x1a/.fiagram.account_service.SearchAccountsResponse\"\x00\x12s\n" +
 	"\x0eGetAccountList\x12..fiagram.account_service.GetAccountListRequest\x1a/.fiagram.account_service.GetAccountListResponse\"\x00\x12|\n" +
 	"\x11UpdateAccountInfo\x121.fiagram.account_service.UpdateAccountInfoRequest\x1a2.fiagram.account_service.UpdateAccountInfoResponse\"\x00\x12\x88\x01\n" +
-	"\x15UpdateAccountPassword\x125.fiagram.account_service.UpdateAccountPasswordRequest\x1a6.fiagram.account_service.UpdateAccountPasswordResponse\"\x00\x12p\n" +
+	"\x15UpdateAccountPassword\x125.fiagram.account_service.UpdateAccountPasswordRequest\x1a6.fiagram.account_service.UpdateAccountPasswordResponse\"\x00\x12s\n" +
+	"\x0eChangePassword\x12..fiagram.account_service.ChangePasswordRequest\x1a/.fiagram.account_service.ChangePasswordResponse\"\x00\x12\x85\x01\n" +
+	"\x14RequestPasswordReset\x124.fiagram.account_service.RequestPasswordResetRequest\x1a5.fiagram.account_service.RequestPasswordResetResponse\"\x00\x12\x85\x01\n" +
+	"\x14ConfirmPasswordReset\x124.fiagram.account_service.ConfirmPasswordResetRequest\x1a5.fiagram.account_service.ConfirmPasswordResetResponse\"\x00\x12p\n" +
 	"\rDeleteAccount\x12-.fiagram.account_service.DeleteAccountRequest\x1a..fiagram.account_service.DeleteAccountResponse\"\x00\x12\x8e\x01\n" +
-	"\x17DeleteAccountByUsername\x127.fiagram.account_service.DeleteAccountByUsernameRequest\x1a8.fiagram.account_service.DeleteAccountByUsernameResponse\"\x00B\x16Z\x14grpc/account_serviceb\x06proto3"
+	"\x17DeleteAccountByUsername\x127.fiagram.account_service.DeleteAccountByUsernameRequest\x1a8.fiagram.account_service.DeleteAccountByUsernameResponse\"\x00\x12s\n" +
+	"\x0eRestoreAccount\x12..fiagram.account_service.RestoreAccountRequest\x1a/.fiagram.account_service.RestoreAccountResponse\"\x00\x12|\n" +
+	"\x11IssueRefreshToken\x121.fiagram.account_service.IssueRefreshTokenRequest\x1a2.fiagram.account_service.IssueRefreshTokenResponse\"\x00\x12\x7f\n" +
+	"\x12RotateRefreshToken\x122.fiagram.account_service.RotateRefreshTokenRequest\x1a3.fiagram.account_service.RotateRefreshTokenResponse\"\x00\x12\x7f\n" +
+	"\x12RevokeRefreshToken\x122.fiagram.account_service.RevokeRefreshTokenRequest\x1a3.fiagram.account_service.RevokeRefreshTokenResponse\"\x00\x12X\n" +
+	"\x05Login\x12%.fiagram.account_service.LoginRequest\x1a&.fiagram.account_service.LoginResponse\"\x00\x12\x7f\n" +
+	"\x12VerifySecondFactor\x122.fiagram.account_service.VerifySecondFactorRequest\x1a3.fiagram.account_service.VerifySecondFactorResponse\"\x00\x12^\n" +
+	"\aGetJWKS\x12'.fiagram.account_service.GetJWKSRequest\x1a(.fiagram.account_service.GetJWKSResponse\"\x00\x12p\n" +
+	"\rUnlockAccount\x12-.fiagram.account_service.UnlockAccountRequest\x1a..fiagram.account_service.UnlockAccountResponse\"\x00\x12s\n" +
+	"\x0eSuspendAccount\x12..fiagram.account_service.SuspendAccountRequest\x1a/.fiagram.account_service.SuspendAccountResponse\"\x00\x12|\n" +
+	"\x11ReactivateAccount\x121.fiagram.account_service.ReactivateAccountRequest\x1a2.fiagram.account_service.ReactivateAccountResponse\"\x00\x12|\n" +
+	"\x11DeactivateAccount\x121.fiagram.account_service.DeactivateAccountRequest\x1a2.fiagram.account_service.DeactivateAccountResponse\"\x00\x12\x88\x01\n" +
+	"\x15SendEmailVerification\x125.fiagram.account_service.SendEmailVerificationRequest\x1a6.fiagram.account_service.SendEmailVerificationResponse\"\x00\x12j\n" +
+	"\vVerifyEmail\x12+.fiagram.account_service.VerifyEmailRequest\x1a,.fiagram.account_service.VerifyEmailResponse\"\x00\x12\x88\x01\n" +
+	"\x15SendPhoneVerification\x125.fiagram.account_service.SendPhoneVerificationRequest\x1a6.fiagram.account_service.SendPhoneVerificationResponse\"\x00\x12j\n" +
+	"\vVerifyPhone\x12+.fiagram.account_service.VerifyPhoneRequest\x1a,.fiagram.account_service.VerifyPhoneResponse\"\x00\x12g\n" +
+	"\n" +
+	"EnrollTOTP\x12*.fiagram.account_service.EnrollTOTPRequest\x1a+.fiagram.account_service.EnrollTOTPResponse\"\x00\x12j\n" +
+	"\vConfirmTOTP\x12+.fiagram.account_service.ConfirmTOTPRequest\x1a,.fiagram.account_service.ConfirmTOTPResponse\"\x00\x12j\n" +
+	"\vDisableTOTP\x12+.fiagram.account_service.DisableTOTPRequest\x1a,.fiagram.account_service.DisableTOTPResponse\"\x00\x12\x88\x01\n" +
+	"\x15GenerateRecoveryCodes\x125.fiagram.account_service.GenerateRecoveryCodesRequest\x1a6.fiagram.account_service.GenerateRecoveryCodesResponse\"\x00\x12\x94\x01\n" +
+	"\x19BeginWebAuthnRegistration\x129.fiagram.account_service.BeginWebAuthnRegistrationRequest\x1a:.fiagram.account_service.BeginWebAuthnRegistrationResponse\"\x00\x12\x97\x01\n" +
+	"\x1aFinishWebAuthnRegistration\x12:.fiagram.account_service.FinishWebAuthnRegistrationRequest\x1a;.fiagram.account_service.FinishWebAuthnRegistrationResponse\"\x00\x12\x8b\x01\n" +
+	"\x16BeginWebAuthnAssertion\x126.fiagram.account_service.BeginWebAuthnAssertionRequest\x1a7.fiagram.account_service.BeginWebAuthnAssertionResponse\"\x00\x12\x8e\x01\n" +
+	"\x17FinishWebAuthnAssertion\x127.fiagram.account_service.FinishWebAuthnAssertionRequest\x1a8.fiagram.account_service.FinishWebAuthnAssertionResponse\"\x00B\x16Z\x14grpc/account_serviceb\x06proto3"
 
 var (
 	file_api_account_service_account_service_proto_rawDescOnce sync.Once
@@ -1181,66 +5439,238 @@ func file_api_account_service_account_service_proto_rawDescGZIP() []byte {
 	return file_api_account_service_account_service_proto_rawDescData
 }
 
-var file_api_account_service_account_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_account_service_account_service_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_api_account_service_account_service_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_api_account_service_account_service_proto_msgTypes = make([]protoimpl.MessageInfo, 85)
 var file_api_account_service_account_service_proto_goTypes = []any{
-	(AccountInfo_Role)(0),                   // 0: fiagram.account_service.AccountInfo.Role
-	(*AccountInfo)(nil),                     // 1: fiagram.account_service.AccountInfo
-	(*CreateAccountRequest)(nil),            // 2: fiagram.account_service.CreateAccountRequest
-	(*CreateAccountResponse)(nil),           // 3: fiagram.account_service.CreateAccountResponse
-	(*GetAccountRequest)(nil),               // 4: fiagram.account_service.GetAccountRequest
-	(*GetAccountResponse)(nil),              // 5: fiagram.account_service.GetAccountResponse
-	(*GetAccountAllRequest)(nil),            // 6: fiagram.account_service.GetAccountAllRequest
-	(*GetAccountAllResponse)(nil),           // 7: fiagram.account_service.GetAccountAllResponse
-	(*GetAccountListRequest)(nil),           // 8: fiagram.account_service.GetAccountListRequest
-	(*GetAccountListResponse)(nil),          // 9: fiagram.account_service.GetAccountListResponse
-	(*UpdateAccountInfoRequest)(nil),        // 10: fiagram.account_service.UpdateAccountInfoRequest
-	(*UpdateAccountInfoResponse)(nil),       // 11: fiagram.account_service.UpdateAccountInfoResponse
-	(*UpdateAccountPasswordRequest)(nil),    // 12: fiagram.account_service.UpdateAccountPasswordRequest
-	(*UpdateAccountPasswordResponse)(nil),   // 13: fiagram.account_service.UpdateAccountPasswordResponse
-	(*DeleteAccountRequest)(nil),            // 14: fiagram.account_service.DeleteAccountRequest
-	(*DeleteAccountResponse)(nil),           // 15: fiagram.account_service.DeleteAccountResponse
-	(*DeleteAccountByUsernameRequest)(nil),  // 16: fiagram.account_service.DeleteAccountByUsernameRequest
-	(*DeleteAccountByUsernameResponse)(nil), // 17: fiagram.account_service.DeleteAccountByUsernameResponse
-	(*CheckAccountValidRequest)(nil),        // 18: fiagram.account_service.CheckAccountValidRequest
-	(*CheckAccountValidResponse)(nil),       // 19: fiagram.account_service.CheckAccountValidResponse
-	(*IsUsernameTakenRequest)(nil),          // 20: fiagram.account_service.IsUsernameTakenRequest
-	(*IsUsernameTakenResponse)(nil),         // 21: fiagram.account_service.IsUsernameTakenResponse
-	(*emptypb.Empty)(nil),                   // 22: google.protobuf.Empty
+	(AccountInfo_Role)(0),                         // 0: fiagram.account_service.AccountInfo.Role
+	(AccountInfo_Status)(0),                       // 1: fiagram.account_service.AccountInfo.Status
+	(ListAccountsRequest_Order)(0),                // 2: fiagram.account_service.ListAccountsRequest.Order
+	(ImportAccountsRequest_Format)(0),             // 3: fiagram.account_service.ImportAccountsRequest.Format
+	(ImportAccountsResponse_RowResult_Outcome)(0), // 4: fiagram.account_service.ImportAccountsResponse.RowResult.Outcome
+	(SearchAccountsResponse_Highlight_Field)(0),   // 5: fiagram.account_service.SearchAccountsResponse.Highlight.Field
+	(*AccountInfo)(nil),                           // 6: fiagram.account_service.AccountInfo
+	(*CreateAccountRequest)(nil),                  // 7: fiagram.account_service.CreateAccountRequest
+	(*CreateAccountResponse)(nil),                 // 8: fiagram.account_service.CreateAccountResponse
+	(*GetAccountRequest)(nil),                     // 9: fiagram.account_service.GetAccountRequest
+	(*GetAccountResponse)(nil),                    // 10: fiagram.account_service.GetAccountResponse
+	(*GetAccountAllRequest)(nil),                  // 11: fiagram.account_service.GetAccountAllRequest
+	(*GetAccountAllResponse)(nil),                 // 12: fiagram.account_service.GetAccountAllResponse
+	(*ListAccountsRequest)(nil),                   // 13: fiagram.account_service.ListAccountsRequest
+	(*ListAccountsResponse)(nil),                  // 14: fiagram.account_service.ListAccountsResponse
+	(*StreamAccountsRequest)(nil),                 // 15: fiagram.account_service.StreamAccountsRequest
+	(*StreamAccountsResponse)(nil),                // 16: fiagram.account_service.StreamAccountsResponse
+	(*ImportAccountsRequest)(nil),                 // 17: fiagram.account_service.ImportAccountsRequest
+	(*ImportAccountsResponse)(nil),                // 18: fiagram.account_service.ImportAccountsResponse
+	(*SearchAccountsRequest)(nil),                 // 19: fiagram.account_service.SearchAccountsRequest
+	(*SearchAccountsResponse)(nil),                // 20: fiagram.account_service.SearchAccountsResponse
+	(*GetAccountListRequest)(nil),                 // 21: fiagram.account_service.GetAccountListRequest
+	(*GetAccountListResponse)(nil),                // 22: fiagram.account_service.GetAccountListResponse
+	(*UpdateAccountInfoRequest)(nil),              // 23: fiagram.account_service.UpdateAccountInfoRequest
+	(*UpdateAccountInfoResponse)(nil),             // 24: fiagram.account_service.UpdateAccountInfoResponse
+	(*UpdateAccountPasswordRequest)(nil),          // 25: fiagram.account_service.UpdateAccountPasswordRequest
+	(*UpdateAccountPasswordResponse)(nil),         // 26: fiagram.account_service.UpdateAccountPasswordResponse
+	(*ChangePasswordRequest)(nil),                 // 27: fiagram.account_service.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),                // 28: fiagram.account_service.ChangePasswordResponse
+	(*RequestPasswordResetRequest)(nil),           // 29: fiagram.account_service.RequestPasswordResetRequest
+	(*RequestPasswordResetResponse)(nil),          // 30: fiagram.account_service.RequestPasswordResetResponse
+	(*ConfirmPasswordResetRequest)(nil),           // 31: fiagram.account_service.ConfirmPasswordResetRequest
+	(*ConfirmPasswordResetResponse)(nil),          // 32: fiagram.account_service.ConfirmPasswordResetResponse
+	(*DeleteAccountRequest)(nil),                  // 33: fiagram.account_service.DeleteAccountRequest
+	(*DeleteAccountResponse)(nil),                 // 34: fiagram.account_service.DeleteAccountResponse
+	(*DeleteAccountByUsernameRequest)(nil),        // 35: fiagram.account_service.DeleteAccountByUsernameRequest
+	(*DeleteAccountByUsernameResponse)(nil),       // 36: fiagram.account_service.DeleteAccountByUsernameResponse
+	(*RestoreAccountRequest)(nil),                 // 37: fiagram.account_service.RestoreAccountRequest
+	(*RestoreAccountResponse)(nil),                // 38: fiagram.account_service.RestoreAccountResponse
+	(*CheckAccountValidRequest)(nil),              // 39: fiagram.account_service.CheckAccountValidRequest
+	(*CheckAccountValidResponse)(nil),             // 40: fiagram.account_service.CheckAccountValidResponse
+	(*IsUsernameTakenRequest)(nil),                // 41: fiagram.account_service.IsUsernameTakenRequest
+	(*IsUsernameTakenResponse)(nil),               // 42: fiagram.account_service.IsUsernameTakenResponse
+	(*IssueRefreshTokenRequest)(nil),              // 43: fiagram.account_service.IssueRefreshTokenRequest
+	(*IssueRefreshTokenResponse)(nil),             // 44: fiagram.account_service.IssueRefreshTokenResponse
+	(*RotateRefreshTokenRequest)(nil),             // 45: fiagram.account_service.RotateRefreshTokenRequest
+	(*RotateRefreshTokenResponse)(nil),            // 46: fiagram.account_service.RotateRefreshTokenResponse
+	(*RevokeRefreshTokenRequest)(nil),             // 47: fiagram.account_service.RevokeRefreshTokenRequest
+	(*RevokeRefreshTokenResponse)(nil),            // 48: fiagram.account_service.RevokeRefreshTokenResponse
+	(*LoginRequest)(nil),                          // 49: fiagram.account_service.LoginRequest
+	(*LoginResponse)(nil),                         // 50: fiagram.account_service.LoginResponse
+	(*VerifySecondFactorRequest)(nil),             // 51: fiagram.account_service.VerifySecondFactorRequest
+	(*VerifySecondFactorResponse)(nil),            // 52: fiagram.account_service.VerifySecondFactorResponse
+	(*JsonWebKey)(nil),                            // 53: fiagram.account_service.JsonWebKey
+	(*GetJWKSRequest)(nil),                        // 54: fiagram.account_service.GetJWKSRequest
+	(*GetJWKSResponse)(nil),                       // 55: fiagram.account_service.GetJWKSResponse
+	(*UnlockAccountRequest)(nil),                  // 56: fiagram.account_service.UnlockAccountRequest
+	(*UnlockAccountResponse)(nil),                 // 57: fiagram.account_service.UnlockAccountResponse
+	(*SuspendAccountRequest)(nil),                 // 58: fiagram.account_service.SuspendAccountRequest
+	(*SuspendAccountResponse)(nil),                // 59: fiagram.account_service.SuspendAccountResponse
+	(*ReactivateAccountRequest)(nil),              // 60: fiagram.account_service.ReactivateAccountRequest
+	(*ReactivateAccountResponse)(nil),             // 61: fiagram.account_service.ReactivateAccountResponse
+	(*DeactivateAccountRequest)(nil),              // 62: fiagram.account_service.DeactivateAccountRequest
+	(*DeactivateAccountResponse)(nil),             // 63: fiagram.account_service.DeactivateAccountResponse
+	(*SendEmailVerificationRequest)(nil),          // 64: fiagram.account_service.SendEmailVerificationRequest
+	(*SendEmailVerificationResponse)(nil),         // 65: fiagram.account_service.SendEmailVerificationResponse
+	(*VerifyEmailRequest)(nil),                    // 66: fiagram.account_service.VerifyEmailRequest
+	(*VerifyEmailResponse)(nil),                   // 67: fiagram.account_service.VerifyEmailResponse
+	(*SendPhoneVerificationRequest)(nil),          // 68: fiagram.account_service.SendPhoneVerificationRequest
+	(*SendPhoneVerificationResponse)(nil),         // 69: fiagram.account_service.SendPhoneVerificationResponse
+	(*VerifyPhoneRequest)(nil),                    // 70: fiagram.account_service.VerifyPhoneRequest
+	(*VerifyPhoneResponse)(nil),                   // 71: fiagram.account_service.VerifyPhoneResponse
+	(*EnrollTOTPRequest)(nil),                     // 72: fiagram.account_service.EnrollTOTPRequest
+	(*EnrollTOTPResponse)(nil),                    // 73: fiagram.account_service.EnrollTOTPResponse
+	(*ConfirmTOTPRequest)(nil),                    // 74: fiagram.account_service.ConfirmTOTPRequest
+	(*ConfirmTOTPResponse)(nil),                   // 75: fiagram.account_service.ConfirmTOTPResponse
+	(*DisableTOTPRequest)(nil),                    // 76: fiagram.account_service.DisableTOTPRequest
+	(*DisableTOTPResponse)(nil),                   // 77: fiagram.account_service.DisableTOTPResponse
+	(*GenerateRecoveryCodesRequest)(nil),          // 78: fiagram.account_service.GenerateRecoveryCodesRequest
+	(*GenerateRecoveryCodesResponse)(nil),         // 79: fiagram.account_service.GenerateRecoveryCodesResponse
+	(*BeginWebAuthnRegistrationRequest)(nil),      // 80: fiagram.account_service.BeginWebAuthnRegistrationRequest
+	(*BeginWebAuthnRegistrationResponse)(nil),     // 81: fiagram.account_service.BeginWebAuthnRegistrationResponse
+	(*FinishWebAuthnRegistrationRequest)(nil),     // 82: fiagram.account_service.FinishWebAuthnRegistrationRequest
+	(*FinishWebAuthnRegistrationResponse)(nil),    // 83: fiagram.account_service.FinishWebAuthnRegistrationResponse
+	(*BeginWebAuthnAssertionRequest)(nil),         // 84: fiagram.account_service.BeginWebAuthnAssertionRequest
+	(*BeginWebAuthnAssertionResponse)(nil),        // 85: fiagram.account_service.BeginWebAuthnAssertionResponse
+	(*FinishWebAuthnAssertionRequest)(nil),        // 86: fiagram.account_service.FinishWebAuthnAssertionRequest
+	(*FinishWebAuthnAssertionResponse)(nil),       // 87: fiagram.account_service.FinishWebAuthnAssertionResponse
+	(*ImportAccountsResponse_RowResult)(nil),      // 88: fiagram.account_service.ImportAccountsResponse.RowResult
+	(*SearchAccountsResponse_Highlight)(nil),      // 89: fiagram.account_service.SearchAccountsResponse.Highlight
+	(*SearchAccountsResponse_Result)(nil),         // 90: fiagram.account_service.SearchAccountsResponse.Result
+	(*timestamppb.Timestamp)(nil),                 // 91: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                         // 92: google.protobuf.Empty
 }
 var file_api_account_service_account_service_proto_depIdxs = []int32{
 	0,  // 0: fiagram.account_service.AccountInfo.role:type_name -> fiagram.account_service.AccountInfo.Role
-	1,  // 1: fiagram.account_service.CreateAccountRequest.account_info:type_name -> fiagram.account_service.AccountInfo
-	1,  // 2: fiagram.account_service.GetAccountResponse.account:type_name -> fiagram.account_service.AccountInfo
-	22, // 3: fiagram.account_service.GetAccountAllRequest.empty:type_name -> google.protobuf.Empty
-	1,  // 4: fiagram.account_service.GetAccountAllResponse.account_info_list:type_name -> fiagram.account_service.AccountInfo
-	1,  // 5: fiagram.account_service.GetAccountListResponse.account_info_list:type_name -> fiagram.account_service.AccountInfo
-	1,  // 6: fiagram.account_service.UpdateAccountInfoRequest.updated_account_info:type_name -> fiagram.account_service.AccountInfo
-	2,  // 7: fiagram.account_service.AccountService.CreateAccount:input_type -> fiagram.account_service.CreateAccountRequest
-	18, // 8: fiagram.account_service.AccountService.CheckAccountValid:input_type -> fiagram.account_service.CheckAccountValidRequest
-	20, // 9: fiagram.account_service.AccountService.IsUsernameTaken:input_type -> fiagram.account_service.IsUsernameTakenRequest
-	4,  // 10: fiagram.account_service.AccountService.GetAccount:input_type -> fiagram.account_service.GetAccountRequest
-	6,  // 11: fiagram.account_service.AccountService.GetAccountAll:input_type -> fiagram.account_service.GetAccountAllRequest
-	8,  // 12: fiagram.account_service.AccountService.GetAccountList:input_type -> fiagram.account_service.GetAccountListRequest
-	10, // 13: fiagram.account_service.AccountService.UpdateAccountInfo:input_type -> fiagram.account_service.UpdateAccountInfoRequest
-	12, // 14: fiagram.account_service.AccountService.UpdateAccountPassword:input_type -> fiagram.account_service.UpdateAccountPasswordRequest
-	14, // 15: fiagram.account_service.AccountService.DeleteAccount:input_type -> fiagram.account_service.DeleteAccountRequest
-	16, // 16: fiagram.account_service.AccountService.DeleteAccountByUsername:input_type -> fiagram.account_service.DeleteAccountByUsernameRequest
-	3,  // 17: fiagram.account_service.AccountService.CreateAccount:output_type -> fiagram.account_service.CreateAccountResponse
-	19, // 18: fiagram.account_service.AccountService.CheckAccountValid:output_type -> fiagram.account_service.CheckAccountValidResponse
-	21, // 19: fiagram.account_service.AccountService.IsUsernameTaken:output_type -> fiagram.account_service.IsUsernameTakenResponse
-	5,  // 20: fiagram.account_service.AccountService.GetAccount:output_type -> fiagram.account_service.GetAccountResponse
-	7,  // 21: fiagram.account_service.AccountService.GetAccountAll:output_type -> fiagram.account_service.GetAccountAllResponse
-	9,  // 22: fiagram.account_service.AccountService.GetAccountList:output_type -> fiagram.account_service.GetAccountListResponse
-	11, // 23: fiagram.account_service.AccountService.UpdateAccountInfo:output_type -> fiagram.account_service.UpdateAccountInfoResponse
-	13, // 24: fiagram.account_service.AccountService.UpdateAccountPassword:output_type -> fiagram.account_service.UpdateAccountPasswordResponse
-	15, // 25: fiagram.account_service.AccountService.DeleteAccount:output_type -> fiagram.account_service.DeleteAccountResponse
-	17, // 26: fiagram.account_service.AccountService.DeleteAccountByUsername:output_type -> fiagram.account_service.DeleteAccountByUsernameResponse
-	17, // [17:27] is the sub-list for method output_type
-	7,  // [7:17] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	1,  // 1: fiagram.account_service.AccountInfo.status:type_name -> fiagram.account_service.AccountInfo.Status
+	6,  // 2: fiagram.account_service.CreateAccountRequest.account_info:type_name -> fiagram.account_service.AccountInfo
+	6,  // 3: fiagram.account_service.GetAccountResponse.account:type_name -> fiagram.account_service.AccountInfo
+	91, // 4: fiagram.account_service.GetAccountResponse.email_verified_at:type_name -> google.protobuf.Timestamp
+	91, // 5: fiagram.account_service.GetAccountResponse.phone_verified_at:type_name -> google.protobuf.Timestamp
+	92, // 6: fiagram.account_service.GetAccountAllRequest.empty:type_name -> google.protobuf.Empty
+	6,  // 7: fiagram.account_service.GetAccountAllResponse.account_info_list:type_name -> fiagram.account_service.AccountInfo
+	0,  // 8: fiagram.account_service.ListAccountsRequest.role:type_name -> fiagram.account_service.AccountInfo.Role
+	1,  // 9: fiagram.account_service.ListAccountsRequest.status:type_name -> fiagram.account_service.AccountInfo.Status
+	91, // 10: fiagram.account_service.ListAccountsRequest.created_after:type_name -> google.protobuf.Timestamp
+	91, // 11: fiagram.account_service.ListAccountsRequest.created_before:type_name -> google.protobuf.Timestamp
+	2,  // 12: fiagram.account_service.ListAccountsRequest.order:type_name -> fiagram.account_service.ListAccountsRequest.Order
+	6,  // 13: fiagram.account_service.ListAccountsResponse.account_info_list:type_name -> fiagram.account_service.AccountInfo
+	0,  // 14: fiagram.account_service.StreamAccountsRequest.role:type_name -> fiagram.account_service.AccountInfo.Role
+	1,  // 15: fiagram.account_service.StreamAccountsRequest.status:type_name -> fiagram.account_service.AccountInfo.Status
+	91, // 16: fiagram.account_service.StreamAccountsRequest.created_after:type_name -> google.protobuf.Timestamp
+	91, // 17: fiagram.account_service.StreamAccountsRequest.created_before:type_name -> google.protobuf.Timestamp
+	2,  // 18: fiagram.account_service.StreamAccountsRequest.order:type_name -> fiagram.account_service.ListAccountsRequest.Order
+	6,  // 19: fiagram.account_service.StreamAccountsResponse.account_info:type_name -> fiagram.account_service.AccountInfo
+	3,  // 20: fiagram.account_service.ImportAccountsRequest.format:type_name -> fiagram.account_service.ImportAccountsRequest.Format
+	88, // 21: fiagram.account_service.ImportAccountsResponse.results:type_name -> fiagram.account_service.ImportAccountsResponse.RowResult
+	90, // 22: fiagram.account_service.SearchAccountsResponse.results:type_name -> fiagram.account_service.SearchAccountsResponse.Result
+	6,  // 23: fiagram.account_service.GetAccountListResponse.account_info_list:type_name -> fiagram.account_service.AccountInfo
+	6,  // 24: fiagram.account_service.UpdateAccountInfoRequest.updated_account_info:type_name -> fiagram.account_service.AccountInfo
+	91, // 25: fiagram.account_service.IssueRefreshTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	91, // 26: fiagram.account_service.RotateRefreshTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	92, // 27: fiagram.account_service.RevokeRefreshTokenResponse.empty:type_name -> google.protobuf.Empty
+	91, // 28: fiagram.account_service.LoginResponse.access_token_expires_at:type_name -> google.protobuf.Timestamp
+	91, // 29: fiagram.account_service.LoginResponse.refresh_token_expires_at:type_name -> google.protobuf.Timestamp
+	91, // 30: fiagram.account_service.VerifySecondFactorResponse.access_token_expires_at:type_name -> google.protobuf.Timestamp
+	91, // 31: fiagram.account_service.VerifySecondFactorResponse.refresh_token_expires_at:type_name -> google.protobuf.Timestamp
+	92, // 32: fiagram.account_service.GetJWKSRequest.empty:type_name -> google.protobuf.Empty
+	53, // 33: fiagram.account_service.GetJWKSResponse.keys:type_name -> fiagram.account_service.JsonWebKey
+	1,  // 34: fiagram.account_service.SuspendAccountResponse.status:type_name -> fiagram.account_service.AccountInfo.Status
+	1,  // 35: fiagram.account_service.ReactivateAccountResponse.status:type_name -> fiagram.account_service.AccountInfo.Status
+	1,  // 36: fiagram.account_service.DeactivateAccountResponse.status:type_name -> fiagram.account_service.AccountInfo.Status
+	91, // 37: fiagram.account_service.SendEmailVerificationResponse.expires_at:type_name -> google.protobuf.Timestamp
+	91, // 38: fiagram.account_service.VerifyEmailResponse.email_verified_at:type_name -> google.protobuf.Timestamp
+	91, // 39: fiagram.account_service.SendPhoneVerificationResponse.expires_at:type_name -> google.protobuf.Timestamp
+	91, // 40: fiagram.account_service.VerifyPhoneResponse.phone_verified_at:type_name -> google.protobuf.Timestamp
+	91, // 41: fiagram.account_service.BeginWebAuthnRegistrationResponse.expires_at:type_name -> google.protobuf.Timestamp
+	91, // 42: fiagram.account_service.BeginWebAuthnAssertionResponse.expires_at:type_name -> google.protobuf.Timestamp
+	91, // 43: fiagram.account_service.FinishWebAuthnAssertionResponse.access_token_expires_at:type_name -> google.protobuf.Timestamp
+	91, // 44: fiagram.account_service.FinishWebAuthnAssertionResponse.refresh_token_expires_at:type_name -> google.protobuf.Timestamp
+	4,  // 45: fiagram.account_service.ImportAccountsResponse.RowResult.outcome:type_name -> fiagram.account_service.ImportAccountsResponse.RowResult.Outcome
+	5,  // 46: fiagram.account_service.SearchAccountsResponse.Highlight.field:type_name -> fiagram.account_service.SearchAccountsResponse.Highlight.Field
+	6,  // 47: fiagram.account_service.SearchAccountsResponse.Result.account_info:type_name -> fiagram.account_service.AccountInfo
+	89, // 48: fiagram.account_service.SearchAccountsResponse.Result.highlights:type_name -> fiagram.account_service.SearchAccountsResponse.Highlight
+	7,  // 49: fiagram.account_service.AccountService.CreateAccount:input_type -> fiagram.account_service.CreateAccountRequest
+	39, // 50: fiagram.account_service.AccountService.CheckAccountValid:input_type -> fiagram.account_service.CheckAccountValidRequest
+	41, // 51: fiagram.account_service.AccountService.IsUsernameTaken:input_type -> fiagram.account_service.IsUsernameTakenRequest
+	9,  // 52: fiagram.account_service.AccountService.GetAccount:input_type -> fiagram.account_service.GetAccountRequest
+	11, // 53: fiagram.account_service.AccountService.GetAccountAll:input_type -> fiagram.account_service.GetAccountAllRequest
+	13, // 54: fiagram.account_service.AccountService.ListAccounts:input_type -> fiagram.account_service.ListAccountsRequest
+	15, // 55: fiagram.account_service.AccountService.StreamAccounts:input_type -> fiagram.account_service.StreamAccountsRequest
+	17, // 56: fiagram.account_service.AccountService.ImportAccounts:input_type -> fiagram.account_service.ImportAccountsRequest
+	19, // 57: fiagram.account_service.AccountService.SearchAccounts:input_type -> fiagram.account_service.SearchAccountsRequest
+	21, // 58: fiagram.account_service.AccountService.GetAccountList:input_type -> fiagram.account_service.GetAccountListRequest
+	23, // 59: fiagram.account_service.AccountService.UpdateAccountInfo:input_type -> fiagram.account_service.UpdateAccountInfoRequest
+	25, // 60: fiagram.account_service.AccountService.UpdateAccountPassword:input_type -> fiagram.account_service.UpdateAccountPasswordRequest
+	27, // 61: fiagram.account_service.AccountService.ChangePassword:input_type -> fiagram.account_service.ChangePasswordRequest
+	29, // 62: fiagram.account_service.AccountService.RequestPasswordReset:input_type -> fiagram.account_service.RequestPasswordResetRequest
+	31, // 63: fiagram.account_service.AccountService.ConfirmPasswordReset:input_type -> fiagram.account_service.ConfirmPasswordResetRequest
+	33, // 64: fiagram.account_service.AccountService.DeleteAccount:input_type -> fiagram.account_service.DeleteAccountRequest
+	35, // 65: fiagram.account_service.AccountService.DeleteAccountByUsername:input_type -> fiagram.account_service.DeleteAccountByUsernameRequest
+	37, // 66: fiagram.account_service.AccountService.RestoreAccount:input_type -> fiagram.account_service.RestoreAccountRequest
+	43, // 67: fiagram.account_service.AccountService.IssueRefreshToken:input_type -> fiagram.account_service.IssueRefreshTokenRequest
+	45, // 68: fiagram.account_service.AccountService.RotateRefreshToken:input_type -> fiagram.account_service.RotateRefreshTokenRequest
+	47, // 69: fiagram.account_service.AccountService.RevokeRefreshToken:input_type -> fiagram.account_service.RevokeRefreshTokenRequest
+	49, // 70: fiagram.account_service.AccountService.Login:input_type -> fiagram.account_service.LoginRequest
+	51, // 71: fiagram.account_service.AccountService.VerifySecondFactor:input_type -> fiagram.account_service.VerifySecondFactorRequest
+	54, // 72: fiagram.account_service.AccountService.GetJWKS:input_type -> fiagram.account_service.GetJWKSRequest
+	56, // 73: fiagram.account_service.AccountService.UnlockAccount:input_type -> fiagram.account_service.UnlockAccountRequest
+	58, // 74: fiagram.account_service.AccountService.SuspendAccount:input_type -> fiagram.account_service.SuspendAccountRequest
+	60, // 75: fiagram.account_service.AccountService.ReactivateAccount:input_type -> fiagram.account_service.ReactivateAccountRequest
+	62, // 76: fiagram.account_service.AccountService.DeactivateAccount:input_type -> fiagram.account_service.DeactivateAccountRequest
+	64, // 77: fiagram.account_service.AccountService.SendEmailVerification:input_type -> fiagram.account_service.SendEmailVerificationRequest
+	66, // 78: fiagram.account_service.AccountService.VerifyEmail:input_type -> fiagram.account_service.VerifyEmailRequest
+	68, // 79: fiagram.account_service.AccountService.SendPhoneVerification:input_type -> fiagram.account_service.SendPhoneVerificationRequest
+	70, // 80: fiagram.account_service.AccountService.VerifyPhone:input_type -> fiagram.account_service.VerifyPhoneRequest
+	72, // 81: fiagram.account_service.AccountService.EnrollTOTP:input_type -> fiagram.account_service.EnrollTOTPRequest
+	74, // 82: fiagram.account_service.AccountService.ConfirmTOTP:input_type -> fiagram.account_service.ConfirmTOTPRequest
+	76, // 83: fiagram.account_service.AccountService.DisableTOTP:input_type -> fiagram.account_service.DisableTOTPRequest
+	78, // 84: fiagram.account_service.AccountService.GenerateRecoveryCodes:input_type -> fiagram.account_service.GenerateRecoveryCodesRequest
+	80, // 85: fiagram.account_service.AccountService.BeginWebAuthnRegistration:input_type -> fiagram.account_service.BeginWebAuthnRegistrationRequest
+	82, // 86: fiagram.account_service.AccountService.FinishWebAuthnRegistration:input_type -> fiagram.account_service.FinishWebAuthnRegistrationRequest
+	84, // 87: fiagram.account_service.AccountService.BeginWebAuthnAssertion:input_type -> fiagram.account_service.BeginWebAuthnAssertionRequest
+	86, // 88: fiagram.account_service.AccountService.FinishWebAuthnAssertion:input_type -> fiagram.account_service.FinishWebAuthnAssertionRequest
+	8,  // 89: fiagram.account_service.AccountService.CreateAccount:output_type -> fiagram.account_service.CreateAccountResponse
+	40, // 90: fiagram.account_service.AccountService.CheckAccountValid:output_type -> fiagram.account_service.CheckAccountValidResponse
+	42, // 91: fiagram.account_service.AccountService.IsUsernameTaken:output_type -> fiagram.account_service.IsUsernameTakenResponse
+	10, // 92: fiagram.account_service.AccountService.GetAccount:output_type -> fiagram.account_service.GetAccountResponse
+	12, // 93: fiagram.account_service.AccountService.GetAccountAll:output_type -> fiagram.account_service.GetAccountAllResponse
+	14, // 94: fiagram.account_service.AccountService.ListAccounts:output_type -> fiagram.account_service.ListAccountsResponse
+	16, // 95: fiagram.account_service.AccountService.StreamAccounts:output_type -> fiagram.account_service.StreamAccountsResponse
+	18, // 96: fiagram.account_service.AccountService.ImportAccounts:output_type -> fiagram.account_service.ImportAccountsResponse
+	20, // 97: fiagram.account_service.AccountService.SearchAccounts:output_type -> fiagram.account_service.SearchAccountsResponse
+	22, // 98: fiagram.account_service.AccountService.GetAccountList:output_type -> fiagram.account_service.GetAccountListResponse
+	24, // 99: fiagram.account_service.AccountService.UpdateAccountInfo:output_type -> fiagram.account_service.UpdateAccountInfoResponse
+	26, // 100: fiagram.account_service.AccountService.UpdateAccountPassword:output_type -> fiagram.account_service.UpdateAccountPasswordResponse
+	28, // 101: fiagram.account_service.AccountService.ChangePassword:output_type -> fiagram.account_service.ChangePasswordResponse
+	30, // 102: fiagram.account_service.AccountService.RequestPasswordReset:output_type -> fiagram.account_service.RequestPasswordResetResponse
+	32, // 103: fiagram.account_service.AccountService.ConfirmPasswordReset:output_type -> fiagram.account_service.ConfirmPasswordResetResponse
+	34, // 104: fiagram.account_service.AccountService.DeleteAccount:output_type -> fiagram.account_service.DeleteAccountResponse
+	36, // 105: fiagram.account_service.AccountService.DeleteAccountByUsername:output_type -> fiagram.account_service.DeleteAccountByUsernameResponse
+	38, // 106: fiagram.account_service.AccountService.RestoreAccount:output_type -> fiagram.account_service.RestoreAccountResponse
+	44, // 107: fiagram.account_service.AccountService.IssueRefreshToken:output_type -> fiagram.account_service.IssueRefreshTokenResponse
+	46, // 108: fiagram.account_service.AccountService.RotateRefreshToken:output_type -> fiagram.account_service.RotateRefreshTokenResponse
+	48, // 109: fiagram.account_service.AccountService.RevokeRefreshToken:output_type -> fiagram.account_service.RevokeRefreshTokenResponse
+	50, // 110: fiagram.account_service.AccountService.Login:output_type -> fiagram.account_service.LoginResponse
+	52, // 111: fiagram.account_service.AccountService.VerifySecondFactor:output_type -> fiagram.account_service.VerifySecondFactorResponse
+	55, // 112: fiagram.account_service.AccountService.GetJWKS:output_type -> fiagram.account_service.GetJWKSResponse
+	57, // 113: fiagram.account_service.AccountService.UnlockAccount:output_type -> fiagram.account_service.UnlockAccountResponse
+	59, // 114: fiagram.account_service.AccountService.SuspendAccount:output_type -> fiagram.account_service.SuspendAccountResponse
+	61, // 115: fiagram.account_service.AccountService.ReactivateAccount:output_type -> fiagram.account_service.ReactivateAccountResponse
+	63, // 116: fiagram.account_service.AccountService.DeactivateAccount:output_type -> fiagram.account_service.DeactivateAccountResponse
+	65, // 117: fiagram.account_service.AccountService.SendEmailVerification:output_type -> fiagram.account_service.SendEmailVerificationResponse
+	67, // 118: fiagram.account_service.AccountService.VerifyEmail:output_type -> fiagram.account_service.VerifyEmailResponse
+	69, // 119: fiagram.account_service.AccountService.SendPhoneVerification:output_type -> fiagram.account_service.SendPhoneVerificationResponse
+	71, // 120: fiagram.account_service.AccountService.VerifyPhone:output_type -> fiagram.account_service.VerifyPhoneResponse
+	73, // 121: fiagram.account_service.AccountService.EnrollTOTP:output_type -> fiagram.account_service.EnrollTOTPResponse
+	75, // 122: fiagram.account_service.AccountService.ConfirmTOTP:output_type -> fiagram.account_service.ConfirmTOTPResponse
+	77, // 123: fiagram.account_service.AccountService.DisableTOTP:output_type -> fiagram.account_service.DisableTOTPResponse
+	79, // 124: fiagram.account_service.AccountService.GenerateRecoveryCodes:output_type -> fiagram.account_service.GenerateRecoveryCodesResponse
+	81, // 125: fiagram.account_service.AccountService.BeginWebAuthnRegistration:output_type -> fiagram.account_service.BeginWebAuthnRegistrationResponse
+	83, // 126: fiagram.account_service.AccountService.FinishWebAuthnRegistration:output_type -> fiagram.account_service.FinishWebAuthnRegistrationResponse
+	85, // 127: fiagram.account_service.AccountService.BeginWebAuthnAssertion:output_type -> fiagram.account_service.BeginWebAuthnAssertionResponse
+	87, // 128: fiagram.account_service.AccountService.FinishWebAuthnAssertion:output_type -> fiagram.account_service.FinishWebAuthnAssertionResponse
+	89, // [89:129] is the sub-list for method output_type
+	49, // [49:89] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_api_account_service_account_service_proto_init() }
@@ -1248,13 +5678,15 @@ func file_api_account_service_account_service_proto_init() {
 	if File_api_account_service_account_service_proto != nil {
 		return
 	}
+	file_api_account_service_account_service_proto_msgTypes[7].OneofWrappers = []any{}
+	file_api_account_service_account_service_proto_msgTypes[9].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_account_service_account_service_proto_rawDesc), len(file_api_account_service_account_service_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   21,
+			NumEnums:      6,
+			NumMessages:   85,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -2,24 +2,111 @@ package grpc
 
 import (
 	"context"
+	"net"
+	"strings"
 
 	"github.com/Fiagram/account_service/internal/generated/grpc/account_service"
 	"github.com/Fiagram/account_service/internal/logic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Handler struct {
 	account_service.UnimplementedAccountServiceServer
-	accountLogic logic.Account
+	accountLogic           logic.Account
+	refreshTokenLogic      logic.RefreshToken
+	accessTokenLogic       logic.AccessToken
+	authLogic              logic.Auth
+	loginThrottleLogic     logic.LoginThrottle
+	emailVerificationLogic logic.EmailVerification
+	phoneVerificationLogic logic.PhoneVerification
+	totpLogic              logic.TOTP
+	recoveryCodeLogic      logic.RecoveryCode
+	webAuthnLogic          logic.WebAuthn
 }
 
 func NewHandler(
 	accountLogic logic.Account,
+	refreshTokenLogic logic.RefreshToken,
+	accessTokenLogic logic.AccessToken,
+	authLogic logic.Auth,
+	loginThrottleLogic logic.LoginThrottle,
+	emailVerificationLogic logic.EmailVerification,
+	phoneVerificationLogic logic.PhoneVerification,
+	totpLogic logic.TOTP,
+	recoveryCodeLogic logic.RecoveryCode,
+	webAuthnLogic logic.WebAuthn,
 ) account_service.AccountServiceServer {
 	return &Handler{
-		accountLogic: accountLogic,
+		accountLogic:           accountLogic,
+		refreshTokenLogic:      refreshTokenLogic,
+		accessTokenLogic:       accessTokenLogic,
+		authLogic:              authLogic,
+		loginThrottleLogic:     loginThrottleLogic,
+		emailVerificationLogic: emailVerificationLogic,
+		phoneVerificationLogic: phoneVerificationLogic,
+		totpLogic:              totpLogic,
+		recoveryCodeLogic:      recoveryCodeLogic,
+		webAuthnLogic:          webAuthnLogic,
 	}
 }
 
+// Returns the host part of the caller's address, so every connection from the
+// same host shares one login failure counter.
+func peerAddressOf(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// Returns the bearer token of the authorization metadata, empty when missing.
+func bearerTokenOf(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(values) == 0 {
+		return ""
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// Unset role, status and bounds leave the listing unfiltered on them.
+func accountFilterOf(
+	role *account_service.AccountInfo_Role,
+	accountStatus *account_service.AccountInfo_Status,
+	createdAfter *timestamppb.Timestamp,
+	createdBefore *timestamppb.Timestamp,
+	usernamePrefix string,
+) logic.AccountFilter {
+	filter := logic.AccountFilter{UsernamePrefix: usernamePrefix}
+	if role != nil {
+		filterRole := logic.Role(*role)
+		filter.Role = &filterRole
+	}
+	if accountStatus != nil {
+		filterStatus := logic.AccountStatus(*accountStatus)
+		filter.Status = &filterStatus
+	}
+	if createdAfter != nil {
+		filter.CreatedAfter = createdAfter.AsTime()
+	}
+	if createdBefore != nil {
+		filter.CreatedBefore = createdBefore.AsTime()
+	}
+	return filter
+}
+
 func (h *Handler) CreateAccount(
 	ctx context.Context,
 	request *account_service.CreateAccountRequest,
@@ -32,6 +119,7 @@ func (h *Handler) CreateAccount(
 				Email:       request.GetAccountInfo().GetEmail(),
 				PhoneNumber: request.GetAccountInfo().GetPhoneNumber(),
 				Role:        logic.Role(request.GetAccountInfo().GetRole()),
+				Status:      logic.AccountStatus(request.GetAccountInfo().GetStatus()),
 			},
 			Password: request.GetPassword(),
 		})
@@ -52,6 +140,7 @@ func (h *Handler) CheckAccountValid(
 		logic.CheckAccountValidParams{
 			Username: request.Username,
 			Password: request.Password,
+			Address:  peerAddressOf(ctx),
 		})
 
 	if err != nil {
@@ -92,7 +181,7 @@ func (h *Handler) GetAccount(
 		return nil, err
 	}
 
-	return &account_service.GetAccountResponse{
+	response := &account_service.GetAccountResponse{
 		AccountId: output.AccountId,
 		Account: &account_service.AccountInfo{
 			Username:    output.AccountInfo.Username,
@@ -100,8 +189,18 @@ func (h *Handler) GetAccount(
 			Email:       output.AccountInfo.Email,
 			PhoneNumber: output.AccountInfo.PhoneNumber,
 			Role:        account_service.AccountInfo_Role(output.AccountInfo.Role),
+			Status:      account_service.AccountInfo_Status(output.AccountInfo.Status),
 		},
-	}, nil
+		IsEmailVerified: output.EmailVerifiedAt != nil,
+		IsPhoneVerified: output.PhoneVerifiedAt != nil,
+	}
+	if output.EmailVerifiedAt != nil {
+		response.EmailVerifiedAt = timestamppb.New(*output.EmailVerifiedAt)
+	}
+	if output.PhoneVerifiedAt != nil {
+		response.PhoneVerifiedAt = timestamppb.New(*output.PhoneVerifiedAt)
+	}
+	return response, nil
 }
 func (h *Handler) GetAccountAll(
 	ctx context.Context,
@@ -122,6 +221,7 @@ func (h *Handler) GetAccountAll(
 			Email:       info.Email,
 			PhoneNumber: info.PhoneNumber,
 			Role:        account_service.AccountInfo_Role(info.Role),
+			Status:      account_service.AccountInfo_Status(info.Status),
 		})
 	}
 
@@ -130,6 +230,179 @@ func (h *Handler) GetAccountAll(
 		AccountInfoList: accountInfos,
 	}, nil
 }
+func (h *Handler) ListAccounts(
+	ctx context.Context,
+	request *account_service.ListAccountsRequest,
+) (*account_service.ListAccountsResponse, error) {
+	output, err := h.accountLogic.ListAccounts(ctx,
+		logic.ListAccountsParams{
+			PageSize:  int(request.GetPageSize()),
+			PageToken: request.GetPageToken(),
+			AccountFilter: accountFilterOf(request.Role, request.Status,
+				request.GetCreatedAfter(), request.GetCreatedBefore(),
+				request.GetUsernamePrefix()),
+			Order: logic.AccountOrder(request.GetOrder()),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	accountInfos := make([]*account_service.AccountInfo, 0, len(output.AccountInfos))
+	for _, info := range output.AccountInfos {
+		accountInfos = append(accountInfos, &account_service.AccountInfo{
+			Username:    info.Username,
+			Fullname:    info.Fullname,
+			Email:       info.Email,
+			PhoneNumber: info.PhoneNumber,
+			Role:        account_service.AccountInfo_Role(info.Role),
+			Status:      account_service.AccountInfo_Status(info.Status),
+		})
+	}
+
+	return &account_service.ListAccountsResponse{
+		AccountIdList:   output.AccountIds,
+		AccountInfoList: accountInfos,
+		NextPageToken:   output.NextPageToken,
+	}, nil
+}
+func (h *Handler) StreamAccounts(
+	request *account_service.StreamAccountsRequest,
+	stream account_service.AccountService_StreamAccountsServer,
+) error {
+	return h.accountLogic.StreamAccounts(stream.Context(),
+		logic.StreamAccountsParams{
+			AccountFilter: accountFilterOf(request.Role, request.Status,
+				request.GetCreatedAfter(), request.GetCreatedBefore(),
+				request.GetUsernamePrefix()),
+			Order: logic.AccountOrder(request.GetOrder()),
+		},
+		// Send blocks while the client is behind, which slows the reading
+		// of the accounts down to its pace
+		func(output logic.StreamAccountsOutput) error {
+			return stream.Send(&account_service.StreamAccountsResponse{
+				AccountId: output.AccountId,
+				AccountInfo: &account_service.AccountInfo{
+					Username:    output.AccountInfo.Username,
+					Fullname:    output.AccountInfo.Fullname,
+					Email:       output.AccountInfo.Email,
+					PhoneNumber: output.AccountInfo.PhoneNumber,
+					Role:        account_service.AccountInfo_Role(output.AccountInfo.Role),
+					Status:      account_service.AccountInfo_Status(output.AccountInfo.Status),
+				},
+			})
+		},
+	)
+}
+
+// Reads the chunks of an import as one file.
+type importAccountsStreamReader struct {
+	stream account_service.AccountService_ImportAccountsServer
+	chunk  []byte
+}
+
+func (r *importAccountsStreamReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		request, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.chunk = request.GetChunk()
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+func (h *Handler) ImportAccounts(
+	stream account_service.AccountService_ImportAccountsServer,
+) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	rows, err := logic.NewAccountImportReader(
+		&importAccountsStreamReader{stream: stream, chunk: first.GetChunk()},
+		logic.AccountFileFormat(first.GetFormat()))
+	if err != nil {
+		return err
+	}
+
+	output, err := h.accountLogic.ImportAccounts(stream.Context(),
+		logic.ImportAccountsParams{
+			Rows:   rows,
+			DryRun: first.GetDryRun(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	results := make([]*account_service.ImportAccountsResponse_RowResult, 0, len(output.Results))
+	for _, result := range output.Results {
+		results = append(results, &account_service.ImportAccountsResponse_RowResult{
+			Row:       uint32(result.Row),
+			Username:  result.Username,
+			Outcome:   account_service.ImportAccountsResponse_RowResult_Outcome(result.Outcome),
+			AccountId: result.AccountId,
+			Message:   result.Message,
+		})
+	}
+
+	return stream.SendAndClose(&account_service.ImportAccountsResponse{
+		Results:        results,
+		CreatedCount:   uint32(output.Counts[logic.ImportAccountsOutcomeCreated]),
+		ValidCount:     uint32(output.Counts[logic.ImportAccountsOutcomeValid]),
+		DuplicateCount: uint32(output.Counts[logic.ImportAccountsOutcomeDuplicate]),
+		InvalidCount:   uint32(output.Counts[logic.ImportAccountsOutcomeInvalid]),
+		FailedCount:    uint32(output.Counts[logic.ImportAccountsOutcomeFailed]),
+	})
+}
+func (h *Handler) SearchAccounts(
+	ctx context.Context,
+	request *account_service.SearchAccountsRequest,
+) (*account_service.SearchAccountsResponse, error) {
+	output, err := h.accountLogic.SearchAccounts(ctx,
+		logic.SearchAccountsParams{
+			Query:     request.GetQuery(),
+			PageSize:  int(request.GetPageSize()),
+			PageToken: request.GetPageToken(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*account_service.SearchAccountsResponse_Result, 0, len(output.Results))
+	for _, result := range output.Results {
+		highlights := make([]*account_service.SearchAccountsResponse_Highlight, 0, len(result.Highlights))
+		for _, highlight := range result.Highlights {
+			highlights = append(highlights, &account_service.SearchAccountsResponse_Highlight{
+				Field:       account_service.SearchAccountsResponse_Highlight_Field(highlight.Field),
+				Highlighted: highlight.Highlighted,
+			})
+		}
+
+		results = append(results, &account_service.SearchAccountsResponse_Result{
+			AccountId: result.AccountId,
+			AccountInfo: &account_service.AccountInfo{
+				Username:    result.AccountInfo.Username,
+				Fullname:    result.AccountInfo.Fullname,
+				Email:       result.AccountInfo.Email,
+				PhoneNumber: result.AccountInfo.PhoneNumber,
+				Role:        account_service.AccountInfo_Role(result.AccountInfo.Role),
+				Status:      account_service.AccountInfo_Status(result.AccountInfo.Status),
+			},
+			Score:      result.Score,
+			Highlights: highlights,
+		})
+	}
+
+	return &account_service.SearchAccountsResponse{
+		Results:       results,
+		NextPageToken: output.NextPageToken,
+	}, nil
+}
 func (h *Handler) GetAccountList(
 	ctx context.Context,
 	request *account_service.GetAccountListRequest,
@@ -151,6 +424,7 @@ func (h *Handler) GetAccountList(
 			Email:       info.Email,
 			PhoneNumber: info.PhoneNumber,
 			Role:        account_service.AccountInfo_Role(info.Role),
+			Status:      account_service.AccountInfo_Status(info.Status),
 		})
 	}
 
@@ -203,6 +477,62 @@ func (h *Handler) UpdateAccountPassword(
 	}, nil
 }
 
+func (h *Handler) ChangePassword(
+	ctx context.Context,
+	request *account_service.ChangePasswordRequest,
+) (*account_service.ChangePasswordResponse, error) {
+	output, err := h.accountLogic.ChangePassword(ctx,
+		logic.ChangePasswordParams{
+			AccountId:       request.GetAccountId(),
+			CurrentPassword: request.GetCurrentPassword(),
+			NewPassword:     request.GetNewPassword(),
+			Address:         peerAddressOf(ctx),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.ChangePasswordResponse{
+		AccountId: output.AccountId,
+	}, nil
+}
+
+func (h *Handler) RequestPasswordReset(
+	ctx context.Context,
+	request *account_service.RequestPasswordResetRequest,
+) (*account_service.RequestPasswordResetResponse, error) {
+	err := h.accountLogic.RequestPasswordReset(ctx,
+		logic.RequestPasswordResetParams{
+			Username: request.GetUsername(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.RequestPasswordResetResponse{
+		Username: request.GetUsername(),
+	}, nil
+}
+
+func (h *Handler) ConfirmPasswordReset(
+	ctx context.Context,
+	request *account_service.ConfirmPasswordResetRequest,
+) (*account_service.ConfirmPasswordResetResponse, error) {
+	output, err := h.accountLogic.ConfirmPasswordReset(ctx,
+		logic.ConfirmPasswordResetParams{
+			Token:       request.GetToken(),
+			NewPassword: request.GetNewPassword(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.ConfirmPasswordResetResponse{
+		AccountId: output.AccountId,
+	}, nil
+}
+
 func (h *Handler) DeleteAccount(
 	ctx context.Context,
 	request *account_service.DeleteAccountRequest,
@@ -236,3 +566,469 @@ func (h *Handler) DeleteAccountByUsername(
 		Username: request.GetUsername(),
 	}, nil
 }
+
+func (h *Handler) RestoreAccount(
+	ctx context.Context,
+	request *account_service.RestoreAccountRequest,
+) (*account_service.RestoreAccountResponse, error) {
+	output, err := h.accountLogic.RestoreAccount(ctx,
+		logic.RestoreAccountParams{
+			AccountId: request.GetAccountId(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.RestoreAccountResponse{
+		AccountId: output.AccountId,
+	}, nil
+}
+
+func (h *Handler) IssueRefreshToken(
+	ctx context.Context,
+	request *account_service.IssueRefreshTokenRequest,
+) (*account_service.IssueRefreshTokenResponse, error) {
+	// Only the holder of a valid access token of the account gets one
+	verified, err := h.accessTokenLogic.VerifyAccessToken(ctx,
+		logic.VerifyAccessTokenParams{
+			AccessToken: bearerTokenOf(ctx),
+		})
+	if err != nil {
+		return nil, err
+	}
+	if verified.AccountId != request.GetAccountId() {
+		return nil, status.Error(codes.PermissionDenied, "access token is of another account")
+	}
+
+	output, err := h.refreshTokenLogic.IssueRefreshToken(ctx,
+		logic.IssueRefreshTokenParams{
+			AccountId: request.GetAccountId(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.IssueRefreshTokenResponse{
+		RefreshToken: output.RefreshToken,
+		ExpiresAt:    timestamppb.New(output.ExpiresAt),
+	}, nil
+}
+
+func (h *Handler) RotateRefreshToken(
+	ctx context.Context,
+	request *account_service.RotateRefreshTokenRequest,
+) (*account_service.RotateRefreshTokenResponse, error) {
+	output, err := h.refreshTokenLogic.RotateRefreshToken(ctx,
+		logic.RotateRefreshTokenParams{
+			RefreshToken: request.GetRefreshToken(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.RotateRefreshTokenResponse{
+		AccountId:    output.AccountId,
+		RefreshToken: output.RefreshToken,
+		ExpiresAt:    timestamppb.New(output.ExpiresAt),
+	}, nil
+}
+
+func (h *Handler) RevokeRefreshToken(
+	ctx context.Context,
+	request *account_service.RevokeRefreshTokenRequest,
+) (*account_service.RevokeRefreshTokenResponse, error) {
+	err := h.refreshTokenLogic.RevokeRefreshToken(ctx,
+		logic.RevokeRefreshTokenParams{
+			RefreshToken: request.GetRefreshToken(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.RevokeRefreshTokenResponse{}, nil
+}
+
+func (h *Handler) Login(
+	ctx context.Context,
+	request *account_service.LoginRequest,
+) (*account_service.LoginResponse, error) {
+	output, err := h.authLogic.Login(ctx,
+		logic.LoginParams{
+			Username: request.GetUsername(),
+			Password: request.GetPassword(),
+			Address:  peerAddressOf(ctx),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.LoginResponse{
+		AccountId:             output.AccountId,
+		TokenType:             "Bearer",
+		AccessToken:           output.AccessToken,
+		AccessTokenExpiresAt:  timestamppb.New(output.AccessTokenExpiresAt),
+		RefreshToken:          output.RefreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(output.RefreshTokenExpiresAt),
+	}, nil
+}
+
+func (h *Handler) VerifySecondFactor(
+	ctx context.Context,
+	request *account_service.VerifySecondFactorRequest,
+) (*account_service.VerifySecondFactorResponse, error) {
+	output, err := h.authLogic.VerifySecondFactor(ctx,
+		logic.VerifySecondFactorParams{
+			ChallengeId: request.GetChallengeId(),
+			Code:        request.GetCode(),
+			Address:     peerAddressOf(ctx),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.VerifySecondFactorResponse{
+		AccountId:              output.AccountId,
+		TokenType:              "Bearer",
+		AccessToken:            output.AccessToken,
+		AccessTokenExpiresAt:   timestamppb.New(output.AccessTokenExpiresAt),
+		RefreshToken:           output.RefreshToken,
+		RefreshTokenExpiresAt:  timestamppb.New(output.RefreshTokenExpiresAt),
+		IsRecoveryCodeUsed:     output.IsRecoveryCodeUsed,
+		RemainingRecoveryCodes: uint32(output.RemainingRecoveryCodes),
+	}, nil
+}
+
+func (h *Handler) GetJWKS(
+	ctx context.Context,
+	request *account_service.GetJWKSRequest,
+) (*account_service.GetJWKSResponse, error) {
+	output, err := h.accessTokenLogic.GetJWKS(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*account_service.JsonWebKey, 0, len(output.Keys))
+	for _, key := range output.Keys {
+		keys = append(keys, &account_service.JsonWebKey{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Use: key.Use,
+			Alg: key.Alg,
+			Crv: key.Crv,
+			X:   key.X,
+			Y:   key.Y,
+			N:   key.N,
+			E:   key.E,
+		})
+	}
+
+	return &account_service.GetJWKSResponse{
+		Keys: keys,
+	}, nil
+}
+
+func (h *Handler) UnlockAccount(
+	ctx context.Context,
+	request *account_service.UnlockAccountRequest,
+) (*account_service.UnlockAccountResponse, error) {
+	err := h.loginThrottleLogic.UnlockAccount(ctx,
+		logic.UnlockAccountParams{
+			Username: request.GetUsername(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.UnlockAccountResponse{
+		Username: request.GetUsername(),
+	}, nil
+}
+
+func (h *Handler) SuspendAccount(
+	ctx context.Context,
+	request *account_service.SuspendAccountRequest,
+) (*account_service.SuspendAccountResponse, error) {
+	output, err := h.accountLogic.SuspendAccount(ctx,
+		logic.ChangeAccountStatusParams{
+			AccountId:      request.GetAccountId(),
+			Reason:         request.GetReason(),
+			ActorAccountId: request.GetActorAccountId(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.SuspendAccountResponse{
+		AccountId: output.AccountId,
+		Status:    account_service.AccountInfo_Status(output.Status),
+	}, nil
+}
+
+func (h *Handler) ReactivateAccount(
+	ctx context.Context,
+	request *account_service.ReactivateAccountRequest,
+) (*account_service.ReactivateAccountResponse, error) {
+	output, err := h.accountLogic.ReactivateAccount(ctx,
+		logic.ChangeAccountStatusParams{
+			AccountId:      request.GetAccountId(),
+			Reason:         request.GetReason(),
+			ActorAccountId: request.GetActorAccountId(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.ReactivateAccountResponse{
+		AccountId: output.AccountId,
+		Status:    account_service.AccountInfo_Status(output.Status),
+	}, nil
+}
+
+func (h *Handler) DeactivateAccount(
+	ctx context.Context,
+	request *account_service.DeactivateAccountRequest,
+) (*account_service.DeactivateAccountResponse, error) {
+	output, err := h.accountLogic.DeactivateAccount(ctx,
+		logic.ChangeAccountStatusParams{
+			AccountId:      request.GetAccountId(),
+			Reason:         request.GetReason(),
+			ActorAccountId: request.GetActorAccountId(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.DeactivateAccountResponse{
+		AccountId: output.AccountId,
+		Status:    account_service.AccountInfo_Status(output.Status),
+	}, nil
+}
+
+func (h *Handler) SendEmailVerification(
+	ctx context.Context,
+	request *account_service.SendEmailVerificationRequest,
+) (*account_service.SendEmailVerificationResponse, error) {
+	output, err := h.emailVerificationLogic.SendEmailVerification(ctx,
+		logic.SendEmailVerificationParams{
+			AccountId: request.GetAccountId(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.SendEmailVerificationResponse{
+		AccountId: request.GetAccountId(),
+		ExpiresAt: timestamppb.New(output.ExpiresAt),
+	}, nil
+}
+
+func (h *Handler) VerifyEmail(
+	ctx context.Context,
+	request *account_service.VerifyEmailRequest,
+) (*account_service.VerifyEmailResponse, error) {
+	output, err := h.emailVerificationLogic.VerifyEmail(ctx,
+		logic.VerifyEmailParams{
+			AccountId: request.GetAccountId(),
+			Code:      request.GetCode(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.VerifyEmailResponse{
+		AccountId:       output.AccountId,
+		EmailVerifiedAt: timestamppb.New(output.EmailVerifiedAt),
+	}, nil
+}
+
+func (h *Handler) SendPhoneVerification(
+	ctx context.Context,
+	request *account_service.SendPhoneVerificationRequest,
+) (*account_service.SendPhoneVerificationResponse, error) {
+	output, err := h.phoneVerificationLogic.SendPhoneVerification(ctx,
+		logic.SendPhoneVerificationParams{
+			AccountId: request.GetAccountId(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.SendPhoneVerificationResponse{
+		AccountId: request.GetAccountId(),
+		ExpiresAt: timestamppb.New(output.ExpiresAt),
+	}, nil
+}
+
+func (h *Handler) VerifyPhone(
+	ctx context.Context,
+	request *account_service.VerifyPhoneRequest,
+) (*account_service.VerifyPhoneResponse, error) {
+	output, err := h.phoneVerificationLogic.VerifyPhone(ctx,
+		logic.VerifyPhoneParams{
+			AccountId: request.GetAccountId(),
+			Code:      request.GetCode(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.VerifyPhoneResponse{
+		AccountId:       output.AccountId,
+		PhoneVerifiedAt: timestamppb.New(output.PhoneVerifiedAt),
+	}, nil
+}
+
+func (h *Handler) EnrollTOTP(
+	ctx context.Context,
+	request *account_service.EnrollTOTPRequest,
+) (*account_service.EnrollTOTPResponse, error) {
+	output, err := h.totpLogic.EnrollTOTP(ctx,
+		logic.EnrollTOTPParams{
+			AccountId: request.GetAccountId(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.EnrollTOTPResponse{
+		AccountId: request.GetAccountId(),
+		Secret:    output.Secret,
+		Uri:       output.URI,
+	}, nil
+}
+
+func (h *Handler) ConfirmTOTP(
+	ctx context.Context,
+	request *account_service.ConfirmTOTPRequest,
+) (*account_service.ConfirmTOTPResponse, error) {
+	err := h.totpLogic.ConfirmTOTP(ctx,
+		logic.ConfirmTOTPParams{
+			AccountId: request.GetAccountId(),
+			Code:      request.GetCode(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.ConfirmTOTPResponse{
+		AccountId: request.GetAccountId(),
+	}, nil
+}
+
+func (h *Handler) DisableTOTP(
+	ctx context.Context,
+	request *account_service.DisableTOTPRequest,
+) (*account_service.DisableTOTPResponse, error) {
+	err := h.totpLogic.DisableTOTP(ctx,
+		logic.DisableTOTPParams{
+			AccountId: request.GetAccountId(),
+			Code:      request.GetCode(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.DisableTOTPResponse{
+		AccountId: request.GetAccountId(),
+	}, nil
+}
+
+func (h *Handler) GenerateRecoveryCodes(
+	ctx context.Context,
+	request *account_service.GenerateRecoveryCodesRequest,
+) (*account_service.GenerateRecoveryCodesResponse, error) {
+	output, err := h.recoveryCodeLogic.GenerateRecoveryCodes(ctx,
+		logic.GenerateRecoveryCodesParams{
+			AccountId: request.GetAccountId(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.GenerateRecoveryCodesResponse{
+		AccountId: request.GetAccountId(),
+		Codes:     output.Codes,
+	}, nil
+}
+
+func (h *Handler) BeginWebAuthnRegistration(
+	ctx context.Context,
+	request *account_service.BeginWebAuthnRegistrationRequest,
+) (*account_service.BeginWebAuthnRegistrationResponse, error) {
+	output, err := h.webAuthnLogic.BeginWebAuthnRegistration(ctx,
+		logic.BeginWebAuthnRegistrationParams{
+			AccountId: request.GetAccountId(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.BeginWebAuthnRegistrationResponse{
+		AccountId: request.GetAccountId(),
+		SessionId: output.SessionId,
+		Options:   output.Options,
+		ExpiresAt: timestamppb.New(output.ExpiresAt),
+	}, nil
+}
+
+func (h *Handler) FinishWebAuthnRegistration(
+	ctx context.Context,
+	request *account_service.FinishWebAuthnRegistrationRequest,
+) (*account_service.FinishWebAuthnRegistrationResponse, error) {
+	output, err := h.webAuthnLogic.FinishWebAuthnRegistration(ctx,
+		logic.FinishWebAuthnRegistrationParams{
+			SessionId:  request.GetSessionId(),
+			Credential: request.GetCredential(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.FinishWebAuthnRegistrationResponse{
+		AccountId:    output.AccountId,
+		CredentialId: output.CredentialId,
+	}, nil
+}
+
+func (h *Handler) BeginWebAuthnAssertion(
+	ctx context.Context,
+	request *account_service.BeginWebAuthnAssertionRequest,
+) (*account_service.BeginWebAuthnAssertionResponse, error) {
+	output, err := h.webAuthnLogic.BeginWebAuthnAssertion(ctx,
+		logic.BeginWebAuthnAssertionParams{
+			Username: request.GetUsername(),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.BeginWebAuthnAssertionResponse{
+		SessionId: output.SessionId,
+		Options:   output.Options,
+		ExpiresAt: timestamppb.New(output.ExpiresAt),
+	}, nil
+}
+
+func (h *Handler) FinishWebAuthnAssertion(
+	ctx context.Context,
+	request *account_service.FinishWebAuthnAssertionRequest,
+) (*account_service.FinishWebAuthnAssertionResponse, error) {
+	output, err := h.authLogic.LoginWithWebAuthn(ctx,
+		logic.FinishWebAuthnAssertionParams{
+			SessionId:  request.GetSessionId(),
+			Credential: request.GetCredential(),
+			Address:    peerAddressOf(ctx),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account_service.FinishWebAuthnAssertionResponse{
+		AccountId:             output.AccountId,
+		TokenType:             "Bearer",
+		AccessToken:           output.AccessToken,
+		AccessTokenExpiresAt:  timestamppb.New(output.AccessTokenExpiresAt),
+		RefreshToken:          output.RefreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(output.RefreshTokenExpiresAt),
+	}, nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/Fiagram/account_service/internal/utils"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Server interface {
@@ -46,8 +48,50 @@ func (s *server) Start(ctx context.Context) error {
 	}
 	defer listener.Close()
 
-	server := grpc.NewServer()
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.recoverUnary),
+		grpc.ChainStreamInterceptor(s.recoverStream),
+	)
 	account_service.RegisterAccountServiceServer(server, s.handler)
 	logger.Info("the grpc server listening")
 	return server.Serve(listener)
 }
+
+// Turns a panic of a handler into an internal error of its call, so the
+// server keeps serving the others.
+func (s *server) recoverUnary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = s.recovered(ctx, info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func (s *server) recoverStream(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = s.recovered(ss.Context(), info.FullMethod, r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+func (s *server) recovered(ctx context.Context, method string, r any) error {
+	utils.LoggerWithContext(ctx, s.logger).
+		With(zap.String("method", method)).
+		With(zap.Any("panic", r)).
+		With(zap.Stack("stack")).
+		Error("recovered from a panic of the handler")
+	return status.Error(codes.Internal, "internal error")
+}
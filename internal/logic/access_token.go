@@ -0,0 +1,294 @@
+package logic
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const accessTokenIdByteLength = 16
+
+type AccessToken interface {
+	IssueAccessToken(ctx context.Context, params IssueAccessTokenParams) (IssueAccessTokenOutput, error)
+	// Checks the signature, the issuer, the audience and the lifetime of a
+	// token this service issued.
+	VerifyAccessToken(ctx context.Context, params VerifyAccessTokenParams) (VerifyAccessTokenOutput, error)
+	GetJWKS(ctx context.Context) (GetJWKSOutput, error)
+}
+
+type AccessTokenClaims struct {
+	jwt.RegisteredClaims
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+type signingKey struct {
+	id         string
+	method     jwt.SigningMethod
+	privateKey crypto.Signer
+}
+
+type accessToken struct {
+	accessTokenConfig configs.AccessToken
+	keys              []signingKey
+	logger            *zap.Logger
+}
+
+func NewAccessToken(
+	accessTokenConfig configs.AccessToken,
+	logger *zap.Logger,
+) (AccessToken, error) {
+	keys := make([]signingKey, 0, len(accessTokenConfig.Keys))
+	for _, keyConfig := range accessTokenConfig.Keys {
+		key, err := loadSigningKey(keyConfig)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		key, err := generateSigningKey()
+		if err != nil {
+			return nil, err
+		}
+		logger.With(zap.String("kid", key.id)).
+			Warn("no access token signing key configured, using an ephemeral key")
+		keys = append(keys, key)
+	}
+
+	return &accessToken{
+		accessTokenConfig: accessTokenConfig,
+		keys:              keys,
+		logger:            logger,
+	}, nil
+}
+
+func loadSigningKey(keyConfig configs.SigningKey) (signingKey, error) {
+	pemBytes, err := os.ReadFile(keyConfig.PrivateKeyFile)
+	if err != nil {
+		return signingKey{}, fmt.Errorf("Failed to read signing key file: %w", err)
+	}
+
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return signingKey{}, fmt.Errorf("Failed to decode PEM block of signing key %q", keyConfig.Id)
+	}
+
+	var parsed any
+	if parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+		if parsed, err = x509.ParseECPrivateKey(block.Bytes); err != nil {
+			if parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+				return signingKey{}, fmt.Errorf("Failed to parse signing key %q: unsupported format", keyConfig.Id)
+			}
+		}
+	}
+
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return signingKey{}, fmt.Errorf("Failed to use signing key %q: not a signer", keyConfig.Id)
+	}
+	method, err := signingMethodOf(signer)
+	if err != nil {
+		return signingKey{}, fmt.Errorf("Failed to use signing key %q: %w", keyConfig.Id, err)
+	}
+
+	id := keyConfig.Id
+	if id == "" {
+		id = keyIdOf(signer.Public())
+	}
+
+	return signingKey{
+		id:         id,
+		method:     method,
+		privateKey: signer,
+	}, nil
+}
+
+func generateSigningKey() (signingKey, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return signingKey{}, fmt.Errorf("Failed to generate signing key: %w", err)
+	}
+
+	return signingKey{
+		id:         keyIdOf(privateKey.Public()),
+		method:     jwt.SigningMethodES256,
+		privateKey: privateKey,
+	}, nil
+}
+
+func signingMethodOf(signer crypto.Signer) (jwt.SigningMethod, error) {
+	switch key := signer.(type) {
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		}
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PrivateKey:
+		return jwt.SigningMethodEdDSA, nil
+	}
+	return nil, errors.New("unsupported key type")
+}
+
+// Derives a stable key id from the DER encoding of the public key.
+func keyIdOf(publicKey crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(der)
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+func (a accessToken) IssueAccessToken(
+	_ context.Context,
+	params IssueAccessTokenParams,
+) (IssueAccessTokenOutput, error) {
+	emptyObj := IssueAccessTokenOutput{}
+	tokenId, err := generateOpaqueToken(accessTokenIdByteLength)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to generate access token id")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(a.accessTokenConfig.TTL)
+	claims := AccessTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenId,
+			Issuer:    a.accessTokenConfig.Issuer,
+			Subject:   strconv.FormatUint(params.AccountId, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Username: params.Username,
+		Role:     params.Role.String(),
+	}
+	if a.accessTokenConfig.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{a.accessTokenConfig.Audience}
+	}
+
+	key := a.keys[0]
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+	signed, err := token.SignedString(key.privateKey)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to sign access token")
+	}
+
+	return IssueAccessTokenOutput{
+		AccessToken: signed,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+func (a accessToken) VerifyAccessToken(
+	_ context.Context,
+	params VerifyAccessTokenParams,
+) (VerifyAccessTokenOutput, error) {
+	emptyObj := VerifyAccessTokenOutput{}
+	if params.AccessToken == "" {
+		return emptyObj, ErrAccessTokenInvalid
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithIssuer(a.accessTokenConfig.Issuer),
+		jwt.WithExpirationRequired(),
+	}
+	if a.accessTokenConfig.Audience != "" {
+		options = append(options, jwt.WithAudience(a.accessTokenConfig.Audience))
+	}
+
+	claims := &AccessTokenClaims{}
+	_, err := jwt.ParseWithClaims(params.AccessToken, claims, a.verificationKeyOf, options...)
+	if err != nil {
+		return emptyObj, ErrAccessTokenInvalid
+	}
+
+	accountId, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return emptyObj, ErrAccessTokenInvalid
+	}
+
+	return VerifyAccessTokenOutput{
+		AccountId: accountId,
+		Username:  claims.Username,
+	}, nil
+}
+
+// Picks the public key the token names, refusing any other algorithm than
+// the one the key signs with.
+func (a accessToken) verificationKeyOf(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	for _, key := range a.keys {
+		if key.id != kid {
+			continue
+		}
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, errors.New("unexpected signing method")
+		}
+		return key.privateKey.Public(), nil
+	}
+	return nil, errors.New("unknown signing key")
+}
+
+func (a accessToken) GetJWKS(_ context.Context) (GetJWKSOutput, error) {
+	keys := make([]JSONWebKey, 0, len(a.keys))
+	for _, key := range a.keys {
+		jwk := JSONWebKey{
+			Kid: key.id,
+			Use: "sig",
+			Alg: key.method.Alg(),
+		}
+		switch publicKey := key.privateKey.Public().(type) {
+		case *ecdsa.PublicKey:
+			size := (publicKey.Curve.Params().BitSize + 7) / 8
+			jwk.Kty = "EC"
+			jwk.Crv = publicKey.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, size)))
+			jwk.Y = base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, size)))
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		default:
+			return GetJWKSOutput{}, status.Error(codes.Internal, "unsupported signing key type")
+		}
+		keys = append(keys, jwk)
+	}
+
+	return GetJWKSOutput{
+		Keys: keys,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package logic
+
+import "time"
+
+type IssueAccessTokenParams struct {
+	AccountId uint64
+	Username  string
+	Role      Role
+}
+
+type IssueAccessTokenOutput struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+type VerifyAccessTokenParams struct {
+	AccessToken string
+}
+
+type VerifyAccessTokenOutput struct {
+	AccountId uint64
+	Username  string
+}
+
+type JSONWebKey struct {
+	Kty string
+	Kid string
+	Use string
+	Alg string
+	Crv string
+	X   string
+	Y   string
+	N   string
+	E   string
+}
+
+type GetJWKSOutput struct {
+	Keys []JSONWebKey
+}
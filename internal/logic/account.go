@@ -2,10 +2,21 @@ package logic
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/Fiagram/account_service/internal/configs"
 	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/dataaccess/notifier"
+	"github.com/Fiagram/account_service/internal/utils"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -18,37 +29,135 @@ type Account interface {
 	IsUsernameTaken(ctx context.Context, params IsUsernameTakenParams) (IsUsernameTakenOutput, error)
 
 	GetAccount(ctx context.Context, params GetAccountParams) (GetAccountOutput, error)
+	// Deprecated: Use ListAccounts, which pages the accounts.
 	GetAccountAll(ctx context.Context, params GetAccountAllParams) (GetAccountAllOutput, error)
+	ListAccounts(ctx context.Context, params ListAccountsParams) (ListAccountsOutput, error)
+	// Calls send with the accounts one at a time, without holding the
+	// listing in memory. Stops at the first error of send and returns it.
+	StreamAccounts(ctx context.Context, params StreamAccountsParams, send func(StreamAccountsOutput) error) error
+	SearchAccounts(ctx context.Context, params SearchAccountsParams) (SearchAccountsOutput, error)
+	// Creates the accounts of the rows in batches, one transaction each.
+	// Rows that cannot be imported are reported, not failing the others.
+	// Batches created before a failure to read the rows stay created.
+	ImportAccounts(ctx context.Context, params ImportAccountsParams) (ImportAccountsOutput, error)
 	GetAccountList(ctx context.Context, params GetAccountListParams) (GetAccountListOutput, error)
 
 	UpdateAccountInfo(ctx context.Context, params UpdateAccountInfoParams) (UpdateAccountInfoOutput, error)
 	UpdateAccountPassword(ctx context.Context, params UpdateAccountPasswordParams) (UpdateAccountPasswordOutput, error)
+	// Lets an account holder replace their own password. Signs the account out
+	// everywhere else by revoking its refresh tokens.
+	ChangePassword(ctx context.Context, params ChangePasswordParams) (ChangePasswordOutput, error)
 
+	RequestPasswordReset(ctx context.Context, params RequestPasswordResetParams) error
+	ConfirmPasswordReset(ctx context.Context, params ConfirmPasswordResetParams) (ConfirmPasswordResetOutput, error)
+
+	// Each moves the account to its status, provided the current status allows
+	// it, and records the reason and the actor. Leaving the active status signs
+	// the account out everywhere by revoking its refresh tokens.
+	SuspendAccount(ctx context.Context, params ChangeAccountStatusParams) (ChangeAccountStatusOutput, error)
+	ReactivateAccount(ctx context.Context, params ChangeAccountStatusParams) (ChangeAccountStatusOutput, error)
+	DeactivateAccount(ctx context.Context, params ChangeAccountStatusParams) (ChangeAccountStatusOutput, error)
+
+	// Deletes softly, the account can be restored until the retention period
+	// is over.
 	DeleteAccount(ctx context.Context, params DeleteAccountParams) error
 	DeleteAccountByUsername(ctx context.Context, params DeleteAccountByUsernameParams) error
+	RestoreAccount(ctx context.Context, params RestoreAccountParams) (RestoreAccountOutput, error)
+	// Removes for good a batch of the accounts deleted longer than the
+	// retention period ago, along with their dependent rows.
+	PurgeDeletedAccounts(ctx context.Context, params PurgeDeletedAccountsParams) (PurgeDeletedAccountsOutput, error)
 }
 
 type account struct {
-	db                      *sql.DB
-	accountAccessor         database.AccountAccessor
-	accountPasswordAccessor database.AccountPasswordAccessor
-	hashLogic               Hash
-	logger                  *zap.Logger
-}
-
-func NewAccount(
-	db *sql.DB,
-	accountAccessor database.AccountAccessor,
-	accountPasswordAccessor database.AccountPasswordAccessor,
-	hashLogic Hash,
-	logger *zap.Logger,
-) Account {
+	db                             *sql.DB
+	accountAccessor                database.AccountAccessor
+	accountPasswordAccessor        database.AccountPasswordAccessor
+	accountPasswordHistoryAccessor database.AccountPasswordHistoryAccessor
+	refreshTokenAccessor           database.RefreshTokenAccessor
+	passwordResetTokenAccessor     database.PasswordResetTokenAccessor
+	verificationCodeAccessor       database.VerificationCodeAccessor
+	accountTOTPAccessor            database.AccountTOTPAccessor
+	loginChallengeAccessor         database.LoginChallengeAccessor
+	recoveryCodeAccessor           database.RecoveryCodeAccessor
+	webAuthnCredentialAccessor     database.WebAuthnCredentialAccessor
+	webAuthnSessionAccessor        database.WebAuthnSessionAccessor
+	accountStatusChangeAccessor    database.AccountStatusChangeAccessor
+	notifier                       notifier.Notifier
+	hashLogic                      Hash
+	loginThrottleLogic             LoginThrottle
+	passwordPolicyLogic            PasswordPolicy
+	secondFactorLogic              SecondFactor
+	passwordHistoryConfig          configs.PasswordHistory
+	passwordResetConfig            configs.PasswordReset
+	phoneNumberConfig              configs.PhoneNumber
+	accountDeletionConfig          configs.AccountDeletion
+	logger                         *zap.Logger
+
+	// Verified against when the username does not exist, so an unknown
+	// username costs as much as a wrong password.
+	dummyHashedString func() (string, error)
+}
+
+// The dependencies of the account logic. Those an account logic is built
+// without are left nil, and the methods needing them must not be called.
+type AccountDependencies struct {
+	DB                             *sql.DB
+	AccountAccessor                database.AccountAccessor
+	AccountPasswordAccessor        database.AccountPasswordAccessor
+	AccountPasswordHistoryAccessor database.AccountPasswordHistoryAccessor
+	RefreshTokenAccessor           database.RefreshTokenAccessor
+	PasswordResetTokenAccessor     database.PasswordResetTokenAccessor
+	VerificationCodeAccessor       database.VerificationCodeAccessor
+	AccountTOTPAccessor            database.AccountTOTPAccessor
+	LoginChallengeAccessor         database.LoginChallengeAccessor
+	RecoveryCodeAccessor           database.RecoveryCodeAccessor
+	WebAuthnCredentialAccessor     database.WebAuthnCredentialAccessor
+	WebAuthnSessionAccessor        database.WebAuthnSessionAccessor
+	AccountStatusChangeAccessor    database.AccountStatusChangeAccessor
+	Notifier                       notifier.Notifier
+	HashLogic                      Hash
+	LoginThrottleLogic             LoginThrottle
+	PasswordPolicyLogic            PasswordPolicy
+	SecondFactorLogic              SecondFactor
+	PasswordHistoryConfig          configs.PasswordHistory
+	PasswordResetConfig            configs.PasswordReset
+	PhoneNumberConfig              configs.PhoneNumber
+	AccountDeletionConfig          configs.AccountDeletion
+	Logger                         *zap.Logger
+}
+
+func NewAccount(deps AccountDependencies) Account {
 	return &account{
-		db:                      db,
-		accountAccessor:         accountAccessor,
-		accountPasswordAccessor: accountPasswordAccessor,
-		hashLogic:               hashLogic,
-		logger:                  logger,
+		db:                             deps.DB,
+		accountAccessor:                deps.AccountAccessor,
+		accountPasswordAccessor:        deps.AccountPasswordAccessor,
+		accountPasswordHistoryAccessor: deps.AccountPasswordHistoryAccessor,
+		refreshTokenAccessor:           deps.RefreshTokenAccessor,
+		passwordResetTokenAccessor:     deps.PasswordResetTokenAccessor,
+		verificationCodeAccessor:       deps.VerificationCodeAccessor,
+		accountTOTPAccessor:            deps.AccountTOTPAccessor,
+		loginChallengeAccessor:         deps.LoginChallengeAccessor,
+		recoveryCodeAccessor:           deps.RecoveryCodeAccessor,
+		webAuthnCredentialAccessor:     deps.WebAuthnCredentialAccessor,
+		webAuthnSessionAccessor:        deps.WebAuthnSessionAccessor,
+		accountStatusChangeAccessor:    deps.AccountStatusChangeAccessor,
+		notifier:                       deps.Notifier,
+		hashLogic:                      deps.HashLogic,
+		loginThrottleLogic:             deps.LoginThrottleLogic,
+		passwordPolicyLogic:            deps.PasswordPolicyLogic,
+		secondFactorLogic:              deps.SecondFactorLogic,
+		passwordHistoryConfig:          deps.PasswordHistoryConfig,
+		passwordResetConfig:            deps.PasswordResetConfig,
+		phoneNumberConfig:              deps.PhoneNumberConfig,
+		accountDeletionConfig:          deps.AccountDeletionConfig,
+		logger:                         deps.Logger,
+		dummyHashedString: sync.OnceValues(func() (string, error) {
+			dummyPassword, err := generateOpaqueToken(refreshTokenByteLength)
+			if err != nil {
+				return "", err
+			}
+			return deps.HashLogic.Hash(context.Background(), dummyPassword)
+		}),
 	}
 }
 
@@ -57,6 +166,27 @@ func (a account) CreateAccount(
 	params CreateAccountParams,
 ) (CreateAccountOutput, error) {
 	emptyOutput := CreateAccountOutput{}
+	err := a.passwordPolicyLogic.ValidatePassword(ctx, ValidatePasswordParams{
+		Password: params.Password,
+		Username: params.AccountInfo.Username,
+		Fullname: params.AccountInfo.Fullname,
+		Email:    params.AccountInfo.Email,
+	})
+	if err != nil {
+		return emptyOutput, err
+	}
+
+	phoneNumber, err := normalizePhoneNumber(params.AccountInfo.PhoneNumber,
+		a.phoneNumberConfig.DefaultCountryCode)
+	if err != nil {
+		return emptyOutput, err
+	}
+
+	accountStatus, err := accountStatusOnCreation(params.AccountInfo.Status)
+	if err != nil {
+		return emptyOutput, err
+	}
+
 	isUsernameTaken, err := a.accountAccessor.IsUsernameTaken(ctx, params.AccountInfo.Username)
 	if err != nil {
 		return emptyOutput, status.Error(codes.Internal, "failed to check if username taken")
@@ -76,8 +206,9 @@ func (a account) CreateAccount(
 			Username:    params.AccountInfo.Username,
 			Fullname:    params.AccountInfo.Fullname,
 			Email:       params.AccountInfo.Email,
-			PhoneNumber: params.AccountInfo.PhoneNumber,
+			PhoneNumber: phoneNumber,
 			RoleId:      uint8(params.AccountInfo.Role),
+			StatusId:    uint8(accountStatus),
 		})
 	if err != nil {
 		return emptyOutput, status.Error(codes.Internal, "failed to create new account")
@@ -98,6 +229,10 @@ func (a account) CreateAccount(
 		return emptyOutput, status.Error(codes.Internal, "failed to create new password")
 	}
 
+	if err = a.recordPasswordHistory(ctx, tx, id, hashedString); err != nil {
+		return emptyOutput, err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return emptyOutput, ErrTxCommitFailed
 	}
@@ -107,9 +242,41 @@ func (a account) CreateAccount(
 	}, nil
 }
 
+// New accounts are active unless asked to wait for an activation.
+func accountStatusOnCreation(accountStatus AccountStatus) (AccountStatus, error) {
+	switch accountStatus {
+	case AccountStatusNone:
+		return AccountStatusActive, nil
+	case AccountStatusPending, AccountStatusActive:
+		return accountStatus, nil
+	default:
+		return AccountStatusNone, ErrAccountStatusOnCreationInvalid
+	}
+}
+
 func (a account) DeleteAccount(
 	ctx context.Context,
 	params DeleteAccountParams,
+) error {
+	return a.softDeleteAccount(ctx, params.AccountId)
+}
+
+func (a account) DeleteAccountByUsername(
+	ctx context.Context,
+	params DeleteAccountByUsernameParams,
+) error {
+	acc, err := a.accountAccessor.GetAccountByUsername(ctx, params.Username)
+	if err != nil {
+		return status.Error(codes.NotFound, "username does not existed")
+	}
+	return a.softDeleteAccount(ctx, acc.Id)
+}
+
+// Hides the account until it is restored or purged, and signs it out
+// everywhere.
+func (a account) softDeleteAccount(
+	ctx context.Context,
+	accountId uint64,
 ) error {
 	tx, err := a.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
@@ -117,17 +284,17 @@ func (a account) DeleteAccount(
 	}
 	defer tx.Rollback()
 
-	err = a.accountPasswordAccessor.
+	err = a.accountAccessor.
 		WithExecutor(tx).
-		DeleteAccountPassword(ctx, params.AccountId)
+		SoftDeleteAccount(ctx, accountId, time.Now())
 	if err != nil {
-		return status.Error(codes.Internal, "failed to delete password")
+		return status.Error(codes.NotFound, "account not found")
 	}
-	err = a.accountAccessor.
+	err = a.refreshTokenAccessor.
 		WithExecutor(tx).
-		DeleteAccount(ctx, params.AccountId)
+		RevokeRefreshTokenOfAccount(ctx, accountId, time.Now())
 	if err != nil {
-		return status.Error(codes.Internal, "failed to delete account")
+		return status.Error(codes.Internal, "failed to revoke refresh tokens")
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -137,39 +304,152 @@ func (a account) DeleteAccount(
 	return nil
 }
 
-func (a account) DeleteAccountByUsername(
+func (a account) RestoreAccount(
 	ctx context.Context,
-	params DeleteAccountByUsernameParams,
-) error {
-	isExisted, err := a.accountAccessor.IsUsernameTaken(ctx, params.Username)
+	params RestoreAccountParams,
+) (RestoreAccountOutput, error) {
+	emptyObj := RestoreAccountOutput{}
+	deletedAfter := time.Now().Add(-a.accountDeletionConfig.RetentionPeriod)
+	err := a.accountAccessor.RestoreAccount(ctx, params.AccountId, deletedAfter)
 	if err != nil {
-		return status.Error(codes.Internal, "failed to check if username existed")
-	} else if !isExisted {
-		return status.Error(codes.NotFound, "username does not existed")
+		return emptyObj, ErrAccountNotRestorable
 	}
 
+	return RestoreAccountOutput{
+		AccountId: params.AccountId,
+	}, nil
+}
+
+// Used when no batch size is configured.
+const defaultPurgeBatchSize = 100
+
+func (a account) PurgeDeletedAccounts(
+	ctx context.Context,
+	params PurgeDeletedAccountsParams,
+) (PurgeDeletedAccountsOutput, error) {
+	emptyObj := PurgeDeletedAccountsOutput{}
+	logger := utils.LoggerWithContext(ctx, a.logger)
+	deletedBefore := time.Now().Add(-a.accountDeletionConfig.RetentionPeriod)
+	batchSize := a.accountDeletionConfig.PurgeBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPurgeBatchSize
+	}
+
+	// Read past the last id of every batch, so accounts failing to purge do
+	// not hold back the ones after them
+	purgedAccountIds := make([]uint64, 0)
+	var afterId uint64
+	for {
+		ids, err := a.accountAccessor.GetDeletedAccountIdList(ctx, deletedBefore, afterId, batchSize)
+		if err != nil {
+			return emptyObj, status.Error(codes.Internal, "failed to get deleted accounts")
+		}
+
+		for _, id := range ids {
+			if err := a.purgeAccount(ctx, id, deletedBefore); err != nil {
+				logger.With(zap.Any("account_id", id)).
+					With(zap.Error(err)).
+					Warn("failed to purge account")
+				continue
+			}
+			purgedAccountIds = append(purgedAccountIds, id)
+		}
+
+		if len(ids) < batchSize {
+			break
+		}
+		afterId = ids[len(ids)-1]
+	}
+
+	return PurgeDeletedAccountsOutput{
+		PurgedAccountIds: purgedAccountIds,
+	}, nil
+}
+
+// Removes the account and every row depending on it in one transaction, so
+// an account restored in the meantime is left whole.
+func (a account) purgeAccount(
+	ctx context.Context,
+	accountId uint64,
+	deletedBefore time.Time,
+) error {
 	tx, err := a.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
 		return ErrTxBeginFailed
 	}
 	defer tx.Rollback()
-	acc, err := a.accountAccessor.
+
+	err = a.refreshTokenAccessor.
 		WithExecutor(tx).
-		GetAccountByUsername(ctx, params.Username)
+		DeleteRefreshTokenOfAccount(ctx, accountId)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to delete refresh tokens")
+	}
+	err = a.verificationCodeAccessor.
+		WithExecutor(tx).
+		DeleteVerificationCodeOfAccount(ctx, accountId)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to delete verification codes")
+	}
+	err = a.loginChallengeAccessor.
+		WithExecutor(tx).
+		DeleteLoginChallengeOfAccount(ctx, accountId)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to delete login challenges")
+	}
+	err = a.webAuthnSessionAccessor.
+		WithExecutor(tx).
+		DeleteWebAuthnSessionOfAccount(ctx, accountId)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to delete webauthn sessions")
+	}
+	err = a.webAuthnCredentialAccessor.
+		WithExecutor(tx).
+		DeleteWebAuthnCredentialOfAccount(ctx, accountId)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to delete webauthn credentials")
+	}
+	err = a.recoveryCodeAccessor.
+		WithExecutor(tx).
+		DeleteRecoveryCodeOfAccount(ctx, accountId)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to delete recovery codes")
+	}
+	err = a.accountTOTPAccessor.
+		WithExecutor(tx).
+		DeleteAccountTOTP(ctx, accountId)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to delete totp")
+	}
+	err = a.passwordResetTokenAccessor.
+		WithExecutor(tx).
+		DeletePasswordResetTokenOfAccount(ctx, accountId)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to delete password reset tokens")
+	}
+	err = a.accountStatusChangeAccessor.
+		WithExecutor(tx).
+		DeleteAccountStatusChangeOfAccount(ctx, accountId)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to delete account status changes")
+	}
+	err = a.accountPasswordHistoryAccessor.
+		WithExecutor(tx).
+		DeleteAccountPasswordHistoryOfAccount(ctx, accountId)
 	if err != nil {
-		return status.Error(codes.Internal, "failed to get account")
+		return status.Error(codes.Internal, "failed to delete password history")
 	}
 	err = a.accountPasswordAccessor.
 		WithExecutor(tx).
-		DeleteAccountPassword(ctx, acc.Id)
+		DeleteAccountPassword(ctx, accountId)
 	if err != nil {
 		return status.Error(codes.Internal, "failed to delete password")
 	}
 	err = a.accountAccessor.
 		WithExecutor(tx).
-		DeleteAccount(ctx, acc.Id)
+		PurgeAccount(ctx, accountId, deletedBefore)
 	if err != nil {
-		return status.Error(codes.Internal, "failed to delete account")
+		return status.Error(codes.Internal, "failed to purge account")
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -184,16 +464,37 @@ func (a account) CheckAccountValid(
 	params CheckAccountValidParams,
 ) (CheckAccountValidOutput, error) {
 	emptyObj := CheckAccountValidOutput{}
+	logger := utils.LoggerWithContext(ctx, a.logger)
+	attempt := LoginAttemptParams{
+		Username: params.Username,
+		Address:  params.Address,
+	}
+	if err := a.loginThrottleLogic.CheckLoginAllowed(ctx, attempt); err != nil {
+		return emptyObj, err
+	}
+
+	recordFailure := func() {
+		if err := a.loginThrottleLogic.RecordLoginFailure(ctx, attempt); err != nil {
+			logger.With(zap.Error(err)).Warn("failed to record login failure")
+		}
+	}
+
+	// Every rejection below answers with the same error after the same amount
+	// of hashing work, so the response tells nothing about which part was wrong.
 	acc, err := a.accountAccessor.
 		GetAccountByUsername(ctx, params.Username)
 	if err != nil {
-		return emptyObj, status.Error(codes.NotFound, "failed to get account")
+		a.verifyDummyPassword(ctx, params.Password)
+		recordFailure()
+		return emptyObj, ErrInvalidCredentials
 	}
 
 	truly, err := a.accountPasswordAccessor.
 		GetAccountPassword(ctx, acc.Id)
 	if err != nil {
-		return emptyObj, status.Error(codes.NotFound, "failed to get password")
+		a.verifyDummyPassword(ctx, params.Password)
+		recordFailure()
+		return emptyObj, ErrInvalidCredentials
 	}
 
 	isValid, err := a.hashLogic.
@@ -203,13 +504,90 @@ func (a account) CheckAccountValid(
 	}
 
 	if !isValid {
-		return emptyObj, nil
+		recordFailure()
+		return emptyObj, ErrInvalidCredentials
+	}
+
+	// Only told once the password is right, so the status of an account does
+	// not leak to someone who cannot log in to it anyway.
+	if err := checkAccountStatus(acc); err != nil {
+		return emptyObj, err
+	}
+
+	if a.hashLogic.NeedsRehash(ctx, truly.HashedString) {
+		a.rehashAccountPassword(ctx, acc.Id, params.Password, truly.HashedString)
 	}
+
+	// The login failures are cleared once the second factor is passed too.
+	challenge, err := a.secondFactorLogic.BeginSecondFactorChallenge(ctx,
+		BeginSecondFactorChallengeParams{
+			AccountId: acc.Id,
+		})
+	if err != nil {
+		return emptyObj, err
+	}
+	if challenge.IsRequired {
+		return emptyObj, secondFactorRequiredError(challenge)
+	}
+
+	if err := a.loginThrottleLogic.RecordLoginSuccess(ctx, attempt); err != nil {
+		logger.With(zap.Error(err)).Warn("failed to clear login failures")
+	}
+
 	return CheckAccountValidOutput{
 		AccountId: acc.Id,
 	}, nil
 }
 
+func (a account) verifyDummyPassword(
+	ctx context.Context,
+	password string,
+) {
+	dummy, err := a.dummyHashedString()
+	if err != nil {
+		utils.LoggerWithContext(ctx, a.logger).
+			With(zap.Error(err)).
+			Warn("failed to prepare the dummy password hash")
+		return
+	}
+	// The result is irrelevant, only the time spent matters.
+	_, _ = a.hashLogic.IsHashEqual(ctx, password, dummy)
+}
+
+// Upgrades a stored hash to the configured algorithm and parameters while the
+// plaintext is at hand. A failure only leaves the old hash in place, so it
+// does not fail the login. The verified hash is only replaced while still
+// stored, so a password changed since the login checked it is kept.
+func (a account) rehashAccountPassword(
+	ctx context.Context,
+	accountId uint64,
+	password string,
+	verifiedHashedString string,
+) {
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", accountId))
+	hashedString, err := a.hashLogic.Hash(ctx, password)
+	if err != nil {
+		logger.With(zap.Error(err)).Warn("failed to rehash password")
+		return
+	}
+
+	isUpdated, err := a.accountPasswordAccessor.
+		UpdateAccountPasswordIfUnchanged(ctx, database.AccountPassword{
+			OfAccountId:  accountId,
+			HashedString: hashedString,
+		}, verifiedHashedString)
+	if err != nil {
+		logger.With(zap.Error(err)).Warn("failed to save rehashed password")
+		return
+	}
+	if !isUpdated {
+		logger.Info("password changed since it was verified, left as it is")
+		return
+	}
+
+	logger.Info("rehashed password with the current hash configuration")
+}
+
 func (a account) IsUsernameTaken(
 	ctx context.Context,
 	params IsUsernameTakenParams,
@@ -242,7 +620,10 @@ func (a account) GetAccount(
 			Email:       acc.Email,
 			PhoneNumber: acc.PhoneNumber,
 			Role:        Role(acc.RoleId),
+			Status:      AccountStatus(acc.StatusId),
 		},
+		EmailVerifiedAt: acc.EmailVerifiedAt,
+		PhoneVerifiedAt: acc.PhoneVerifiedAt,
 	}, nil
 }
 
@@ -267,6 +648,7 @@ func (a account) GetAccountAll(
 			Email:       acc.Email,
 			PhoneNumber: acc.PhoneNumber,
 			Role:        Role(acc.RoleId),
+			Status:      AccountStatus(acc.StatusId),
 		})
 	}
 
@@ -276,6 +658,185 @@ func (a account) GetAccountAll(
 	}, nil
 }
 
+const (
+	defaultAccountPageSize = 50
+	maxAccountPageSize     = 1000
+)
+
+// The opaque page token of ListAccounts and SearchAccounts, before encoding.
+type accountPageToken struct {
+	// Set by ListAccounts, which pages on the id.
+	AfterId uint64 `json:"after_id,omitempty"`
+	// Set by SearchAccounts, whose ranked results are paged by offset.
+	Offset int `json:"offset,omitempty"`
+	// Ties the token to the listing it pages, so it is not applied to
+	// another one by mistake.
+	ListingHash string `json:"listing_hash"`
+}
+
+func accountListingHash(params ListAccountsParams) string {
+	var role, accountStatus string
+	if params.Role != nil {
+		role = params.Role.String()
+	}
+	if params.Status != nil {
+		accountStatus = params.Status.String()
+	}
+	return hashListing(fmt.Sprintf("list|%s|%s|%d|%d|%q|%d", role, accountStatus,
+		params.CreatedAfter.UnixNano(), params.CreatedBefore.UnixNano(),
+		params.UsernamePrefix, params.Order))
+}
+
+func accountListQueryOf(filter AccountFilter, order AccountOrder) database.AccountListQuery {
+	query := database.AccountListQuery{
+		CreatedAfter:   filter.CreatedAfter,
+		CreatedBefore:  filter.CreatedBefore,
+		UsernamePrefix: filter.UsernamePrefix,
+		IsDescending:   order == AccountOrderIdDescending,
+	}
+	if filter.Role != nil {
+		roleId := uint8(*filter.Role)
+		query.RoleId = &roleId
+	}
+	if filter.Status != nil {
+		statusId := uint8(*filter.Status)
+		query.StatusId = &statusId
+	}
+	return query
+}
+
+// Defaults and caps the requested page size.
+func accountPageSize(pageSize int) (int, error) {
+	switch {
+	case pageSize < 0:
+		return 0, ErrPageSizeInvalid
+	case pageSize == 0:
+		return defaultAccountPageSize, nil
+	case pageSize > maxAccountPageSize:
+		return maxAccountPageSize, nil
+	}
+	return pageSize, nil
+}
+
+func hashListing(listing string) string {
+	sum := sha256.Sum256([]byte(listing))
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
+
+func encodeAccountPageToken(token accountPageToken) (string, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeAccountPageToken(encoded string) (accountPageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return accountPageToken{}, err
+	}
+	var token accountPageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return accountPageToken{}, err
+	}
+	return token, nil
+}
+
+func (a account) ListAccounts(
+	ctx context.Context,
+	params ListAccountsParams,
+) (ListAccountsOutput, error) {
+	emptyObj := ListAccountsOutput{}
+	pageSize, err := accountPageSize(params.PageSize)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	listingHash := accountListingHash(params)
+	query := accountListQueryOf(params.AccountFilter, params.Order)
+	// One more than the page tells whether another page follows
+	query.Limit = pageSize + 1
+	if params.PageToken != "" {
+		token, err := decodeAccountPageToken(params.PageToken)
+		if err != nil || token.ListingHash != listingHash {
+			return emptyObj, ErrPageTokenInvalid
+		}
+		query.AfterId = token.AfterId
+	}
+
+	accs, err := a.accountAccessor.ListAccounts(ctx, query)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to list accounts")
+	}
+
+	var nextPageToken string
+	if len(accs) > pageSize {
+		accs = accs[:pageSize]
+		nextPageToken, err = encodeAccountPageToken(accountPageToken{
+			AfterId:     accs[pageSize-1].Id,
+			ListingHash: listingHash,
+		})
+		if err != nil {
+			return emptyObj, status.Error(codes.Internal, "failed to encode page token")
+		}
+	}
+
+	accountIds := make([]uint64, 0, len(accs))
+	accountInfos := make([]AccountInfo, 0, len(accs))
+
+	for _, acc := range accs {
+		accountIds = append(accountIds, acc.Id)
+		accountInfos = append(accountInfos, AccountInfo{
+			Username:    acc.Username,
+			Fullname:    acc.Fullname,
+			Email:       acc.Email,
+			PhoneNumber: acc.PhoneNumber,
+			Role:        Role(acc.RoleId),
+			Status:      AccountStatus(acc.StatusId),
+		})
+	}
+
+	return ListAccountsOutput{
+		AccountIds:    accountIds,
+		AccountInfos:  accountInfos,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (a account) StreamAccounts(
+	ctx context.Context,
+	params StreamAccountsParams,
+	send func(StreamAccountsOutput) error,
+) error {
+	// Tells the errors of send apart from the ones of the database
+	var sendErr error
+	err := a.accountAccessor.StreamAccounts(ctx,
+		accountListQueryOf(params.AccountFilter, params.Order),
+		func(acc database.Account) error {
+			sendErr = send(StreamAccountsOutput{
+				AccountId: acc.Id,
+				AccountInfo: AccountInfo{
+					Username:    acc.Username,
+					Fullname:    acc.Fullname,
+					Email:       acc.Email,
+					PhoneNumber: acc.PhoneNumber,
+					Role:        Role(acc.RoleId),
+					Status:      AccountStatus(acc.StatusId),
+				},
+			})
+			return sendErr
+		})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return status.Error(codes.Internal, "failed to stream accounts")
+	}
+
+	return nil
+}
+
 func (a account) GetAccountList(
 	ctx context.Context,
 	params GetAccountListParams,
@@ -297,6 +858,7 @@ func (a account) GetAccountList(
 			Email:       acc.Email,
 			PhoneNumber: acc.PhoneNumber,
 			Role:        Role(acc.RoleId),
+			Status:      AccountStatus(acc.StatusId),
 		})
 	}
 
@@ -311,6 +873,11 @@ func (a account) UpdateAccountInfo(
 	params UpdateAccountInfoParams,
 ) (UpdateAccountInfoOutput, error) {
 	emptyObj := UpdateAccountInfoOutput{}
+	phoneNumber, err := normalizePhoneNumber(params.UpdatedAccountInfo.PhoneNumber,
+		a.phoneNumberConfig.DefaultCountryCode)
+	if err != nil {
+		return emptyObj, err
+	}
 
 	tx, err := a.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
@@ -327,8 +894,15 @@ func (a account) UpdateAccountInfo(
 
 	// Update fields
 	acc.Fullname = params.UpdatedAccountInfo.Fullname
+	if strings.TrimSpace(params.UpdatedAccountInfo.Email) != acc.Email {
+		// The new address has to be verified on its own
+		acc.EmailVerifiedAt = nil
+	}
 	acc.Email = params.UpdatedAccountInfo.Email
-	acc.PhoneNumber = params.UpdatedAccountInfo.PhoneNumber
+	if phoneNumber != acc.PhoneNumber {
+		acc.PhoneVerifiedAt = nil
+	}
+	acc.PhoneNumber = phoneNumber
 	acc.RoleId = uint8(params.UpdatedAccountInfo.Role)
 	// Username usually shouldn't be updated, or special care taken if allowed.
 	// Assuming params.UpdatedAccountInfo doesn't carry ID/Username for update target, but here we updating 'acc' found by ID.
@@ -358,21 +932,372 @@ func (a account) UpdateAccountPassword(
 ) (UpdateAccountPasswordOutput, error) {
 	emptyObj := UpdateAccountPasswordOutput{}
 
-	hashedString, err := a.hashLogic.Hash(ctx, params.Password)
+	acc, err := a.accountAccessor.GetAccount(ctx, params.AccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.NotFound, "account not found")
+	}
+
+	err = a.passwordPolicyLogic.ValidatePassword(ctx, ValidatePasswordParams{
+		Password: params.Password,
+		Username: acc.Username,
+		Fullname: acc.Fullname,
+		Email:    acc.Email,
+	})
+	if err != nil {
+		return emptyObj, err
+	}
+
+	tx, err := a.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return emptyObj, ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	if err = a.replaceAccountPassword(ctx, tx, acc.Id, params.Password); err != nil {
+		return emptyObj, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return emptyObj, ErrTxCommitFailed
+	}
+
+	return UpdateAccountPasswordOutput{
+		AccountId: params.AccountId,
+	}, nil
+}
+
+func (a account) ChangePassword(
+	ctx context.Context,
+	params ChangePasswordParams,
+) (ChangePasswordOutput, error) {
+	emptyObj := ChangePasswordOutput{}
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.Any("of_account_id", params.AccountId))
+
+	tx, err := a.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return emptyObj, ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	acc, err := a.accountAccessor.
+		WithExecutor(tx).
+		GetAccount(ctx, params.AccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.NotFound, "account not found")
+	}
+
+	// Guessing the current password here is as good as guessing it at login,
+	// so the attempts share the login throttle.
+	attempt := LoginAttemptParams{
+		Username: acc.Username,
+		Address:  params.Address,
+	}
+	if err := a.loginThrottleLogic.CheckLoginAllowed(ctx, attempt); err != nil {
+		return emptyObj, err
+	}
+
+	truly, err := a.accountPasswordAccessor.
+		WithExecutor(tx).
+		GetAccountPassword(ctx, acc.Id)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to get account password")
+	}
+
+	isValid, err := a.hashLogic.
+		IsHashEqual(ctx, params.CurrentPassword, truly.HashedString)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to when equal hashed")
+	}
+	if !isValid {
+		if err := a.loginThrottleLogic.RecordLoginFailure(ctx, attempt); err != nil {
+			logger.With(zap.Error(err)).Warn("failed to record login failure")
+		}
+		return emptyObj, ErrCurrentPasswordIncorrect
+	}
+
+	err = a.passwordPolicyLogic.ValidatePassword(ctx, ValidatePasswordParams{
+		Password: params.NewPassword,
+		Username: acc.Username,
+		Fullname: acc.Fullname,
+		Email:    acc.Email,
+	})
+	if err != nil {
+		return emptyObj, err
+	}
+
+	if err = a.replaceAccountPassword(ctx, tx, acc.Id, params.NewPassword); err != nil {
+		return emptyObj, err
+	}
+
+	err = a.refreshTokenAccessor.
+		WithExecutor(tx).
+		RevokeRefreshTokenOfAccount(ctx, acc.Id, time.Now())
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to revoke refresh tokens")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return emptyObj, ErrTxCommitFailed
+	}
+
+	if err := a.loginThrottleLogic.RecordLoginSuccess(ctx, attempt); err != nil {
+		logger.With(zap.Error(err)).Warn("failed to clear login failures")
+	}
+
+	return ChangePasswordOutput{
+		AccountId: acc.Id,
+	}, nil
+}
+
+// Stores a new password for the account, rejecting it when it matches the
+// current password or one kept in the history. The caller has validated it
+// against the password policy already.
+func (a account) replaceAccountPassword(
+	ctx context.Context,
+	tx *sql.Tx,
+	accountId uint64,
+	password string,
+) error {
+	isReused, err := a.isPasswordRecentlyUsed(ctx, tx, accountId, password)
+	if err != nil {
+		return err
+	}
+	if isReused {
+		return passwordViolationError(&errdetails.BadRequest_FieldViolation{
+			Field:  "password",
+			Reason: "PASSWORD_RECENTLY_USED",
+			Description: fmt.Sprintf("password must differ from the last %d passwords",
+				max(a.passwordHistoryConfig.Size, 1)),
+		})
+	}
+
+	hashedString, err := a.hashLogic.Hash(ctx, password)
 	if err != nil {
-		return emptyObj, status.Error(codes.Internal, "failed to hash password")
+		return status.Error(codes.Internal, "failed to hash password")
 	}
 
 	err = a.accountPasswordAccessor.
+		WithExecutor(tx).
 		UpdateAccountPassword(ctx, database.AccountPassword{
-			OfAccountId:  params.AccountId,
+			OfAccountId:  accountId,
 			HashedString: hashedString,
 		})
 	if err != nil {
-		return emptyObj, status.Error(codes.Internal, "failed to update account password")
+		return status.Error(codes.Internal, "failed to update account password")
 	}
 
-	return UpdateAccountPasswordOutput{
-		AccountId: params.AccountId,
+	return a.recordPasswordHistory(ctx, tx, accountId, hashedString)
+}
+
+// Compares the password against the current one, which predates the history
+// for older accounts, and against the entries of the history.
+func (a account) isPasswordRecentlyUsed(
+	ctx context.Context,
+	tx *sql.Tx,
+	accountId uint64,
+	password string,
+) (bool, error) {
+	current, err := a.accountPasswordAccessor.
+		WithExecutor(tx).
+		GetAccountPassword(ctx, accountId)
+	if err != nil {
+		return false, status.Error(codes.Internal, "failed to get account password")
+	}
+	hashedStrings := []string{current.HashedString}
+
+	if a.passwordHistoryConfig.Size > 0 {
+		history, err := a.accountPasswordHistoryAccessor.
+			WithExecutor(tx).
+			GetAccountPasswordHistoryList(ctx, accountId, a.passwordHistoryConfig.Size)
+		if err != nil {
+			return false, status.Error(codes.Internal, "failed to get password history")
+		}
+		for _, entry := range history {
+			if entry.HashedString != current.HashedString {
+				hashedStrings = append(hashedStrings, entry.HashedString)
+			}
+		}
+	}
+
+	for _, hashedString := range hashedStrings {
+		isEqual, err := a.hashLogic.IsHashEqual(ctx, password, hashedString)
+		if err != nil {
+			return false, err
+		}
+		if isEqual {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Appends the hash to the history and prunes the entries beyond the
+// configured size.
+func (a account) recordPasswordHistory(
+	ctx context.Context,
+	tx *sql.Tx,
+	accountId uint64,
+	hashedString string,
+) error {
+	if a.passwordHistoryConfig.Size <= 0 {
+		return nil
+	}
+
+	historyAccessor := a.accountPasswordHistoryAccessor.WithExecutor(tx)
+	err := historyAccessor.CreateAccountPasswordHistory(ctx, database.AccountPasswordHistory{
+		OfAccountId:  accountId,
+		HashedString: hashedString,
+	})
+	if err != nil {
+		return status.Error(codes.Internal, "failed to record password history")
+	}
+
+	kept, err := historyAccessor.GetAccountPasswordHistoryList(ctx, accountId, a.passwordHistoryConfig.Size)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to get password history")
+	}
+	if len(kept) < a.passwordHistoryConfig.Size {
+		return nil
+	}
+
+	err = historyAccessor.DeleteAccountPasswordHistoryBefore(ctx, accountId, kept[len(kept)-1].Id)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to prune password history")
+	}
+	return nil
+}
+
+// The statuses each status may move to. Deactivation is reachable from every
+// status so that any account can be closed.
+var accountStatusTransitions = map[AccountStatus][]AccountStatus{
+	AccountStatusPending:     {AccountStatusActive, AccountStatusDeactivated},
+	AccountStatusActive:      {AccountStatusSuspended, AccountStatusLocked, AccountStatusDeactivated},
+	AccountStatusSuspended:   {AccountStatusActive, AccountStatusDeactivated},
+	AccountStatusLocked:      {AccountStatusActive, AccountStatusSuspended, AccountStatusDeactivated},
+	AccountStatusDeactivated: {AccountStatusActive},
+}
+
+func canAccountStatusMove(from AccountStatus, to AccountStatus) bool {
+	for _, allowed := range accountStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the error telling why the account may not log in, or nil when it
+// is active.
+func checkAccountStatus(acc database.Account) error {
+	switch AccountStatus(acc.StatusId) {
+	case AccountStatusActive:
+		return nil
+	case AccountStatusPending:
+		return ErrAccountPending
+	case AccountStatusSuspended:
+		return ErrAccountSuspended
+	case AccountStatusLocked:
+		return ErrAccountLocked
+	case AccountStatusDeactivated:
+		return ErrAccountDeactivated
+	default:
+		return status.Error(codes.Internal, "account has an unknown status")
+	}
+}
+
+func (a account) changeAccountStatus(
+	ctx context.Context,
+	params ChangeAccountStatusParams,
+	to AccountStatus,
+) (ChangeAccountStatusOutput, error) {
+	emptyObj := ChangeAccountStatusOutput{}
+	if strings.TrimSpace(params.Reason) == "" {
+		return emptyObj, ErrAccountStatusReasonRequired
+	}
+
+	tx, err := a.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return emptyObj, ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	acc, err := a.accountAccessor.
+		WithExecutor(tx).
+		GetAccount(ctx, params.AccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.NotFound, "account not found")
+	}
+
+	from := AccountStatus(acc.StatusId)
+	if !canAccountStatusMove(from, to) {
+		return emptyObj, ErrAccountStatusTransitionInvalid
+	}
+
+	// Fails when a concurrent request changed the status first
+	err = a.accountAccessor.
+		WithExecutor(tx).
+		UpdateAccountStatus(ctx, acc.Id, uint8(from), uint8(to))
+	if err != nil {
+		return emptyObj, ErrAccountStatusTransitionInvalid
+	}
+
+	_, err = a.accountStatusChangeAccessor.
+		WithExecutor(tx).
+		CreateAccountStatusChange(ctx, database.AccountStatusChange{
+			OfAccountId:    acc.Id,
+			FromStatusId:   uint8(from),
+			ToStatusId:     uint8(to),
+			Reason:         params.Reason,
+			ActorAccountId: params.ActorAccountId,
+		})
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to record account status change")
+	}
+
+	if to != AccountStatusActive {
+		err = a.refreshTokenAccessor.
+			WithExecutor(tx).
+			RevokeRefreshTokenOfAccount(ctx, acc.Id, time.Now())
+		if err != nil {
+			return emptyObj, status.Error(codes.Internal, "failed to revoke refresh tokens")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return emptyObj, ErrTxCommitFailed
+	}
+
+	utils.LoggerWithContext(ctx, a.logger).
+		With(zap.Any("account_id", acc.Id)).
+		With(zap.Any("actor_account_id", params.ActorAccountId)).
+		With(zap.String("from", from.String())).
+		With(zap.String("to", to.String())).
+		With(zap.String("reason", params.Reason)).
+		Info("changed account status")
+
+	return ChangeAccountStatusOutput{
+		AccountId: acc.Id,
+		Status:    to,
 	}, nil
 }
+
+func (a account) SuspendAccount(
+	ctx context.Context,
+	params ChangeAccountStatusParams,
+) (ChangeAccountStatusOutput, error) {
+	return a.changeAccountStatus(ctx, params, AccountStatusSuspended)
+}
+
+func (a account) ReactivateAccount(
+	ctx context.Context,
+	params ChangeAccountStatusParams,
+) (ChangeAccountStatusOutput, error) {
+	return a.changeAccountStatus(ctx, params, AccountStatusActive)
+}
+
+func (a account) DeactivateAccount(
+	ctx context.Context,
+	params ChangeAccountStatusParams,
+) (ChangeAccountStatusOutput, error) {
+	return a.changeAccountStatus(ctx, params, AccountStatusDeactivated)
+}
@@ -0,0 +1,229 @@
+package logic
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The columns of an export when none are chosen, the hashed password aside.
+var defaultAccountExportColumns = []string{
+	accountColumnId,
+	accountColumnUsername,
+	accountColumnFullname,
+	accountColumnEmail,
+	accountColumnPhoneNumber,
+	accountColumnRole,
+	accountColumnStatus,
+	accountColumnCreatedAt,
+}
+
+// Writes the accounts of an export one at a time.
+type AccountExportWriter interface {
+	Write(exported ExportedAccount) error
+	// Flushes the buffered accounts, which must be done after the last one.
+	Flush() error
+}
+
+func NewAccountExportWriter(
+	w io.Writer,
+	format AccountFileFormat,
+	options AccountExportOptions,
+) (AccountExportWriter, error) {
+	columns, err := accountExportColumnsOf(options)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case AccountFileFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(columns); err != nil {
+			return nil, err
+		}
+		return &csvAccountExportWriter{
+			writer:  writer,
+			columns: columns,
+			maskPII: options.MaskPII,
+		}, nil
+	case AccountFileFormatJSONL:
+		return &jsonlAccountExportWriter{
+			writer:  bufio.NewWriter(w),
+			columns: columns,
+			maskPII: options.MaskPII,
+		}, nil
+	default:
+		return nil, ErrAccountFileFormatInvalid
+	}
+}
+
+func accountExportColumnsOf(options AccountExportOptions) ([]string, error) {
+	if len(options.Columns) == 0 {
+		columns := append([]string{}, defaultAccountExportColumns...)
+		if options.IncludePasswordHashes {
+			columns = append(columns, accountColumnHashedPassword)
+		}
+		return columns, nil
+	}
+
+	columns := make([]string, 0, len(options.Columns))
+	for _, column := range options.Columns {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if column == accountColumnHashedPassword {
+			if !options.IncludePasswordHashes {
+				return nil, ErrPasswordHashExportForbidden
+			}
+		} else if !isDefaultAccountExportColumn(column) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown export column %q", column)
+		}
+		for _, seen := range columns {
+			if seen == column {
+				return nil, status.Errorf(codes.InvalidArgument, "repeated export column %q", column)
+			}
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func isDefaultAccountExportColumn(column string) bool {
+	for _, known := range defaultAccountExportColumns {
+		if known == column {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the value of the column as written by both formats.
+func exportedAccountField(exported ExportedAccount, column string, maskPII bool) string {
+	info := exported.AccountInfo
+	switch column {
+	case accountColumnId:
+		return strconv.FormatUint(exported.AccountId, 10)
+	case accountColumnUsername:
+		return info.Username
+	case accountColumnFullname:
+		if maskPII {
+			return maskFullname(info.Fullname)
+		}
+		return info.Fullname
+	case accountColumnEmail:
+		if maskPII {
+			return maskEmail(info.Email)
+		}
+		return info.Email
+	case accountColumnPhoneNumber:
+		if maskPII {
+			return maskPhoneNumber(info.PhoneNumber)
+		}
+		return info.PhoneNumber
+	case accountColumnRole:
+		return info.Role.String()
+	case accountColumnStatus:
+		return info.Status.String()
+	case accountColumnCreatedAt:
+		return exported.CreatedAt.UTC().Format(time.RFC3339)
+	case accountColumnHashedPassword:
+		return exported.HashedPassword
+	default:
+		return ""
+	}
+}
+
+// Keeps the first character, masking the others.
+func maskKeepingFirst(s string) string {
+	first, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return ""
+	}
+	return string(first) + strings.Repeat("*", utf8.RuneCountInString(s[size:]))
+}
+
+// Keeps the first character of each word.
+func maskFullname(fullname string) string {
+	words := strings.Fields(fullname)
+	for i, word := range words {
+		words[i] = maskKeepingFirst(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// Keeps the first character of the local part and the whole domain.
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return maskKeepingFirst(email)
+	}
+	return maskKeepingFirst(email[:at]) + email[at:]
+}
+
+// Keeps the last two digits.
+func maskPhoneNumber(phoneNumber string) string {
+	const kept = 2
+	if len(phoneNumber) <= kept {
+		return strings.Repeat("*", len(phoneNumber))
+	}
+	return strings.Repeat("*", len(phoneNumber)-kept) + phoneNumber[len(phoneNumber)-kept:]
+}
+
+type csvAccountExportWriter struct {
+	writer  *csv.Writer
+	columns []string
+	maskPII bool
+}
+
+func (c *csvAccountExportWriter) Write(exported ExportedAccount) error {
+	fields := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		fields[i] = exportedAccountField(exported, column, c.maskPII)
+	}
+	return c.writer.Write(fields)
+}
+
+func (c *csvAccountExportWriter) Flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// Writes the keys of each line in the order of the columns.
+type jsonlAccountExportWriter struct {
+	writer  *bufio.Writer
+	columns []string
+	maskPII bool
+}
+
+func (j *jsonlAccountExportWriter) Write(exported ExportedAccount) error {
+	line := []byte{'{'}
+	for i, column := range j.columns {
+		if i > 0 {
+			line = append(line, ',')
+		}
+		line = strconv.AppendQuote(line, column)
+		line = append(line, ':')
+		if column == accountColumnId {
+			line = strconv.AppendUint(line, exported.AccountId, 10)
+			continue
+		}
+		value, err := json.Marshal(exportedAccountField(exported, column, j.maskPII))
+		if err != nil {
+			return err
+		}
+		line = append(line, value...)
+	}
+	line = append(line, '}', '\n')
+	_, err := j.writer.Write(line)
+	return err
+}
+
+func (j *jsonlAccountExportWriter) Flush() error {
+	return j.writer.Flush()
+}
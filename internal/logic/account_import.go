@@ -0,0 +1,469 @@
+package logic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultImportBatchSize = 100
+	// The longest line of a JSONL file.
+	maxImportLineBytes = 1 << 20
+)
+
+// The columns of CSV account files, which are the keys of JSONL ones too.
+// Imports take all of them but the id and the creation time, which are only
+// exported.
+const (
+	accountColumnId             = "id"
+	accountColumnUsername       = "username"
+	accountColumnFullname       = "fullname"
+	accountColumnEmail          = "email"
+	accountColumnPhoneNumber    = "phone_number"
+	accountColumnRole           = "role"
+	accountColumnStatus         = "status"
+	accountColumnPassword       = "password"
+	accountColumnHashedPassword = "hashed_password"
+	accountColumnCreatedAt      = "created_at"
+)
+
+// Wrapped by the errors of a row that cannot be read, after which the next
+// rows still can.
+var ErrImportRowUnreadable = errors.New("row cannot be read")
+
+// Reads the rows of an import one at a time.
+type AccountImportReader interface {
+	// Returns io.EOF after the last row. An error wrapping
+	// ErrImportRowUnreadable stands for one row, any other error ends the
+	// import.
+	Read() (ImportAccountsRow, error)
+}
+
+func NewAccountImportReader(r io.Reader, format AccountFileFormat) (AccountImportReader, error) {
+	switch format {
+	case AccountFileFormatCSV:
+		reader := csv.NewReader(r)
+		// The field count is checked against the header, per row
+		reader.FieldsPerRecord = -1
+		return &csvAccountImportReader{reader: reader}, nil
+	case AccountFileFormatJSONL:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineBytes)
+		return &jsonlAccountImportReader{scanner: scanner}, nil
+	default:
+		return nil, ErrAccountFileFormatInvalid
+	}
+}
+
+func unreadableImportRow(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrImportRowUnreadable, fmt.Sprintf(format, args...))
+}
+
+// A row of an account file, as written.
+type accountRecord struct {
+	Username       string `json:"username"`
+	Fullname       string `json:"fullname"`
+	Email          string `json:"email"`
+	PhoneNumber    string `json:"phone_number"`
+	Role           string `json:"role"`
+	Status         string `json:"status"`
+	Password       string `json:"password"`
+	HashedPassword string `json:"hashed_password"`
+}
+
+func (r *accountRecord) field(column string) *string {
+	switch column {
+	case accountColumnUsername:
+		return &r.Username
+	case accountColumnFullname:
+		return &r.Fullname
+	case accountColumnEmail:
+		return &r.Email
+	case accountColumnPhoneNumber:
+		return &r.PhoneNumber
+	case accountColumnRole:
+		return &r.Role
+	case accountColumnStatus:
+		return &r.Status
+	case accountColumnPassword:
+		return &r.Password
+	case accountColumnHashedPassword:
+		return &r.HashedPassword
+	default:
+		return nil
+	}
+}
+
+// An empty role stands for a member, and an empty status for the default
+// status of new accounts.
+func (r accountRecord) toRow() (ImportAccountsRow, error) {
+	row := ImportAccountsRow{
+		AccountInfo: AccountInfo{
+			Username:    strings.TrimSpace(r.Username),
+			Fullname:    strings.TrimSpace(r.Fullname),
+			Email:       strings.TrimSpace(r.Email),
+			PhoneNumber: strings.TrimSpace(r.PhoneNumber),
+			Role:        Member,
+		},
+		Password:       r.Password,
+		HashedPassword: strings.TrimSpace(r.HashedPassword),
+	}
+
+	if roleName := strings.ToLower(strings.TrimSpace(r.Role)); roleName != "" {
+		role, ok := RoleNamed(roleName)
+		if !ok {
+			return row, unreadableImportRow("unknown role %q", r.Role)
+		}
+		row.AccountInfo.Role = role
+	}
+	if statusName := strings.ToLower(strings.TrimSpace(r.Status)); statusName != "" {
+		accountStatus, ok := AccountStatusNamed(statusName)
+		if !ok {
+			return row, unreadableImportRow("unknown status %q", r.Status)
+		}
+		row.AccountInfo.Status = accountStatus
+	}
+	return row, nil
+}
+
+type csvAccountImportReader struct {
+	reader *csv.Reader
+	// Nil until the header has been read.
+	columns []string
+}
+
+func (c *csvAccountImportReader) readHeader() error {
+	header, err := c.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.EOF
+		}
+		return status.Error(codes.InvalidArgument, "failed to read the csv header")
+	}
+
+	columns := make([]string, 0, len(header))
+	var record accountRecord
+	for _, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if record.field(column) == nil {
+			return status.Errorf(codes.InvalidArgument, "unknown csv column %q", column)
+		}
+		for _, seen := range columns {
+			if seen == column {
+				return status.Errorf(codes.InvalidArgument, "repeated csv column %q", column)
+			}
+		}
+		columns = append(columns, column)
+	}
+	for _, seen := range columns {
+		if seen == accountColumnUsername {
+			c.columns = columns
+			return nil
+		}
+	}
+	return status.Error(codes.InvalidArgument, "the csv header must name the username column")
+}
+
+func (c *csvAccountImportReader) Read() (ImportAccountsRow, error) {
+	if c.columns == nil {
+		if err := c.readHeader(); err != nil {
+			return ImportAccountsRow{}, err
+		}
+	}
+
+	fields, err := c.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return ImportAccountsRow{}, unreadableImportRow("%v", parseErr.Err)
+		}
+		return ImportAccountsRow{}, err
+	}
+	if len(fields) != len(c.columns) {
+		return ImportAccountsRow{}, unreadableImportRow("%d fields for %d columns",
+			len(fields), len(c.columns))
+	}
+
+	var record accountRecord
+	for i, column := range c.columns {
+		*record.field(column) = fields[i]
+	}
+	return record.toRow()
+}
+
+// Blank lines are skipped.
+type jsonlAccountImportReader struct {
+	scanner *bufio.Scanner
+}
+
+func (j *jsonlAccountImportReader) Read() (ImportAccountsRow, error) {
+	for j.scanner.Scan() {
+		line := bytes.TrimSpace(j.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		var record accountRecord
+		if err := decoder.Decode(&record); err != nil {
+			return ImportAccountsRow{}, unreadableImportRow("%v", err)
+		}
+		return record.toRow()
+	}
+	if err := j.scanner.Err(); err != nil {
+		return ImportAccountsRow{}, err
+	}
+	return ImportAccountsRow{}, io.EOF
+}
+
+// Joins the message of a status error with the violations it details.
+func importMessageOf(err error) string {
+	st := status.Convert(err)
+	messages := []string{st.Message()}
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				messages = append(messages, violation.GetDescription())
+			}
+		}
+	}
+	return strings.Join(messages, ": ")
+}
+
+// A valid row waiting for its batch to be created.
+type importedAccount struct {
+	resultIndex    int
+	account        database.Account
+	hashedPassword string
+}
+
+func (a account) ImportAccounts(
+	ctx context.Context,
+	params ImportAccountsParams,
+) (ImportAccountsOutput, error) {
+	batchSize := params.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+
+	var results []ImportAccountsRowResult
+	// Lower cased, as usernames compare case insensitively
+	usernames := map[string]struct{}{}
+	batch := make([]importedAccount, 0, batchSize)
+	for rowNumber := 1; ; rowNumber++ {
+		row, err := params.Rows.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, ErrImportRowUnreadable) {
+			if _, ok := status.FromError(err); ok {
+				return ImportAccountsOutput{}, err
+			}
+			return ImportAccountsOutput{}, status.Error(codes.InvalidArgument, "failed to read the rows")
+		}
+
+		results = append(results, ImportAccountsRowResult{
+			Row:      rowNumber,
+			Username: row.AccountInfo.Username,
+		})
+		result := &results[len(results)-1]
+		if err != nil {
+			result.Outcome = ImportAccountsOutcomeInvalid
+			result.Message = err.Error()
+			continue
+		}
+
+		imported, outcome, message := a.prepareImportedAccount(ctx, row, params.DryRun, usernames)
+		if outcome != ImportAccountsOutcomeNone {
+			result.Outcome = outcome
+			result.Message = message
+			continue
+		}
+		if params.DryRun {
+			result.Outcome = ImportAccountsOutcomeValid
+			continue
+		}
+
+		imported.resultIndex = len(results) - 1
+		batch = append(batch, imported)
+		if len(batch) == batchSize {
+			a.createImportedAccounts(ctx, batch, results)
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		a.createImportedAccounts(ctx, batch, results)
+	}
+
+	counts := map[ImportAccountsOutcome]int{}
+	for _, result := range results {
+		counts[result.Outcome]++
+	}
+	return ImportAccountsOutput{
+		Results: results,
+		Counts:  counts,
+	}, nil
+}
+
+// Checks the row as CreateAccount would, and hashes its password unless on
+// a dry run. Returns the outcome of a row that cannot be imported, or none.
+func (a account) prepareImportedAccount(
+	ctx context.Context,
+	row ImportAccountsRow,
+	isDryRun bool,
+	usernames map[string]struct{},
+) (importedAccount, ImportAccountsOutcome, string) {
+	emptyObj := importedAccount{}
+	info := row.AccountInfo
+	if info.Username == "" {
+		return emptyObj, ImportAccountsOutcomeInvalid, "username is required"
+	}
+	if (row.Password == "") == (row.HashedPassword == "") {
+		return emptyObj, ImportAccountsOutcomeInvalid, "either a password or a hashed password is required"
+	}
+	if _, ok := usernames[strings.ToLower(info.Username)]; ok {
+		return emptyObj, ImportAccountsOutcomeDuplicate, "username is used by an earlier row"
+	}
+
+	if row.Password != "" {
+		err := a.passwordPolicyLogic.ValidatePassword(ctx, ValidatePasswordParams{
+			Password: row.Password,
+			Username: info.Username,
+			Fullname: info.Fullname,
+			Email:    info.Email,
+		})
+		if err != nil {
+			return emptyObj, ImportAccountsOutcomeInvalid, importMessageOf(err)
+		}
+	} else if !a.hashLogic.IsHashSupported(ctx, row.HashedPassword) {
+		return emptyObj, ImportAccountsOutcomeInvalid, "hashed password is not of a supported hash algorithm or parameters"
+	}
+
+	phoneNumber, err := normalizePhoneNumber(info.PhoneNumber, a.phoneNumberConfig.DefaultCountryCode)
+	if err != nil {
+		return emptyObj, ImportAccountsOutcomeInvalid, importMessageOf(err)
+	}
+	accountStatus, err := accountStatusOnCreation(info.Status)
+	if err != nil {
+		return emptyObj, ImportAccountsOutcomeInvalid, importMessageOf(err)
+	}
+
+	isUsernameTaken, err := a.accountAccessor.IsUsernameTaken(ctx, info.Username)
+	if err != nil {
+		return emptyObj, ImportAccountsOutcomeFailed, "failed to check if username taken"
+	} else if isUsernameTaken {
+		return emptyObj, ImportAccountsOutcomeDuplicate, "username has already taken"
+	}
+	usernames[strings.ToLower(info.Username)] = struct{}{}
+
+	hashedPassword := row.HashedPassword
+	if row.Password != "" && !isDryRun {
+		hashedPassword, err = a.hashLogic.Hash(ctx, row.Password)
+		if err != nil {
+			return emptyObj, ImportAccountsOutcomeFailed, importMessageOf(err)
+		}
+	}
+
+	return importedAccount{
+		account: database.Account{
+			Username:    info.Username,
+			Fullname:    info.Fullname,
+			Email:       info.Email,
+			PhoneNumber: phoneNumber,
+			RoleId:      uint8(info.Role),
+			StatusId:    uint8(accountStatus),
+		},
+		hashedPassword: hashedPassword,
+	}, ImportAccountsOutcomeNone, ""
+}
+
+// Creates the batch in one transaction and records the outcomes in the
+// results of its rows.
+func (a account) createImportedAccounts(
+	ctx context.Context,
+	batch []importedAccount,
+	results []ImportAccountsRowResult,
+) {
+	ids, err := a.createImportedAccountsInTx(ctx, batch)
+	if err == nil {
+		for i, imported := range batch {
+			results[imported.resultIndex].Outcome = ImportAccountsOutcomeCreated
+			results[imported.resultIndex].AccountId = ids[i]
+		}
+		return
+	}
+
+	// One failing row rolls the whole batch back, so the rows are retried
+	// alone to create all the others
+	if len(batch) > 1 {
+		for i := range batch {
+			a.createImportedAccounts(ctx, batch[i:i+1], results)
+		}
+		return
+	}
+	result := &results[batch[0].resultIndex]
+	// The username may have been taken since the row was checked
+	isUsernameTaken, takenErr := a.accountAccessor.IsUsernameTaken(ctx, batch[0].account.Username)
+	if takenErr == nil && isUsernameTaken {
+		result.Outcome = ImportAccountsOutcomeDuplicate
+		result.Message = "username has already taken"
+		return
+	}
+	result.Outcome = ImportAccountsOutcomeFailed
+	result.Message = importMessageOf(err)
+}
+
+func (a account) createImportedAccountsInTx(
+	ctx context.Context,
+	batch []importedAccount,
+) ([]uint64, error) {
+	tx, err := a.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	ids := make([]uint64, 0, len(batch))
+	for _, imported := range batch {
+		id, err := a.accountAccessor.
+			WithExecutor(tx).
+			CreateAccount(ctx, imported.account)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to create new account")
+		}
+
+		err = a.accountPasswordAccessor.
+			WithExecutor(tx).
+			CreateAccountPassword(ctx, database.AccountPassword{
+				OfAccountId:  id,
+				HashedString: imported.hashedPassword,
+			})
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to create new password")
+		}
+
+		if err = a.recordPasswordHistory(ctx, tx, id, imported.hashedPassword); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, ErrTxCommitFailed
+	}
+	return ids, nil
+}
@@ -0,0 +1,178 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/dataaccess/notifier"
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const passwordResetTokenByteLength = 32
+
+// Succeeds whether or not the username exists, so the response cannot be used
+// to find out which usernames are registered. Requesting a new token drops the
+// ones issued before.
+func (a account) RequestPasswordReset(
+	ctx context.Context,
+	params RequestPasswordResetParams,
+) error {
+	logger := utils.LoggerWithContext(ctx, a.logger).With(zap.String("username", params.Username))
+	if params.Username == "" {
+		return status.Error(codes.InvalidArgument, "username is required")
+	}
+
+	acc, err := a.accountAccessor.GetAccountByUsername(ctx, params.Username)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return status.Error(codes.Internal, "failed to get account")
+		}
+		logger.Info("password reset requested for an unknown username")
+		return nil
+	}
+	if acc.Email == "" {
+		logger.Warn("password reset requested for an account without email")
+		return nil
+	}
+
+	token, err := generateOpaqueToken(passwordResetTokenByteLength)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to generate password reset token")
+	}
+	expiresAt := time.Now().Add(a.passwordResetConfig.TTL)
+
+	tx, err := a.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	err = a.passwordResetTokenAccessor.
+		WithExecutor(tx).
+		DeletePasswordResetTokenOfAccount(ctx, acc.Id)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to delete password reset tokens")
+	}
+	_, err = a.passwordResetTokenAccessor.
+		WithExecutor(tx).
+		CreatePasswordResetToken(ctx, database.PasswordResetToken{
+			OfAccountId: acc.Id,
+			HashedToken: hashOpaqueToken(token),
+			ExpiresAt:   expiresAt,
+		})
+	if err != nil {
+		return status.Error(codes.Internal, "failed to create password reset token")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return ErrTxCommitFailed
+	}
+
+	err = a.notifier.Send(ctx, notifier.Message{
+		Channel:   notifier.ChannelEmail,
+		Recipient: acc.Email,
+		Subject:   "Reset your password",
+		Body: fmt.Sprintf("Use this token to reset your password: %s\nIt expires at %s.",
+			token, expiresAt.UTC().Format(time.RFC1123)),
+	})
+	if err != nil {
+		return status.Error(codes.Internal, "failed to send password reset token")
+	}
+
+	return nil
+}
+
+// Sets the new password and redeems the token in one transaction, then signs
+// the account out everywhere by revoking its refresh tokens.
+func (a account) ConfirmPasswordReset(
+	ctx context.Context,
+	params ConfirmPasswordResetParams,
+) (ConfirmPasswordResetOutput, error) {
+	emptyObj := ConfirmPasswordResetOutput{}
+	if params.Token == "" {
+		return emptyObj, ErrPasswordResetTokenInvalid
+	}
+
+	tx, err := a.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return emptyObj, ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	prt, err := a.passwordResetTokenAccessor.
+		WithExecutor(tx).
+		GetPasswordResetTokenByHashedToken(ctx, hashOpaqueToken(params.Token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return emptyObj, ErrPasswordResetTokenInvalid
+		}
+		return emptyObj, status.Error(codes.Internal, "failed to get password reset token")
+	}
+
+	now := time.Now()
+	if prt.UsedAt != nil {
+		return emptyObj, ErrPasswordResetTokenUsed
+	}
+	if now.After(prt.ExpiresAt) {
+		return emptyObj, ErrPasswordResetTokenExpired
+	}
+
+	acc, err := a.accountAccessor.
+		WithExecutor(tx).
+		GetAccount(ctx, prt.OfAccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to get account")
+	}
+
+	err = a.passwordPolicyLogic.ValidatePassword(ctx, ValidatePasswordParams{
+		Password: params.NewPassword,
+		Username: acc.Username,
+		Fullname: acc.Fullname,
+		Email:    acc.Email,
+	})
+	if err != nil {
+		return emptyObj, err
+	}
+
+	err = a.passwordResetTokenAccessor.
+		WithExecutor(tx).
+		UsePasswordResetToken(ctx, prt.Id, now)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to use password reset token")
+	}
+
+	if err = a.replaceAccountPassword(ctx, tx, acc.Id, params.NewPassword); err != nil {
+		return emptyObj, err
+	}
+
+	err = a.refreshTokenAccessor.
+		WithExecutor(tx).
+		RevokeRefreshTokenOfAccount(ctx, acc.Id, now)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to revoke refresh tokens")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return emptyObj, ErrTxCommitFailed
+	}
+
+	// Whoever got locked out by guessing the old password should not keep the
+	// owner out of the account after the reset.
+	err = a.loginThrottleLogic.RecordLoginSuccess(ctx, LoginAttemptParams{Username: acc.Username})
+	if err != nil {
+		utils.LoggerWithContext(ctx, a.logger).
+			With(zap.Error(err)).
+			Warn("failed to clear login failures")
+	}
+
+	return ConfirmPasswordResetOutput{
+		AccountId: acc.Id,
+	}, nil
+}
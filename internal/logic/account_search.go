@@ -0,0 +1,180 @@
+package logic
+
+import (
+	"context"
+	"html"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxAccountSearchTerms = 8
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Splits the query into lower case words of letters and digits, the way the
+// FULLTEXT index splits the fields. Repeated words are dropped and words past
+// the maximum are ignored.
+func accountSearchTerms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !isWordRune(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		if len(terms) == maxAccountSearchTerms {
+			break
+		}
+		if !slices.Contains(terms, word) {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// Wraps the parts of the value matched by the terms in <em> tags, escaping
+// the rest as HTML. Matches starting a word are preferred, the ones inside a
+// word are highlighted only when the value has none of the former. Returns
+// false when no term matches.
+func highlightAccountField(value string, terms []string) (string, bool) {
+	runes := []rune(value)
+	lowered := make([]rune, len(runes))
+	for i, r := range runes {
+		lowered[i] = unicode.ToLower(r)
+	}
+
+	isMatched := make([]bool, len(runes))
+	var hasMatch bool
+	for _, isAtWordStart := range []bool{true, false} {
+		for _, term := range terms {
+			termRunes := []rune(term)
+			for i := 0; i+len(termRunes) <= len(lowered); i++ {
+				if isAtWordStart && i > 0 && isWordRune(lowered[i-1]) {
+					continue
+				}
+				if !slices.Equal(lowered[i:i+len(termRunes)], termRunes) {
+					continue
+				}
+				for j := i; j < i+len(termRunes); j++ {
+					isMatched[j] = true
+				}
+				hasMatch = true
+			}
+		}
+		if hasMatch {
+			break
+		}
+	}
+	if !hasMatch {
+		return "", false
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && isMatched[j] == isMatched[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if isMatched[i] {
+			sb.WriteString("<em>" + segment + "</em>")
+		} else {
+			sb.WriteString(segment)
+		}
+		i = j
+	}
+	return sb.String(), true
+}
+
+func (a account) SearchAccounts(
+	ctx context.Context,
+	params SearchAccountsParams,
+) (SearchAccountsOutput, error) {
+	emptyObj := SearchAccountsOutput{}
+	terms := accountSearchTerms(params.Query)
+	if len(terms) == 0 {
+		return emptyObj, ErrSearchQueryInvalid
+	}
+	pageSize, err := accountPageSize(params.PageSize)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	// The order of the words changes neither the matches nor the ranking
+	sortedTerms := slices.Sorted(slices.Values(terms))
+	searchHash := hashListing("search|" + strings.Join(sortedTerms, " "))
+	var offset int
+	if params.PageToken != "" {
+		token, err := decodeAccountPageToken(params.PageToken)
+		if err != nil || token.ListingHash != searchHash || token.Offset <= 0 {
+			return emptyObj, ErrPageTokenInvalid
+		}
+		offset = token.Offset
+	}
+
+	found, err := a.accountAccessor.SearchAccounts(ctx, database.AccountSearchQuery{
+		Terms:  terms,
+		Offset: offset,
+		// One more than the page tells whether another page follows
+		Limit: pageSize + 1,
+	})
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to search accounts")
+	}
+
+	var nextPageToken string
+	if len(found) > pageSize {
+		found = found[:pageSize]
+		nextPageToken, err = encodeAccountPageToken(accountPageToken{
+			Offset:      offset + pageSize,
+			ListingHash: searchHash,
+		})
+		if err != nil {
+			return emptyObj, status.Error(codes.Internal, "failed to encode page token")
+		}
+	}
+
+	results := make([]AccountSearchResult, 0, len(found))
+	for _, f := range found {
+		var highlights []AccountFieldHighlight
+		for _, field := range []struct {
+			field AccountField
+			value string
+		}{
+			{AccountFieldUsername, f.Username},
+			{AccountFieldFullname, f.Fullname},
+			{AccountFieldEmail, f.Email},
+		} {
+			if highlighted, ok := highlightAccountField(field.value, terms); ok {
+				highlights = append(highlights, AccountFieldHighlight{
+					Field:       field.field,
+					Highlighted: highlighted,
+				})
+			}
+		}
+
+		results = append(results, AccountSearchResult{
+			AccountId: f.Id,
+			AccountInfo: AccountInfo{
+				Username:    f.Username,
+				Fullname:    f.Fullname,
+				Email:       f.Email,
+				PhoneNumber: f.PhoneNumber,
+				Role:        Role(f.RoleId),
+				Status:      AccountStatus(f.StatusId),
+			},
+			Score:      f.Score,
+			Highlights: highlights,
+		})
+	}
+
+	return SearchAccountsOutput{
+		Results:       results,
+		NextPageToken: nextPageToken,
+	}, nil
+}
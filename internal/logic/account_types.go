@@ -1,5 +1,7 @@
 package logic
 
+import "time"
+
 type Role uint8
 
 const (
@@ -8,12 +10,85 @@ const (
 	Member
 )
 
+func (r Role) String() string {
+	switch r {
+	case Admin:
+		return "admin"
+	case Member:
+		return "member"
+	default:
+		return "none"
+	}
+}
+
+// Returns the role whose String is the given name.
+func RoleNamed(name string) (Role, bool) {
+	for _, role := range []Role{Admin, Member} {
+		if role.String() == name {
+			return role, true
+		}
+	}
+	return None, false
+}
+
+type AccountStatus uint8
+
+const (
+	AccountStatusNone AccountStatus = iota
+	// Created but not allowed to log in until activated.
+	AccountStatusPending
+	AccountStatusActive
+	// Barred from logging in by an admin.
+	AccountStatusSuspended
+	// Barred from logging in for security reasons, such as a suspected
+	// takeover.
+	AccountStatusLocked
+	// Closed, either by the account holder or by an admin.
+	AccountStatusDeactivated
+)
+
+func (s AccountStatus) String() string {
+	switch s {
+	case AccountStatusPending:
+		return "pending"
+	case AccountStatusActive:
+		return "active"
+	case AccountStatusSuspended:
+		return "suspended"
+	case AccountStatusLocked:
+		return "locked"
+	case AccountStatusDeactivated:
+		return "deactivated"
+	default:
+		return "none"
+	}
+}
+
+// Returns the status whose String is the given name.
+func AccountStatusNamed(name string) (AccountStatus, bool) {
+	for _, accountStatus := range []AccountStatus{
+		AccountStatusPending,
+		AccountStatusActive,
+		AccountStatusSuspended,
+		AccountStatusLocked,
+		AccountStatusDeactivated,
+	} {
+		if accountStatus.String() == name {
+			return accountStatus, true
+		}
+	}
+	return AccountStatusNone, false
+}
+
 type AccountInfo struct {
 	Username    string
 	Fullname    string
 	Email       string
 	PhoneNumber string
 	Role        Role
+	// Only set on creation, where it is either pending or active, and through
+	// the status transitions afterwards.
+	Status AccountStatus
 }
 
 type CreateAccountParams struct {
@@ -33,9 +108,25 @@ type DeleteAccountByUsernameParams struct {
 	Username string
 }
 
+type RestoreAccountParams struct {
+	AccountId uint64
+}
+
+type RestoreAccountOutput struct {
+	AccountId uint64
+}
+
+type PurgeDeletedAccountsParams struct{}
+
+type PurgeDeletedAccountsOutput struct {
+	PurgedAccountIds []uint64
+}
+
 type CheckAccountValidParams struct {
 	Username string
 	Password string
+	// The peer address of the caller, used to throttle failed attempts.
+	Address string
 }
 
 type CheckAccountValidOutput struct {
@@ -57,6 +148,186 @@ type GetAccountParams struct {
 type GetAccountOutput struct {
 	AccountId   uint64
 	AccountInfo AccountInfo
+	// Nil until the current email has been verified.
+	EmailVerifiedAt *time.Time
+	// Nil until the current phone number has been verified.
+	PhoneVerifiedAt *time.Time
+}
+
+type AccountOrder uint8
+
+const (
+	// Oldest accounts first, ids grow with creation.
+	AccountOrderIdAscending AccountOrder = iota
+	// Newest accounts first.
+	AccountOrderIdDescending
+)
+
+// Narrows a listing of accounts.
+type AccountFilter struct {
+	// Nil matches every role.
+	Role *Role
+	// Nil matches every status.
+	Status *AccountStatus
+	// Zero leaves the creation time unbounded on that side. Both bounds are
+	// exclusive.
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	UsernamePrefix string
+}
+
+type ListAccountsParams struct {
+	// Zero asks for the default size, sizes above the maximum are capped.
+	PageSize int
+	// Empty for the first page, otherwise the token returned with the
+	// previous page of the same listing.
+	PageToken string
+	AccountFilter
+	Order AccountOrder
+}
+
+type ListAccountsOutput struct {
+	AccountIds   []uint64
+	AccountInfos []AccountInfo
+	// Empty on the last page.
+	NextPageToken string
+}
+
+type StreamAccountsParams struct {
+	AccountFilter
+	Order AccountOrder
+}
+
+type StreamAccountsOutput struct {
+	AccountId   uint64
+	AccountInfo AccountInfo
+}
+
+type SearchAccountsParams struct {
+	// Free text, whose words are matched against the starts of the words of
+	// the username, fullname and email.
+	Query string
+	// Zero asks for the default size, sizes above the maximum are capped.
+	PageSize int
+	// Empty for the first page, otherwise the token returned with the
+	// previous page of the same search.
+	PageToken string
+}
+
+type AccountField uint8
+
+const (
+	AccountFieldUsername AccountField = iota + 1
+	AccountFieldFullname
+	AccountFieldEmail
+)
+
+// A field of a found account, with its matched parts wrapped in <em> tags.
+// The rest of the field is HTML escaped.
+type AccountFieldHighlight struct {
+	Field       AccountField
+	Highlighted string
+}
+
+type AccountSearchResult struct {
+	AccountId   uint64
+	AccountInfo AccountInfo
+	// Higher is more relevant. Scores compare within one search only.
+	Score      float64
+	Highlights []AccountFieldHighlight
+}
+
+type SearchAccountsOutput struct {
+	// Most relevant first.
+	Results []AccountSearchResult
+	// Empty on the last page.
+	NextPageToken string
+}
+
+type AccountFileFormat uint8
+
+const (
+	AccountFileFormatNone AccountFileFormat = iota
+	// Comma separated values, with a header row naming the columns.
+	AccountFileFormatCSV
+	// One JSON object per line.
+	AccountFileFormatJSONL
+)
+
+func (f AccountFileFormat) String() string {
+	switch f {
+	case AccountFileFormatCSV:
+		return "csv"
+	case AccountFileFormatJSONL:
+		return "jsonl"
+	default:
+		return "none"
+	}
+}
+
+// A row of an import. Exactly one of Password and HashedPassword is set.
+type ImportAccountsRow struct {
+	AccountInfo AccountInfo
+	Password    string
+	// Stored as is, so it must come from a supported hash algorithm, with
+	// parameters the server is willing to verify.
+	HashedPassword string
+}
+
+type ImportAccountsParams struct {
+	Rows AccountImportReader
+	// Validates the rows without creating any account.
+	DryRun bool
+	// The most rows created in one transaction. Zero uses the default.
+	BatchSize int
+}
+
+type ImportAccountsOutcome uint8
+
+const (
+	ImportAccountsOutcomeNone ImportAccountsOutcome = iota
+	ImportAccountsOutcomeCreated
+	// The row passed the validation of a dry run.
+	ImportAccountsOutcomeValid
+	// The username is taken, or used by an earlier row of the import.
+	ImportAccountsOutcomeDuplicate
+	ImportAccountsOutcomeInvalid
+	// The row was valid, yet its account could not be created.
+	ImportAccountsOutcomeFailed
+)
+
+func (o ImportAccountsOutcome) String() string {
+	switch o {
+	case ImportAccountsOutcomeCreated:
+		return "created"
+	case ImportAccountsOutcomeValid:
+		return "valid"
+	case ImportAccountsOutcomeDuplicate:
+		return "duplicate"
+	case ImportAccountsOutcomeInvalid:
+		return "invalid"
+	case ImportAccountsOutcomeFailed:
+		return "failed"
+	default:
+		return "none"
+	}
+}
+
+type ImportAccountsRowResult struct {
+	// Counts the rows from one, leaving the header of a CSV file out.
+	Row      int
+	Username string
+	Outcome  ImportAccountsOutcome
+	// Set when the account has been created.
+	AccountId uint64
+	// Tells why the row was not imported.
+	Message string
+}
+
+type ImportAccountsOutput struct {
+	// One per row, in the order of the rows.
+	Results []ImportAccountsRowResult
+	Counts  map[ImportAccountsOutcome]int
 }
 
 type GetAccountAllParams struct{}
@@ -92,3 +363,60 @@ type UpdateAccountPasswordParams struct {
 type UpdateAccountPasswordOutput struct {
 	AccountId uint64
 }
+
+type ChangePasswordParams struct {
+	AccountId       uint64
+	CurrentPassword string
+	NewPassword     string
+	// The peer address of the caller, used to throttle wrong current passwords.
+	Address string
+}
+
+type ChangePasswordOutput struct {
+	AccountId uint64
+}
+
+type RequestPasswordResetParams struct {
+	Username string
+}
+
+type ConfirmPasswordResetParams struct {
+	Token       string
+	NewPassword string
+}
+
+type ConfirmPasswordResetOutput struct {
+	AccountId uint64
+}
+
+type ChangeAccountStatusParams struct {
+	AccountId uint64
+	Reason    string
+	// The admin making the change, zero when the service makes it itself.
+	ActorAccountId uint64
+}
+
+type ChangeAccountStatusOutput struct {
+	AccountId uint64
+	Status    AccountStatus
+}
+
+// An account as written by an export.
+type ExportedAccount struct {
+	AccountId   uint64
+	AccountInfo AccountInfo
+	CreatedAt   time.Time
+	// Left empty unless password hashes are exported.
+	HashedPassword string
+}
+
+type AccountExportOptions struct {
+	// The columns written, in order. Empty means all of them, leaving the
+	// hashed password out unless password hashes are included.
+	Columns []string
+	// Masks the fullname, the email and the phone number, keeping enough of
+	// each to tell accounts apart by eye.
+	MaskPII bool
+	// Allows the hashed password column, which is refused otherwise.
+	IncludePasswordHashes bool
+}
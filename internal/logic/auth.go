@@ -0,0 +1,137 @@
+package logic
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type Auth interface {
+	Login(ctx context.Context, params LoginParams) (LoginOutput, error)
+	// Finishes a login the password check answered with a second factor
+	// challenge.
+	VerifySecondFactor(ctx context.Context, params VerifySecondFactorParams) (SecondFactorLoginOutput, error)
+	// Signs in with a passkey instead of a password.
+	LoginWithWebAuthn(ctx context.Context, params FinishWebAuthnAssertionParams) (LoginOutput, error)
+}
+
+type auth struct {
+	accountLogic      Account
+	accessTokenLogic  AccessToken
+	refreshTokenLogic RefreshToken
+	secondFactorLogic SecondFactor
+	webAuthnLogic     WebAuthn
+	logger            *zap.Logger
+}
+
+func NewAuth(
+	accountLogic Account,
+	accessTokenLogic AccessToken,
+	refreshTokenLogic RefreshToken,
+	secondFactorLogic SecondFactor,
+	webAuthnLogic WebAuthn,
+	logger *zap.Logger,
+) Auth {
+	return &auth{
+		accountLogic:      accountLogic,
+		accessTokenLogic:  accessTokenLogic,
+		refreshTokenLogic: refreshTokenLogic,
+		secondFactorLogic: secondFactorLogic,
+		webAuthnLogic:     webAuthnLogic,
+		logger:            logger,
+	}
+}
+
+func (a auth) Login(
+	ctx context.Context,
+	params LoginParams,
+) (LoginOutput, error) {
+	emptyObj := LoginOutput{}
+	valid, err := a.accountLogic.CheckAccountValid(ctx,
+		CheckAccountValidParams{
+			Username: params.Username,
+			Password: params.Password,
+			Address:  params.Address,
+		})
+	if err != nil {
+		return emptyObj, err
+	}
+
+	return a.issueTokens(ctx, valid.AccountId)
+}
+
+func (a auth) VerifySecondFactor(
+	ctx context.Context,
+	params VerifySecondFactorParams,
+) (SecondFactorLoginOutput, error) {
+	emptyObj := SecondFactorLoginOutput{}
+	verified, err := a.secondFactorLogic.VerifySecondFactor(ctx, params)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	login, err := a.issueTokens(ctx, verified.AccountId)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	return SecondFactorLoginOutput{
+		LoginOutput:            login,
+		IsRecoveryCodeUsed:     verified.IsRecoveryCodeUsed,
+		RemainingRecoveryCodes: verified.RemainingRecoveryCodes,
+	}, nil
+}
+
+func (a auth) LoginWithWebAuthn(
+	ctx context.Context,
+	params FinishWebAuthnAssertionParams,
+) (LoginOutput, error) {
+	emptyObj := LoginOutput{}
+	asserted, err := a.webAuthnLogic.FinishWebAuthnAssertion(ctx, params)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	return a.issueTokens(ctx, asserted.AccountId)
+}
+
+// Mints the access and refresh tokens that complete a successful login.
+func (a auth) issueTokens(
+	ctx context.Context,
+	accountId uint64,
+) (LoginOutput, error) {
+	emptyObj := LoginOutput{}
+	acc, err := a.accountLogic.GetAccount(ctx,
+		GetAccountParams{
+			AccountId: accountId,
+		})
+	if err != nil {
+		return emptyObj, err
+	}
+
+	access, err := a.accessTokenLogic.IssueAccessToken(ctx,
+		IssueAccessTokenParams{
+			AccountId: acc.AccountId,
+			Username:  acc.AccountInfo.Username,
+			Role:      acc.AccountInfo.Role,
+		})
+	if err != nil {
+		return emptyObj, err
+	}
+
+	refresh, err := a.refreshTokenLogic.IssueRefreshToken(ctx,
+		IssueRefreshTokenParams{
+			AccountId: acc.AccountId,
+		})
+	if err != nil {
+		return emptyObj, err
+	}
+
+	return LoginOutput{
+		AccountId:             acc.AccountId,
+		AccessToken:           access.AccessToken,
+		AccessTokenExpiresAt:  access.ExpiresAt,
+		RefreshToken:          refresh.RefreshToken,
+		RefreshTokenExpiresAt: refresh.ExpiresAt,
+	}, nil
+}
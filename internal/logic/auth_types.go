@@ -0,0 +1,24 @@
+package logic
+
+import "time"
+
+type LoginParams struct {
+	Username string
+	Password string
+	Address  string
+}
+
+type LoginOutput struct {
+	AccountId             uint64
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+type SecondFactorLoginOutput struct {
+	LoginOutput
+	IsRecoveryCodeUsed bool
+	// Only set when a recovery code was used.
+	RemainingRecoveryCodes int
+}
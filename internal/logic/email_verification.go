@@ -0,0 +1,135 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/dataaccess/notifier"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type EmailVerification interface {
+	// Sends a numeric code to the current email of the account.
+	SendEmailVerification(ctx context.Context, params SendEmailVerificationParams) (SendEmailVerificationOutput, error)
+	VerifyEmail(ctx context.Context, params VerifyEmailParams) (VerifyEmailOutput, error)
+}
+
+type emailVerification struct {
+	db              *sql.DB
+	accountAccessor database.AccountAccessor
+	notifier        notifier.Notifier
+	codes           verificationCodes
+	logger          *zap.Logger
+}
+
+func NewEmailVerification(
+	db *sql.DB,
+	accountAccessor database.AccountAccessor,
+	verificationCodeAccessor database.VerificationCodeAccessor,
+	notifier notifier.Notifier,
+	emailVerificationConfig configs.VerificationCode,
+	logger *zap.Logger,
+) EmailVerification {
+	return &emailVerification{
+		db:              db,
+		accountAccessor: accountAccessor,
+		notifier:        notifier,
+		codes: verificationCodes{
+			verificationCodeAccessor: verificationCodeAccessor,
+			codeConfig:               emailVerificationConfig,
+			logger:                   logger,
+		},
+		logger: logger,
+	}
+}
+
+func (e emailVerification) SendEmailVerification(
+	ctx context.Context,
+	params SendEmailVerificationParams,
+) (SendEmailVerificationOutput, error) {
+	emptyObj := SendEmailVerificationOutput{}
+	acc, err := e.accountAccessor.GetAccount(ctx, params.AccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.NotFound, "account not found")
+	}
+	if acc.Email == "" {
+		return emptyObj, status.Error(codes.FailedPrecondition, "account has no email")
+	}
+	if acc.EmailVerifiedAt != nil {
+		return emptyObj, ErrEmailAlreadyVerified
+	}
+
+	code, expiresAt, err := e.codes.issue(ctx, acc.Id, database.VerificationChannelEmail, acc.Email)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	err = e.notifier.Send(ctx, notifier.Message{
+		Channel:   notifier.ChannelEmail,
+		Recipient: acc.Email,
+		Subject:   "Verify your email",
+		Body: fmt.Sprintf("Your verification code is %s\nIt expires at %s.",
+			code, expiresAt.UTC().Format(time.RFC1123)),
+	})
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to send email verification")
+	}
+
+	return SendEmailVerificationOutput{
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (e emailVerification) VerifyEmail(
+	ctx context.Context,
+	params VerifyEmailParams,
+) (VerifyEmailOutput, error) {
+	emptyObj := VerifyEmailOutput{}
+	if params.Code == "" {
+		return emptyObj, ErrVerificationCodeInvalid
+	}
+
+	tx, err := e.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return emptyObj, ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	acc, err := e.accountAccessor.
+		WithExecutor(tx).
+		GetAccount(ctx, params.AccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.NotFound, "account not found")
+	}
+	if acc.EmailVerifiedAt != nil {
+		return emptyObj, ErrEmailAlreadyVerified
+	}
+
+	err = e.codes.redeem(ctx, tx, acc.Id, database.VerificationChannelEmail, acc.Email, params.Code)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	verifiedAt := time.Now()
+	err = e.accountAccessor.
+		WithExecutor(tx).
+		SetAccountEmailVerified(ctx, acc.Id, acc.Email, verifiedAt)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to set email verified")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return emptyObj, ErrTxCommitFailed
+	}
+
+	return VerifyEmailOutput{
+		AccountId:       acc.Id,
+		EmailVerifiedAt: verifiedAt,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package logic
+
+import "time"
+
+type SendEmailVerificationParams struct {
+	AccountId uint64
+}
+
+type SendEmailVerificationOutput struct {
+	ExpiresAt time.Time
+}
+
+type VerifyEmailParams struct {
+	AccountId uint64
+	Code      string
+}
+
+type VerifyEmailOutput struct {
+	AccountId       uint64
+	EmailVerifiedAt time.Time
+}
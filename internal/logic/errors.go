@@ -1,11 +1,93 @@
 package logic
 
 import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// Reasons of the errors telling why an account may not log in.
+const (
+	ReasonAccountPending     = "ACCOUNT_PENDING"
+	ReasonAccountSuspended   = "ACCOUNT_SUSPENDED"
+	ReasonAccountLocked      = "ACCOUNT_LOCKED"
+	ReasonAccountDeactivated = "ACCOUNT_DEACTIVATED"
+)
+
+// Attaches the reason, so clients can tell errors sharing a code apart.
+func errorWithReason(c codes.Code, msg string, reason string) error {
+	st := status.New(c, msg)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: ErrorDomain,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
 var (
 	ErrTxCommitFailed = status.Error(codes.Internal, "failed to commit")
 	ErrTxBeginFailed  = status.Error(codes.Internal, "failed to take a transaction up")
+
+	ErrInvalidCredentials       = status.Error(codes.Unauthenticated, "invalid username or password")
+	ErrCurrentPasswordIncorrect = status.Error(codes.Unauthenticated, "current password is incorrect")
+
+	ErrAccessTokenInvalid = status.Error(codes.Unauthenticated, "invalid access token")
+
+	ErrRefreshTokenInvalid = status.Error(codes.Unauthenticated, "invalid refresh token")
+	ErrRefreshTokenExpired = status.Error(codes.Unauthenticated, "refresh token has expired")
+	ErrRefreshTokenRevoked = status.Error(codes.Unauthenticated, "refresh token has been revoked")
+	ErrRefreshTokenReused  = status.Error(codes.Unauthenticated, "refresh token reuse detected")
+
+	ErrPasswordResetTokenInvalid = status.Error(codes.Unauthenticated, "invalid password reset token")
+	ErrPasswordResetTokenExpired = status.Error(codes.Unauthenticated, "password reset token has expired")
+	ErrPasswordResetTokenUsed    = status.Error(codes.Unauthenticated, "password reset token has been used")
+
+	ErrVerificationCodeInvalid          = status.Error(codes.InvalidArgument, "invalid verification code")
+	ErrVerificationCodeExpired          = status.Error(codes.FailedPrecondition, "verification code has expired")
+	ErrVerificationCodeAttemptsExceeded = status.Error(codes.FailedPrecondition, "too many wrong verification codes, request a new one")
+	ErrEmailAlreadyVerified             = status.Error(codes.FailedPrecondition, "email has already been verified")
+	ErrPhoneAlreadyVerified             = status.Error(codes.FailedPrecondition, "phone number has already been verified")
+
+	ErrTOTPNotEnrolled    = status.Error(codes.FailedPrecondition, "totp has not been enrolled")
+	ErrTOTPAlreadyEnabled = status.Error(codes.FailedPrecondition, "totp has already been enabled")
+	ErrTOTPCodeInvalid    = status.Error(codes.InvalidArgument, "invalid totp code")
+	ErrTOTPNotConfigured  = status.Error(codes.FailedPrecondition, "totp has not been configured on the server")
+
+	ErrLoginChallengeInvalid          = status.Error(codes.Unauthenticated, "invalid login challenge")
+	ErrLoginChallengeExpired          = status.Error(codes.Unauthenticated, "login challenge has expired")
+	ErrLoginChallengeAttemptsExceeded = status.Error(codes.Unauthenticated, "too many wrong codes, log in again")
+	ErrSecondFactorInvalid            = status.Error(codes.Unauthenticated, "invalid second factor code")
+	ErrSecondFactorNotEnabled         = status.Error(codes.FailedPrecondition, "second factor has not been enabled")
+
+	ErrWebAuthnSessionInvalid    = status.Error(codes.InvalidArgument, "invalid webauthn session")
+	ErrWebAuthnSessionExpired    = status.Error(codes.InvalidArgument, "webauthn session has expired")
+	ErrWebAuthnCredentialInvalid = status.Error(codes.InvalidArgument, "invalid webauthn credential")
+	ErrWebAuthnCredentialExists  = status.Error(codes.AlreadyExists, "webauthn credential has already been registered")
+	ErrWebAuthnAssertionFailed   = status.Error(codes.Unauthenticated, "webauthn assertion failed")
+	ErrWebAuthnCloneDetected     = status.Error(codes.PermissionDenied, "webauthn authenticator may have been cloned")
+
+	ErrAccountPending     = errorWithReason(codes.FailedPrecondition, "account has not been activated yet", ReasonAccountPending)
+	ErrAccountSuspended   = errorWithReason(codes.PermissionDenied, "account has been suspended", ReasonAccountSuspended)
+	ErrAccountLocked      = errorWithReason(codes.PermissionDenied, "account has been locked", ReasonAccountLocked)
+	ErrAccountDeactivated = errorWithReason(codes.PermissionDenied, "account has been deactivated", ReasonAccountDeactivated)
+
+	ErrAccountNotRestorable = status.Error(codes.NotFound, "no deleted account to restore, it may have been purged")
+
+	ErrAccountStatusTransitionInvalid = status.Error(codes.FailedPrecondition, "account cannot move to the requested status")
+	ErrAccountStatusReasonRequired    = status.Error(codes.InvalidArgument, "a reason is required to change the account status")
+
+	ErrPageSizeInvalid  = status.Error(codes.InvalidArgument, "page size must not be negative")
+	ErrPageTokenInvalid = status.Error(codes.InvalidArgument, "invalid page token, it may belong to another listing")
+
+	ErrAccountStatusOnCreationInvalid = status.Error(codes.InvalidArgument, "an account can only be created pending or active")
+
+	ErrAccountFileFormatInvalid    = status.Error(codes.InvalidArgument, "account file format must be csv or jsonl")
+	ErrPasswordHashExportForbidden = status.Error(codes.PermissionDenied, "password hashes are only exported when asked for explicitly")
+
+	ErrSearchQueryInvalid = status.Error(codes.InvalidArgument, "search query must hold a letter or a digit")
+
+	ErrPhoneNumberInvalid = status.Error(codes.InvalidArgument, "phone number must be in international format, such as +84912345678")
 )
@@ -3,6 +3,8 @@ package logic
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/Fiagram/account_service/internal/configs"
 	"golang.org/x/crypto/bcrypt"
@@ -13,32 +15,155 @@ import (
 type Hash interface {
 	Hash(ctx context.Context, input string) (hashed string, err error)
 	IsHashEqual(ctx context.Context, input string, hashed string) (bool, error)
+	// Reports whether a stored hash was made with another algorithm or with
+	// other parameters than the configured ones.
+	NeedsRehash(ctx context.Context, hashed string) bool
+	// Reports whether a hash made elsewhere is well formed and made with a
+	// supported algorithm, so it can be stored as is.
+	IsHashSupported(ctx context.Context, hashed string) bool
+	// The longest input in bytes the configured algorithm accepts, zero when
+	// it has no limit.
+	MaxInputBytes() int
+}
+
+// A hasher implements one algorithm. Every hash it produces carries its
+// algorithm identifier and parameters, so it can be verified later even after
+// the configuration changed.
+type hasher interface {
+	hash(input string) (string, error)
+	isHashEqual(input string, hashed string) (bool, error)
+	isOutdated(hashed string) bool
+	canVerify(hashed string) bool
+	isWellFormed(hashed string) bool
+	maxInputBytes() int
 }
 
 type hash struct {
 	hashConfig configs.Hash
+	current    hasher
+	hashers    []hasher
 }
 
-func NewHash(hashConfig configs.Hash) Hash {
+// Fails on an unknown algorithm, so a mistyped configuration stops the
+// service at startup rather than failing every hash.
+func NewHash(hashConfig configs.Hash) (Hash, error) {
+	bcryptHasher := &bcryptHasher{cost: hashConfig.Cost}
+	argon2idHasher := newArgon2idHasher(hashConfig.Argon2id)
+
+	var current hasher
+	switch hashConfig.Algorithm {
+	case configs.HashAlgorithmBcrypt, "":
+		current = bcryptHasher
+	case configs.HashAlgorithmArgon2id:
+		current = argon2idHasher
+	default:
+		return nil, fmt.Errorf("Failed to create hash: unknown algorithm %q", hashConfig.Algorithm)
+	}
+
 	return &hash{
-		hashConfig,
+		hashConfig: hashConfig,
+		current:    current,
+		hashers:    []hasher{bcryptHasher, argon2idHasher},
+	}, nil
+}
+
+func (h hash) hasherOf(hashed string) hasher {
+	for _, hr := range h.hashers {
+		if hr.canVerify(hashed) {
+			return hr
+		}
 	}
+	return nil
 }
 
 func (h hash) Hash(_ context.Context, input string) (string, error) {
-	hashed, err := bcrypt.GenerateFromPassword([]byte(input), h.hashConfig.Cost)
+	hashed, err := h.current.hash(input)
 	if err != nil {
 		return "", status.Error(codes.Internal, "failed to hash data")
 	}
-	return string(hashed), nil
+	return hashed, nil
 }
 
 func (h hash) IsHashEqual(_ context.Context, input string, hashed string) (bool, error) {
+	hr := h.hasherOf(hashed)
+	if hr == nil {
+		return false, status.Error(codes.Internal, "unknown hash format")
+	}
+	isEqual, err := hr.isHashEqual(input, hashed)
+	if err != nil {
+		return false, status.Error(codes.Internal, "failed to compare input")
+	}
+	return isEqual, nil
+}
+
+func (h hash) NeedsRehash(_ context.Context, hashed string) bool {
+	if !h.current.canVerify(hashed) {
+		return true
+	}
+	return h.current.isOutdated(hashed)
+}
+
+func (h hash) IsHashSupported(_ context.Context, hashed string) bool {
+	hr := h.hasherOf(hashed)
+	return hr != nil && hr.isWellFormed(hashed)
+}
+
+func (h hash) MaxInputBytes() int {
+	return h.current.maxInputBytes()
+}
+
+type bcryptHasher struct {
+	cost int
+}
+
+// Mirrors bcrypt, which silently falls back to its default cost for a cost
+// below the minimum.
+func (b bcryptHasher) effectiveCost() int {
+	if b.cost < bcrypt.MinCost {
+		return bcrypt.DefaultCost
+	}
+	return b.cost
+}
+
+func (b bcryptHasher) hash(input string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(input), b.effectiveCost())
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+func (b bcryptHasher) isHashEqual(input string, hashed string) (bool, error) {
 	if err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(input)); err != nil {
 		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
 			return false, nil
 		}
-		return false, status.Error(codes.Internal, "failed to compare input")
+		return false, err
 	}
 	return true, nil
 }
+
+func (b bcryptHasher) isOutdated(hashed string) bool {
+	cost, err := bcrypt.Cost([]byte(hashed))
+	if err != nil {
+		return true
+	}
+	return cost != b.effectiveCost()
+}
+
+func (b bcryptHasher) canVerify(hashed string) bool {
+	return strings.HasPrefix(hashed, "$2a$") ||
+		strings.HasPrefix(hashed, "$2b$") ||
+		strings.HasPrefix(hashed, "$2y$")
+}
+
+// Every bcrypt hash is 60 bytes long, including its version and cost.
+func (b bcryptHasher) isWellFormed(hashed string) bool {
+	_, err := bcrypt.Cost([]byte(hashed))
+	return err == nil && len(hashed) == 60
+}
+
+// bcrypt rejects anything longer instead of truncating it.
+func (b bcryptHasher) maxInputBytes() int {
+	return 72
+}
@@ -0,0 +1,183 @@
+package logic
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+var (
+	errInvalidArgon2idHash = errors.New("invalid argon2id hash")
+	errArgon2idCostTooHigh = errors.New("argon2id hash costs more than allowed")
+)
+
+// Bounds on the salt and key of the hashes verified.
+const (
+	argon2idMinSaltLength = 8
+	argon2idMaxSaltLength = 64
+	argon2idMinKeyLength  = 16
+	argon2idMaxKeyLength  = 64
+)
+
+// Hashes costing more than this many times the configured parameters are
+// refused, as verifying them would take up the memory and time of the server.
+const argon2idMaxCostFactor = 4
+
+// Fallbacks for parameters left out of the configuration.
+var defaultArgon2idConfig = configs.Argon2id{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Hashes are encoded in the PHC string format:
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>
+type argon2idHasher struct {
+	config configs.Argon2id
+}
+
+func newArgon2idHasher(config configs.Argon2id) *argon2idHasher {
+	if config.Memory == 0 {
+		config.Memory = defaultArgon2idConfig.Memory
+	}
+	if config.Iterations == 0 {
+		config.Iterations = defaultArgon2idConfig.Iterations
+	}
+	if config.Parallelism == 0 {
+		config.Parallelism = defaultArgon2idConfig.Parallelism
+	}
+	if config.SaltLength == 0 {
+		config.SaltLength = defaultArgon2idConfig.SaltLength
+	}
+	if config.KeyLength == 0 {
+		config.KeyLength = defaultArgon2idConfig.KeyLength
+	}
+	return &argon2idHasher{config: config}
+}
+
+type argon2idHash struct {
+	params configs.Argon2id
+	salt   []byte
+	key    []byte
+}
+
+func (a argon2idHasher) hash(input string) (string, error) {
+	salt := make([]byte, a.config.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(input), salt,
+		a.config.Iterations,
+		a.config.Memory,
+		a.config.Parallelism,
+		a.config.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		a.config.Memory,
+		a.config.Iterations,
+		a.config.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (a argon2idHasher) isHashEqual(input string, hashed string) (bool, error) {
+	decoded, err := decodeArgon2idHash(hashed)
+	if err != nil {
+		return false, err
+	}
+	if err := a.checkCosts(decoded.params); err != nil {
+		return false, err
+	}
+
+	key := argon2.IDKey([]byte(input), decoded.salt,
+		decoded.params.Iterations,
+		decoded.params.Memory,
+		decoded.params.Parallelism,
+		decoded.params.KeyLength)
+
+	return subtle.ConstantTimeCompare(key, decoded.key) == 1, nil
+}
+
+func (a argon2idHasher) isOutdated(hashed string) bool {
+	decoded, err := decodeArgon2idHash(hashed)
+	if err != nil {
+		return true
+	}
+	return decoded.params != a.config
+}
+
+func (a argon2idHasher) canVerify(hashed string) bool {
+	return strings.HasPrefix(hashed, argon2idPrefix)
+}
+
+func (a argon2idHasher) isWellFormed(hashed string) bool {
+	decoded, err := decodeArgon2idHash(hashed)
+	return err == nil && a.checkCosts(decoded.params) == nil
+}
+
+func (a argon2idHasher) checkCosts(params configs.Argon2id) error {
+	if uint64(params.Memory) > argon2idMaxCostFactor*uint64(a.config.Memory) ||
+		uint64(params.Iterations) > argon2idMaxCostFactor*uint64(a.config.Iterations) ||
+		uint64(params.Parallelism) > argon2idMaxCostFactor*uint64(a.config.Parallelism) {
+		return errArgon2idCostTooHigh
+	}
+	return nil
+}
+
+func (a argon2idHasher) maxInputBytes() int {
+	return 0
+}
+
+func decodeArgon2idHash(hashed string) (argon2idHash, error) {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, key
+	parts := strings.Split(hashed, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return argon2idHash{}, errInvalidArgon2idHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return argon2idHash{}, errInvalidArgon2idHash
+	}
+
+	var out argon2idHash
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d",
+		&out.params.Memory,
+		&out.params.Iterations,
+		&out.params.Parallelism)
+	if err != nil {
+		return argon2idHash{}, errInvalidArgon2idHash
+	}
+	// argon2.IDKey panics on no iterations or no parallelism
+	if out.params.Iterations < 1 || out.params.Parallelism < 1 {
+		return argon2idHash{}, errInvalidArgon2idHash
+	}
+
+	if out.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return argon2idHash{}, errInvalidArgon2idHash
+	}
+	if out.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return argon2idHash{}, errInvalidArgon2idHash
+	}
+	if len(out.salt) < argon2idMinSaltLength || len(out.salt) > argon2idMaxSaltLength ||
+		len(out.key) < argon2idMinKeyLength || len(out.key) > argon2idMaxKeyLength {
+		return argon2idHash{}, errInvalidArgon2idHash
+	}
+	out.params.SaltLength = uint32(len(out.salt))
+	out.params.KeyLength = uint32(len(out.key))
+
+	return out, nil
+}
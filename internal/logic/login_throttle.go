@@ -0,0 +1,218 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type LoginThrottle interface {
+	// Fails with ResourceExhausted while the username or the address has to
+	// wait before it may try to log in again.
+	CheckLoginAllowed(ctx context.Context, params LoginAttemptParams) error
+	RecordLoginFailure(ctx context.Context, params LoginAttemptParams) error
+	RecordLoginSuccess(ctx context.Context, params LoginAttemptParams) error
+
+	UnlockAccount(ctx context.Context, params UnlockAccountParams) error
+}
+
+type loginThrottle struct {
+	loginFailureAccessor database.LoginFailureAccessor
+	lockoutConfig        configs.Lockout
+	logger               *zap.Logger
+}
+
+func NewLoginThrottle(
+	loginFailureAccessor database.LoginFailureAccessor,
+	lockoutConfig configs.Lockout,
+	logger *zap.Logger,
+) LoginThrottle {
+	return &loginThrottle{
+		loginFailureAccessor: loginFailureAccessor,
+		lockoutConfig:        lockoutConfig,
+		logger:               logger,
+	}
+}
+
+type loginScope struct {
+	scope      database.LoginFailureScope
+	identifier string
+	policy     configs.LockoutPolicy
+}
+
+func (l loginThrottle) scopesOf(params LoginAttemptParams) []loginScope {
+	scopes := make([]loginScope, 0, 2)
+	if params.Username != "" {
+		scopes = append(scopes, loginScope{
+			scope:      database.LoginFailureScopeUsername,
+			identifier: params.Username,
+			policy:     l.lockoutConfig.Account,
+		})
+	}
+	if params.Address != "" {
+		scopes = append(scopes, loginScope{
+			scope:      database.LoginFailureScopeAddress,
+			identifier: params.Address,
+			policy:     l.lockoutConfig.Address,
+		})
+	}
+	return scopes
+}
+
+func isPolicyEnabled(policy configs.LockoutPolicy) bool {
+	return policy.BackoffAfter > 0 || policy.LockAfter > 0
+}
+
+// Returns how long the scope still has to wait before its next attempt.
+func retryDelayOf(lf database.LoginFailure, policy configs.LockoutPolicy, now time.Time) time.Duration {
+	if lf.LockedUntil != nil && now.Before(*lf.LockedUntil) {
+		return lf.LockedUntil.Sub(now)
+	}
+
+	if policy.BackoffAfter == 0 || lf.FailureCount < policy.BackoffAfter {
+		return 0
+	}
+
+	backoff := policy.BackoffBase
+	for i := policy.BackoffAfter; i < lf.FailureCount; i++ {
+		backoff *= 2
+		if policy.BackoffMax > 0 && backoff >= policy.BackoffMax {
+			backoff = policy.BackoffMax
+			break
+		}
+	}
+
+	if waitUntil := lf.LastFailedAt.Add(backoff); now.Before(waitUntil) {
+		return waitUntil.Sub(now)
+	}
+	return 0
+}
+
+func tooManyLoginAttemptsError(retryDelay time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "too many failed login attempts, try again later")
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryDelay.Round(time.Second)),
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func (l loginThrottle) CheckLoginAllowed(
+	ctx context.Context,
+	params LoginAttemptParams,
+) error {
+	now := time.Now()
+	var retryDelay time.Duration
+	for _, s := range l.scopesOf(params) {
+		if !isPolicyEnabled(s.policy) {
+			continue
+		}
+
+		lf, err := l.loginFailureAccessor.GetLoginFailure(ctx, s.scope, s.identifier)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return status.Error(codes.Internal, "failed to get login failures")
+		}
+
+		retryDelay = max(retryDelay, retryDelayOf(lf, s.policy, now))
+	}
+
+	if retryDelay > 0 {
+		return tooManyLoginAttemptsError(retryDelay)
+	}
+	return nil
+}
+
+func (l loginThrottle) RecordLoginFailure(
+	ctx context.Context,
+	params LoginAttemptParams,
+) error {
+	now := time.Now()
+	for _, s := range l.scopesOf(params) {
+		if !isPolicyEnabled(s.policy) {
+			continue
+		}
+
+		// Counted in a single statement, so parallel failures all count
+		var resetBefore *time.Time
+		if s.policy.ResetAfter > 0 {
+			before := now.Add(-s.policy.ResetAfter)
+			resetBefore = &before
+		}
+		lf, err := l.loginFailureAccessor.IncrementLoginFailure(ctx, s.scope, s.identifier, now, resetBefore)
+		if err != nil {
+			return status.Error(codes.Internal, "failed to record login failure")
+		}
+
+		isLocked := lf.LockedUntil != nil && now.Before(*lf.LockedUntil)
+		if s.policy.LockAfter == 0 || lf.FailureCount < s.policy.LockAfter || isLocked {
+			continue
+		}
+
+		// Parallel failures past the threshold race for the lock, which only
+		// one of them takes
+		lockedUntil := now.Add(s.policy.LockDuration)
+		isLockedNow, err := l.loginFailureAccessor.LockLoginFailure(ctx, s.scope, s.identifier, lockedUntil, now)
+		if err != nil {
+			return status.Error(codes.Internal, "failed to record login failure")
+		}
+		if isLockedNow {
+			utils.LoggerWithContext(ctx, l.logger).
+				With(zap.Any("scope", s.scope)).
+				With(zap.Any("identifier", s.identifier)).
+				With(zap.Time("locked_until", lockedUntil)).
+				Warn("locked out after too many failed login attempts")
+		}
+	}
+
+	return nil
+}
+
+// Clears the failures of the username only. Failures of the address are kept,
+// otherwise an attacker could reset its counter by logging into an account of
+// their own between guesses.
+func (l loginThrottle) RecordLoginSuccess(
+	ctx context.Context,
+	params LoginAttemptParams,
+) error {
+	if params.Username == "" {
+		return nil
+	}
+
+	err := l.loginFailureAccessor.
+		DeleteLoginFailure(ctx, database.LoginFailureScopeUsername, params.Username)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to clear login failures")
+	}
+	return nil
+}
+
+func (l loginThrottle) UnlockAccount(
+	ctx context.Context,
+	params UnlockAccountParams,
+) error {
+	if params.Username == "" {
+		return status.Error(codes.InvalidArgument, "username is required")
+	}
+
+	err := l.loginFailureAccessor.
+		DeleteLoginFailure(ctx, database.LoginFailureScopeUsername, params.Username)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to unlock account")
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package logic
+
+type LoginAttemptParams struct {
+	Username string
+	Address  string
+}
+
+type UnlockAccountParams struct {
+	Username string
+}
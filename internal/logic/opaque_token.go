@@ -0,0 +1,24 @@
+package logic
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// Generates a URL-safe random token carrying the given number of random bytes.
+func generateOpaqueToken(byteLength int) (string, error) {
+	buf := make([]byte, byteLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// Opaque tokens are high-entropy, so a fast digest is enough to keep the
+// plaintext out of the database while still allowing lookups by hash.
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
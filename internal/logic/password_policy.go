@@ -0,0 +1,210 @@
+package logic
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Parts of the account info shorter than this are too common to ban.
+const minBannedAccountInfoLength = 3
+
+type PasswordPolicy interface {
+	// Fails with InvalidArgument carrying one errdetails.BadRequest field
+	// violation per broken rule.
+	ValidatePassword(ctx context.Context, params ValidatePasswordParams) error
+}
+
+type passwordPolicy struct {
+	policyConfig configs.PasswordPolicy
+	maxBytes     int
+	// Upper case hex SHA-1 digests of the breached passwords.
+	breached map[string]struct{}
+	logger   *zap.Logger
+}
+
+func NewPasswordPolicy(
+	policyConfig configs.PasswordPolicy,
+	hashLogic Hash,
+	logger *zap.Logger,
+) (PasswordPolicy, error) {
+	maxBytes := policyConfig.MaxBytes
+	if hashMax := hashLogic.MaxInputBytes(); hashMax > 0 &&
+		(maxBytes <= 0 || hashMax < maxBytes) {
+		maxBytes = hashMax
+	}
+
+	breached := map[string]struct{}{}
+	if policyConfig.BreachedPasswordFile != "" {
+		var err error
+		breached, err = loadBreachedPasswords(policyConfig.BreachedPasswordFile)
+		if err != nil {
+			return nil, err
+		}
+		logger.With(zap.Int("breached_passwords", len(breached))).
+			Info("loaded the breached password list")
+	}
+
+	return &passwordPolicy{
+		policyConfig: policyConfig,
+		maxBytes:     maxBytes,
+		breached:     breached,
+		logger:       logger,
+	}, nil
+}
+
+func loadBreachedPasswords(filePath string) (map[string]struct{}, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open breached password file: %w", err)
+	}
+	defer file.Close()
+
+	breached := map[string]struct{}{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		digest, _, _ := strings.Cut(line, ":")
+		if !isSha1Hex(digest) {
+			digest = sha1Hex(line)
+		}
+		breached[strings.ToUpper(digest)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read breached password file: %w", err)
+	}
+
+	return breached, nil
+}
+
+func isSha1Hex(s string) bool {
+	if len(s) != sha1.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// Lists the lower cased parts of the account info that must not appear in the
+// password.
+func bannedAccountInfoOf(params ValidatePasswordParams) []string {
+	candidates := []string{
+		params.Username,
+		strings.Join(strings.Fields(params.Fullname), ""),
+		params.Email,
+	}
+	if local, _, ok := strings.Cut(params.Email, "@"); ok {
+		candidates = append(candidates, local)
+	}
+
+	banned := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidate = strings.ToLower(strings.TrimSpace(candidate))
+		if utf8.RuneCountInString(candidate) >= minBannedAccountInfoLength {
+			banned = append(banned, candidate)
+		}
+	}
+	return banned
+}
+
+func (p passwordPolicy) ValidatePassword(
+	_ context.Context,
+	params ValidatePasswordParams,
+) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	violate := func(reason string, description string) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "password",
+			Reason:      reason,
+			Description: description,
+		})
+	}
+
+	password := params.Password
+	if !utf8.ValidString(password) {
+		violate("PASSWORD_INVALID_ENCODING", "password must be valid UTF-8")
+	}
+	if length := utf8.RuneCountInString(password); length < p.policyConfig.MinLength || length == 0 {
+		violate("PASSWORD_TOO_SHORT",
+			fmt.Sprintf("password must be at least %d characters long", max(p.policyConfig.MinLength, 1)))
+	}
+	if p.maxBytes > 0 && len(password) > p.maxBytes {
+		violate("PASSWORD_TOO_LONG",
+			fmt.Sprintf("password must be at most %d bytes long", p.maxBytes))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.policyConfig.RequireUppercase && !hasUpper {
+		violate("PASSWORD_MISSING_UPPERCASE", "password must contain an uppercase letter")
+	}
+	if p.policyConfig.RequireLowercase && !hasLower {
+		violate("PASSWORD_MISSING_LOWERCASE", "password must contain a lowercase letter")
+	}
+	if p.policyConfig.RequireDigit && !hasDigit {
+		violate("PASSWORD_MISSING_DIGIT", "password must contain a digit")
+	}
+	if p.policyConfig.RequireSymbol && !hasSymbol {
+		violate("PASSWORD_MISSING_SYMBOL", "password must contain a symbol")
+	}
+
+	if p.policyConfig.DisallowAccountInfo {
+		lowered := strings.ToLower(password)
+		for _, banned := range bannedAccountInfoOf(params) {
+			if strings.Contains(lowered, banned) {
+				violate("PASSWORD_CONTAINS_ACCOUNT_INFO",
+					"password must not contain the username, fullname or email")
+				break
+			}
+		}
+	}
+
+	if _, ok := p.breached[sha1Hex(password)]; ok {
+		violate("PASSWORD_BREACHED", "password has appeared in a data breach")
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return passwordViolationError(violations...)
+}
+
+func passwordViolationError(violations ...*errdetails.BadRequest_FieldViolation) error {
+	st := status.New(codes.InvalidArgument, "password does not satisfy the password policy")
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
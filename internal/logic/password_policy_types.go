@@ -0,0 +1,8 @@
+package logic
+
+type ValidatePasswordParams struct {
+	Password string
+	Username string
+	Fullname string
+	Email    string
+}
@@ -0,0 +1,51 @@
+package logic
+
+import (
+	"strings"
+)
+
+const (
+	minE164Digits = 7
+	maxE164Digits = 15
+)
+
+// Brings a phone number into E.164 form. Common separators are dropped, the
+// international "00" prefix becomes "+", and a number in national format gets
+// the default country code in place of its trunk prefix "0". An empty number
+// stays empty, since the phone number of an account is optional.
+func normalizePhoneNumber(raw string, defaultCountryCode string) (string, error) {
+	number := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')', '/', '\t':
+			return -1
+		}
+		return r
+	}, raw)
+	if number == "" {
+		return "", nil
+	}
+
+	var digits string
+	switch {
+	case strings.HasPrefix(number, "+"):
+		digits = number[1:]
+	case strings.HasPrefix(number, "00"):
+		digits = number[2:]
+	default:
+		if defaultCountryCode == "" {
+			return "", ErrPhoneNumberInvalid
+		}
+		digits = strings.TrimPrefix(defaultCountryCode, "+") + strings.TrimPrefix(number, "0")
+	}
+
+	if len(digits) < minE164Digits || len(digits) > maxE164Digits || digits[0] == '0' {
+		return "", ErrPhoneNumberInvalid
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", ErrPhoneNumberInvalid
+		}
+	}
+
+	return "+" + digits, nil
+}
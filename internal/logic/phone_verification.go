@@ -0,0 +1,131 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/dataaccess/notifier"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type PhoneVerification interface {
+	// Texts a one-time code to the current phone number of the account.
+	SendPhoneVerification(ctx context.Context, params SendPhoneVerificationParams) (SendPhoneVerificationOutput, error)
+	VerifyPhone(ctx context.Context, params VerifyPhoneParams) (VerifyPhoneOutput, error)
+}
+
+type phoneVerification struct {
+	db              *sql.DB
+	accountAccessor database.AccountAccessor
+	smsSender       notifier.SMSSender
+	codes           verificationCodes
+	logger          *zap.Logger
+}
+
+func NewPhoneVerification(
+	db *sql.DB,
+	accountAccessor database.AccountAccessor,
+	verificationCodeAccessor database.VerificationCodeAccessor,
+	smsSender notifier.SMSSender,
+	phoneVerificationConfig configs.VerificationCode,
+	logger *zap.Logger,
+) PhoneVerification {
+	return &phoneVerification{
+		db:              db,
+		accountAccessor: accountAccessor,
+		smsSender:       smsSender,
+		codes: verificationCodes{
+			verificationCodeAccessor: verificationCodeAccessor,
+			codeConfig:               phoneVerificationConfig,
+			logger:                   logger,
+		},
+		logger: logger,
+	}
+}
+
+func (p phoneVerification) SendPhoneVerification(
+	ctx context.Context,
+	params SendPhoneVerificationParams,
+) (SendPhoneVerificationOutput, error) {
+	emptyObj := SendPhoneVerificationOutput{}
+	acc, err := p.accountAccessor.GetAccount(ctx, params.AccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.NotFound, "account not found")
+	}
+	if acc.PhoneNumber == "" {
+		return emptyObj, status.Error(codes.FailedPrecondition, "account has no phone number")
+	}
+	if acc.PhoneVerifiedAt != nil {
+		return emptyObj, ErrPhoneAlreadyVerified
+	}
+
+	code, expiresAt, err := p.codes.issue(ctx, acc.Id, database.VerificationChannelPhone, acc.PhoneNumber)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	err = p.smsSender.SendSMS(ctx, acc.PhoneNumber,
+		fmt.Sprintf("Your verification code is %s. It expires in %s.",
+			code, time.Until(expiresAt).Round(time.Minute)))
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to send phone verification")
+	}
+
+	return SendPhoneVerificationOutput{
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (p phoneVerification) VerifyPhone(
+	ctx context.Context,
+	params VerifyPhoneParams,
+) (VerifyPhoneOutput, error) {
+	emptyObj := VerifyPhoneOutput{}
+	if params.Code == "" {
+		return emptyObj, ErrVerificationCodeInvalid
+	}
+
+	tx, err := p.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return emptyObj, ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	acc, err := p.accountAccessor.
+		WithExecutor(tx).
+		GetAccount(ctx, params.AccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.NotFound, "account not found")
+	}
+	if acc.PhoneVerifiedAt != nil {
+		return emptyObj, ErrPhoneAlreadyVerified
+	}
+
+	err = p.codes.redeem(ctx, tx, acc.Id, database.VerificationChannelPhone, acc.PhoneNumber, params.Code)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	verifiedAt := time.Now()
+	err = p.accountAccessor.
+		WithExecutor(tx).
+		SetAccountPhoneVerified(ctx, acc.Id, acc.PhoneNumber, verifiedAt)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to set phone verified")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return emptyObj, ErrTxCommitFailed
+	}
+
+	return VerifyPhoneOutput{
+		AccountId:       acc.Id,
+		PhoneVerifiedAt: verifiedAt,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package logic
+
+import "time"
+
+type SendPhoneVerificationParams struct {
+	AccountId uint64
+}
+
+type SendPhoneVerificationOutput struct {
+	ExpiresAt time.Time
+}
+
+type VerifyPhoneParams struct {
+	AccountId uint64
+	Code      string
+}
+
+type VerifyPhoneOutput struct {
+	AccountId       uint64
+	PhoneVerifiedAt time.Time
+}
@@ -0,0 +1,198 @@
+package logic
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRecoveryCodeCount = 10
+	recoveryCodeLength       = 10
+	// Lower case letters and digits without the look-alikes 0, o, 1, l and i.
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+type RecoveryCode interface {
+	// Replaces every earlier code of the account. Only their hashes are kept,
+	// so the codes are shown this once.
+	GenerateRecoveryCodes(ctx context.Context, params GenerateRecoveryCodesParams) (GenerateRecoveryCodesOutput, error)
+	// Consumes the matching unused code of the account.
+	UseRecoveryCode(ctx context.Context, params UseRecoveryCodeParams) (UseRecoveryCodeOutput, error)
+}
+
+type recoveryCode struct {
+	db                   *sql.DB
+	recoveryCodeAccessor database.RecoveryCodeAccessor
+	totpLogic            TOTP
+	hashLogic            Hash
+	secondFactorConfig   configs.SecondFactor
+	logger               *zap.Logger
+}
+
+func NewRecoveryCode(
+	db *sql.DB,
+	recoveryCodeAccessor database.RecoveryCodeAccessor,
+	totpLogic TOTP,
+	hashLogic Hash,
+	secondFactorConfig configs.SecondFactor,
+	logger *zap.Logger,
+) RecoveryCode {
+	return &recoveryCode{
+		db:                   db,
+		recoveryCodeAccessor: recoveryCodeAccessor,
+		totpLogic:            totpLogic,
+		hashLogic:            hashLogic,
+		secondFactorConfig:   secondFactorConfig,
+		logger:               logger,
+	}
+}
+
+func generateRecoveryCode() (string, error) {
+	var sb strings.Builder
+	alphabetSize := big.NewInt(int64(len(recoveryCodeAlphabet)))
+	for range recoveryCodeLength {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(recoveryCodeAlphabet[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+// Splits a code in two halves so it is easier to copy down.
+func formatRecoveryCode(code string) string {
+	return code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:]
+}
+
+// Undoes formatRecoveryCode and whatever case or spacing the user typed.
+func normalizeRecoveryCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+}
+
+func (r recoveryCode) count() int {
+	if r.secondFactorConfig.RecoveryCodeCount <= 0 {
+		return defaultRecoveryCodeCount
+	}
+	return r.secondFactorConfig.RecoveryCodeCount
+}
+
+func (r recoveryCode) GenerateRecoveryCodes(
+	ctx context.Context,
+	params GenerateRecoveryCodesParams,
+) (GenerateRecoveryCodesOutput, error) {
+	emptyObj := GenerateRecoveryCodesOutput{}
+	isEnabled, err := r.totpLogic.IsTOTPEnabled(ctx, params.AccountId)
+	if err != nil {
+		return emptyObj, err
+	}
+	if !isEnabled {
+		return emptyObj, ErrSecondFactorNotEnabled
+	}
+
+	// Hashed before the transaction opens, the hashing is the slow part.
+	plainCodes := make([]string, 0, r.count())
+	hashedCodes := make([]string, 0, r.count())
+	for range r.count() {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return emptyObj, status.Error(codes.Internal, "failed to generate recovery code")
+		}
+		hashed, err := r.hashLogic.Hash(ctx, code)
+		if err != nil {
+			return emptyObj, status.Error(codes.Internal, "failed to hash recovery code")
+		}
+		plainCodes = append(plainCodes, formatRecoveryCode(code))
+		hashedCodes = append(hashedCodes, hashed)
+	}
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return emptyObj, ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	err = r.recoveryCodeAccessor.
+		WithExecutor(tx).
+		DeleteRecoveryCodeOfAccount(ctx, params.AccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to delete recovery codes")
+	}
+	for _, hashed := range hashedCodes {
+		_, err = r.recoveryCodeAccessor.
+			WithExecutor(tx).
+			CreateRecoveryCode(ctx, database.RecoveryCode{
+				OfAccountId: params.AccountId,
+				HashedCode:  hashed,
+			})
+		if err != nil {
+			return emptyObj, status.Error(codes.Internal, "failed to create recovery code")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return emptyObj, ErrTxCommitFailed
+	}
+
+	return GenerateRecoveryCodesOutput{
+		Codes: plainCodes,
+	}, nil
+}
+
+// The hashes are salted, so the code is verified against every unused one.
+func (r recoveryCode) UseRecoveryCode(
+	ctx context.Context,
+	params UseRecoveryCodeParams,
+) (UseRecoveryCodeOutput, error) {
+	emptyObj := UseRecoveryCodeOutput{}
+	code := normalizeRecoveryCode(params.Code)
+	if len(code) != recoveryCodeLength {
+		return emptyObj, nil
+	}
+
+	unused, err := r.recoveryCodeAccessor.GetUnusedRecoveryCodeList(ctx, params.AccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to get recovery codes")
+	}
+
+	for _, rc := range unused {
+		isEqual, err := r.hashLogic.IsHashEqual(ctx, code, rc.HashedCode)
+		if err != nil {
+			utils.LoggerWithContext(ctx, r.logger).
+				With(zap.Any("id", rc.Id)).
+				With(zap.Error(err)).
+				Warn("failed to verify recovery code")
+			continue
+		}
+		if !isEqual {
+			continue
+		}
+
+		// Fails when a concurrent request used the same code first
+		if err := r.recoveryCodeAccessor.UseRecoveryCode(ctx, rc.Id, time.Now()); err != nil {
+			return emptyObj, nil
+		}
+		return UseRecoveryCodeOutput{
+			IsUsed:         true,
+			RemainingCount: len(unused) - 1,
+		}, nil
+	}
+
+	return emptyObj, nil
+}
@@ -0,0 +1,20 @@
+package logic
+
+type GenerateRecoveryCodesParams struct {
+	AccountId uint64
+}
+
+type GenerateRecoveryCodesOutput struct {
+	Codes []string
+}
+
+type UseRecoveryCodeParams struct {
+	AccountId uint64
+	Code      string
+}
+
+type UseRecoveryCodeOutput struct {
+	// False when no unused code of the account matches.
+	IsUsed         bool
+	RemainingCount int
+}
@@ -0,0 +1,210 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	refreshTokenByteLength  = 32
+	refreshFamilyByteLength = 16
+)
+
+type RefreshToken interface {
+	// Starts a token family for the account id it is given, which the caller
+	// must have authenticated.
+	IssueRefreshToken(ctx context.Context, params IssueRefreshTokenParams) (IssueRefreshTokenOutput, error)
+	RotateRefreshToken(ctx context.Context, params RotateRefreshTokenParams) (RotateRefreshTokenOutput, error)
+	RevokeRefreshToken(ctx context.Context, params RevokeRefreshTokenParams) error
+}
+
+type refreshToken struct {
+	db                   *sql.DB
+	accountAccessor      database.AccountAccessor
+	refreshTokenAccessor database.RefreshTokenAccessor
+	refreshTokenConfig   configs.RefreshToken
+	logger               *zap.Logger
+}
+
+func NewRefreshToken(
+	db *sql.DB,
+	accountAccessor database.AccountAccessor,
+	refreshTokenAccessor database.RefreshTokenAccessor,
+	refreshTokenConfig configs.RefreshToken,
+	logger *zap.Logger,
+) RefreshToken {
+	return &refreshToken{
+		db:                   db,
+		accountAccessor:      accountAccessor,
+		refreshTokenAccessor: refreshTokenAccessor,
+		refreshTokenConfig:   refreshTokenConfig,
+		logger:               logger,
+	}
+}
+
+// Creates a new token for the given family and returns its plaintext form.
+// Only the hash of the token is persisted.
+func (r refreshToken) createToken(
+	ctx context.Context,
+	exec database.Executor,
+	accountId uint64,
+	familyId string,
+) (string, time.Time, error) {
+	token, err := generateOpaqueToken(refreshTokenByteLength)
+	if err != nil {
+		return "", time.Time{}, status.Error(codes.Internal, "failed to generate refresh token")
+	}
+
+	expiresAt := time.Now().Add(r.refreshTokenConfig.TTL)
+	_, err = r.refreshTokenAccessor.
+		WithExecutor(exec).
+		CreateRefreshToken(ctx, database.RefreshToken{
+			OfAccountId: accountId,
+			FamilyId:    familyId,
+			HashedToken: hashOpaqueToken(token),
+			ExpiresAt:   expiresAt,
+		})
+	if err != nil {
+		return "", time.Time{}, status.Error(codes.Internal, "failed to create refresh token")
+	}
+
+	return token, expiresAt, nil
+}
+
+func (r refreshToken) IssueRefreshToken(
+	ctx context.Context,
+	params IssueRefreshTokenParams,
+) (IssueRefreshTokenOutput, error) {
+	emptyObj := IssueRefreshTokenOutput{}
+	if _, err := r.accountAccessor.GetAccount(ctx, params.AccountId); err != nil {
+		return emptyObj, status.Error(codes.NotFound, "failed to get account")
+	}
+
+	familyId, err := generateOpaqueToken(refreshFamilyByteLength)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to generate refresh token family")
+	}
+
+	token, expiresAt, err := r.createToken(ctx, r.db, params.AccountId, familyId)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	return IssueRefreshTokenOutput{
+		RefreshToken: token,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+func (r refreshToken) RotateRefreshToken(
+	ctx context.Context,
+	params RotateRefreshTokenParams,
+) (RotateRefreshTokenOutput, error) {
+	emptyObj := RotateRefreshTokenOutput{}
+	logger := utils.LoggerWithContext(ctx, r.logger)
+	if params.RefreshToken == "" {
+		return emptyObj, ErrRefreshTokenInvalid
+	}
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return emptyObj, ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	current, err := r.refreshTokenAccessor.
+		WithExecutor(tx).
+		GetRefreshTokenByHashedToken(ctx, hashOpaqueToken(params.RefreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return emptyObj, ErrRefreshTokenInvalid
+		}
+		return emptyObj, status.Error(codes.Internal, "failed to get refresh token")
+	}
+
+	now := time.Now()
+	if current.RevokedAt != nil {
+		return emptyObj, ErrRefreshTokenRevoked
+	}
+
+	// A rotated token being presented again means it has leaked, so the whole
+	// family is revoked and every holder has to authenticate from scratch.
+	if current.RotatedAt != nil {
+		err = r.refreshTokenAccessor.
+			WithExecutor(tx).
+			RevokeRefreshTokenFamily(ctx, current.FamilyId, now)
+		if err != nil {
+			return emptyObj, status.Error(codes.Internal, "failed to revoke refresh token family")
+		}
+		if err = tx.Commit(); err != nil {
+			return emptyObj, ErrTxCommitFailed
+		}
+		logger.With(zap.Any("of_account_id", current.OfAccountId)).
+			With(zap.Any("family_id", current.FamilyId)).
+			Warn("refresh token reuse detected, revoked the token family")
+		return emptyObj, ErrRefreshTokenReused
+	}
+
+	if !now.Before(current.ExpiresAt) {
+		return emptyObj, ErrRefreshTokenExpired
+	}
+
+	err = r.refreshTokenAccessor.
+		WithExecutor(tx).
+		RotateRefreshToken(ctx, current.Id, now)
+	if err != nil {
+		// Lost the race against a concurrent rotation of the same token.
+		return emptyObj, ErrRefreshTokenInvalid
+	}
+
+	token, expiresAt, err := r.createToken(ctx, tx, current.OfAccountId, current.FamilyId)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return emptyObj, ErrTxCommitFailed
+	}
+
+	return RotateRefreshTokenOutput{
+		AccountId:    current.OfAccountId,
+		RefreshToken: token,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+func (r refreshToken) RevokeRefreshToken(
+	ctx context.Context,
+	params RevokeRefreshTokenParams,
+) error {
+	if params.RefreshToken == "" {
+		return ErrRefreshTokenInvalid
+	}
+
+	current, err := r.refreshTokenAccessor.
+		GetRefreshTokenByHashedToken(ctx, hashOpaqueToken(params.RefreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRefreshTokenInvalid
+		}
+		return status.Error(codes.Internal, "failed to get refresh token")
+	}
+
+	// Logging out ends the whole session, not only its latest token.
+	err = r.refreshTokenAccessor.
+		RevokeRefreshTokenFamily(ctx, current.FamilyId, time.Now())
+	if err != nil {
+		return status.Error(codes.Internal, "failed to revoke refresh token")
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package logic
+
+import "time"
+
+type IssueRefreshTokenParams struct {
+	AccountId uint64
+}
+
+type IssueRefreshTokenOutput struct {
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+type RotateRefreshTokenParams struct {
+	RefreshToken string
+}
+
+type RotateRefreshTokenOutput struct {
+	AccountId    uint64
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+type RevokeRefreshTokenParams struct {
+	RefreshToken string
+}
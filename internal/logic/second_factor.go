@@ -0,0 +1,243 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// The errdetails.ErrorInfo domain of the errors this service explains.
+	ErrorDomain = "fiagram.account_service"
+	// The errdetails.ErrorInfo reason telling a client to finish the login
+	// with VerifySecondFactor. The challenge id is in the metadata.
+	ReasonSecondFactorRequired = "SECOND_FACTOR_REQUIRED"
+)
+
+const (
+	loginChallengeByteLength         = 32
+	defaultLoginChallengeMaxAttempts = 5
+	secondFactorMethodTOTP           = "totp"
+	secondFactorMetadataChallengeId  = "challenge_id"
+	secondFactorMetadataExpiresAt    = "expires_at"
+	secondFactorMetadataMethods      = "methods"
+)
+
+type SecondFactor interface {
+	// Opens a login challenge when the account has a second factor enabled.
+	BeginSecondFactorChallenge(ctx context.Context, params BeginSecondFactorChallengeParams) (BeginSecondFactorChallengeOutput, error)
+	// Completes a login challenge with a code of the second factor.
+	VerifySecondFactor(ctx context.Context, params VerifySecondFactorParams) (VerifySecondFactorOutput, error)
+}
+
+type secondFactor struct {
+	accountAccessor        database.AccountAccessor
+	loginChallengeAccessor database.LoginChallengeAccessor
+	totpLogic              TOTP
+	recoveryCodeLogic      RecoveryCode
+	loginThrottleLogic     LoginThrottle
+	secondFactorConfig     configs.SecondFactor
+	logger                 *zap.Logger
+}
+
+func NewSecondFactor(
+	accountAccessor database.AccountAccessor,
+	loginChallengeAccessor database.LoginChallengeAccessor,
+	totpLogic TOTP,
+	recoveryCodeLogic RecoveryCode,
+	loginThrottleLogic LoginThrottle,
+	secondFactorConfig configs.SecondFactor,
+	logger *zap.Logger,
+) SecondFactor {
+	return &secondFactor{
+		accountAccessor:        accountAccessor,
+		loginChallengeAccessor: loginChallengeAccessor,
+		totpLogic:              totpLogic,
+		recoveryCodeLogic:      recoveryCodeLogic,
+		loginThrottleLogic:     loginThrottleLogic,
+		secondFactorConfig:     secondFactorConfig,
+		logger:                 logger,
+	}
+}
+
+// The error a password check answers with while a second factor is pending.
+// It is an error rather than a successful response, so a client unaware of
+// second factors cannot mistake it for a finished login.
+func secondFactorRequiredError(challenge BeginSecondFactorChallengeOutput) error {
+	st := status.New(codes.Unauthenticated, "second factor required")
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: ReasonSecondFactorRequired,
+		Domain: ErrorDomain,
+		Metadata: map[string]string{
+			secondFactorMetadataChallengeId: challenge.ChallengeId,
+			secondFactorMetadataExpiresAt:   challenge.ExpiresAt.UTC().Format(time.RFC3339),
+			secondFactorMetadataMethods:     secondFactorMethodTOTP,
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func (s secondFactor) maxAttempts() uint32 {
+	if s.secondFactorConfig.MaxAttempts == 0 {
+		return defaultLoginChallengeMaxAttempts
+	}
+	return s.secondFactorConfig.MaxAttempts
+}
+
+func (s secondFactor) BeginSecondFactorChallenge(
+	ctx context.Context,
+	params BeginSecondFactorChallengeParams,
+) (BeginSecondFactorChallengeOutput, error) {
+	emptyObj := BeginSecondFactorChallengeOutput{}
+	isEnabled, err := s.totpLogic.IsTOTPEnabled(ctx, params.AccountId)
+	if err != nil {
+		return emptyObj, err
+	}
+	if !isEnabled {
+		return emptyObj, nil
+	}
+
+	challengeId, err := generateOpaqueToken(loginChallengeByteLength)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to generate login challenge")
+	}
+
+	expiresAt := time.Now().Add(s.secondFactorConfig.ChallengeTTL)
+	_, err = s.loginChallengeAccessor.CreateLoginChallenge(ctx, database.LoginChallenge{
+		OfAccountId:     params.AccountId,
+		HashedChallenge: hashOpaqueToken(challengeId),
+		ExpiresAt:       expiresAt,
+	})
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to create login challenge")
+	}
+
+	return BeginSecondFactorChallengeOutput{
+		IsRequired:  true,
+		ChallengeId: challengeId,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// Wrong codes count both on the challenge and on the login throttle, so
+// opening new challenges does not buy an attacker more guesses.
+func (s secondFactor) VerifySecondFactor(
+	ctx context.Context,
+	params VerifySecondFactorParams,
+) (VerifySecondFactorOutput, error) {
+	emptyObj := VerifySecondFactorOutput{}
+	logger := utils.LoggerWithContext(ctx, s.logger)
+	if params.ChallengeId == "" {
+		return emptyObj, ErrLoginChallengeInvalid
+	}
+
+	lc, err := s.loginChallengeAccessor.
+		GetLoginChallengeByHashedChallenge(ctx, hashOpaqueToken(params.ChallengeId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return emptyObj, ErrLoginChallengeInvalid
+		}
+		return emptyObj, status.Error(codes.Internal, "failed to get login challenge")
+	}
+	if lc.UsedAt != nil {
+		return emptyObj, ErrLoginChallengeInvalid
+	}
+	if time.Now().After(lc.ExpiresAt) {
+		return emptyObj, ErrLoginChallengeExpired
+	}
+	if lc.FailedAttempts >= s.maxAttempts() {
+		return emptyObj, ErrLoginChallengeAttemptsExceeded
+	}
+
+	acc, err := s.accountAccessor.GetAccount(ctx, lc.OfAccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to get account")
+	}
+	// The account may have been suspended since the password was checked
+	if err := checkAccountStatus(acc); err != nil {
+		return emptyObj, err
+	}
+
+	attempt := LoginAttemptParams{
+		Username: acc.Username,
+		Address:  params.Address,
+	}
+	if err := s.loginThrottleLogic.CheckLoginAllowed(ctx, attempt); err != nil {
+		return emptyObj, err
+	}
+
+	output, err := s.checkCode(ctx, acc.Id, params.Code)
+	if err != nil {
+		return emptyObj, err
+	}
+	if !output.isValid {
+		if err := s.loginChallengeAccessor.IncreaseLoginChallengeFailedAttempts(ctx, lc.Id); err != nil {
+			logger.With(zap.Error(err)).Warn("failed to count wrong second factor code")
+		}
+		if err := s.loginThrottleLogic.RecordLoginFailure(ctx, attempt); err != nil {
+			logger.With(zap.Error(err)).Warn("failed to record login failure")
+		}
+		return emptyObj, ErrSecondFactorInvalid
+	}
+
+	if err := s.loginChallengeAccessor.UseLoginChallenge(ctx, lc.Id, time.Now()); err != nil {
+		return emptyObj, ErrLoginChallengeInvalid
+	}
+
+	if err := s.loginThrottleLogic.RecordLoginSuccess(ctx, attempt); err != nil {
+		logger.With(zap.Error(err)).Warn("failed to clear login failures")
+	}
+
+	return VerifySecondFactorOutput{
+		AccountId:              acc.Id,
+		IsRecoveryCodeUsed:     output.isRecoveryCode,
+		RemainingRecoveryCodes: output.remainingRecoveryCodes,
+	}, nil
+}
+
+type secondFactorCheck struct {
+	isValid                bool
+	isRecoveryCode         bool
+	remainingRecoveryCodes int
+}
+
+// TOTP codes are all digits of a fixed length, which no recovery code is, so
+// the shape of the code tells which factor to check.
+func (s secondFactor) checkCode(
+	ctx context.Context,
+	accountId uint64,
+	code string,
+) (secondFactorCheck, error) {
+	if isTOTPCodeShaped(code) {
+		isValid, err := s.totpLogic.VerifyTOTPCode(ctx, accountId, code)
+		if err != nil {
+			return secondFactorCheck{}, err
+		}
+		return secondFactorCheck{isValid: isValid}, nil
+	}
+
+	used, err := s.recoveryCodeLogic.UseRecoveryCode(ctx, UseRecoveryCodeParams{
+		AccountId: accountId,
+		Code:      code,
+	})
+	if err != nil {
+		return secondFactorCheck{}, err
+	}
+	return secondFactorCheck{
+		isValid:                used.IsUsed,
+		isRecoveryCode:         used.IsUsed,
+		remainingRecoveryCodes: used.RemainingCount,
+	}, nil
+}
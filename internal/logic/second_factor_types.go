@@ -0,0 +1,29 @@
+package logic
+
+import "time"
+
+type BeginSecondFactorChallengeParams struct {
+	AccountId uint64
+}
+
+type BeginSecondFactorChallengeOutput struct {
+	// False when the account has no second factor, the other fields are
+	// empty then.
+	IsRequired  bool
+	ChallengeId string
+	ExpiresAt   time.Time
+}
+
+type VerifySecondFactorParams struct {
+	ChallengeId string
+	// Either a TOTP code or a recovery code.
+	Code string
+	// The peer address of the caller, used to throttle wrong codes.
+	Address string
+}
+
+type VerifySecondFactorOutput struct {
+	AccountId              uint64
+	IsRecoveryCodeUsed     bool
+	RemainingRecoveryCodes int
+}
@@ -0,0 +1,58 @@
+package logic
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+const secretBoxKeyLength = 32
+
+// Encrypts small secrets with AES-256-GCM before they are stored. A sealed
+// secret is the base64 encoding of the nonce followed by the ciphertext. The
+// associated data binds it to the row it is stored in, so it does not open
+// once moved to another.
+type secretBox struct {
+	aead cipher.AEAD
+}
+
+func newSecretBox(key []byte) (secretBox, error) {
+	if len(key) != secretBoxKeyLength {
+		return secretBox{}, fmt.Errorf("Failed to create secret box: key must be %d bytes", secretBoxKeyLength)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return secretBox{}, fmt.Errorf("Failed to create secret box: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return secretBox{}, fmt.Errorf("Failed to create secret box: %w", err)
+	}
+
+	return secretBox{aead: aead}, nil
+}
+
+func (b secretBox) seal(plaintext []byte, associatedData []byte) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := b.aead.Seal(nonce, nonce, plaintext, associatedData)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (b secretBox) open(sealed string, associatedData []byte) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) < b.aead.NonceSize() {
+		return nil, errors.New("sealed secret is too short")
+	}
+	nonce, ciphertext := raw[:b.aead.NonceSize()], raw[b.aead.NonceSize():]
+	return b.aead.Open(nil, nonce, ciphertext, associatedData)
+}
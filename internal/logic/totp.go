@@ -0,0 +1,392 @@
+package logic
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The parameters every common authenticator app supports.
+const (
+	totpSecretByteLength = 20
+	totpDigits           = 6
+	totpPeriod           = 30 * time.Second
+)
+
+var totpSecretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type TOTP interface {
+	// Starts an enrollment, replacing one that was never confirmed.
+	EnrollTOTP(ctx context.Context, params EnrollTOTPParams) (EnrollTOTPOutput, error)
+	// Turns TOTP on once the account holder proves their app produces codes.
+	ConfirmTOTP(ctx context.Context, params ConfirmTOTPParams) error
+	// Discards the recovery codes as well.
+	DisableTOTP(ctx context.Context, params DisableTOTPParams) error
+
+	IsTOTPEnabled(ctx context.Context, accountId uint64) (bool, error)
+	// Accepts every code at most once.
+	VerifyTOTPCode(ctx context.Context, accountId uint64, code string) (bool, error)
+}
+
+type totp struct {
+	db                   *sql.DB
+	accountAccessor      database.AccountAccessor
+	accountTOTPAccessor  database.AccountTOTPAccessor
+	recoveryCodeAccessor database.RecoveryCodeAccessor
+	totpConfig           configs.TOTP
+	// Nil when no encryption key is configured, which refuses enrollments.
+	box    *secretBox
+	logger *zap.Logger
+}
+
+func NewTOTP(
+	db *sql.DB,
+	accountAccessor database.AccountAccessor,
+	accountTOTPAccessor database.AccountTOTPAccessor,
+	recoveryCodeAccessor database.RecoveryCodeAccessor,
+	totpConfig configs.TOTP,
+	logger *zap.Logger,
+) (TOTP, error) {
+	var box *secretBox
+	if totpConfig.EncryptionKey != "" {
+		key, err := base64.StdEncoding.DecodeString(totpConfig.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode TOTP encryption key: %w", err)
+		}
+		configured, err := newSecretBox(key)
+		if err != nil {
+			return nil, err
+		}
+		box = &configured
+	} else {
+		// Secrets sealed under an ephemeral key would not open after a
+		// restart, locking their accounts out
+		logger.Warn("no TOTP encryption key configured, TOTP enrollment is refused")
+	}
+
+	return &totp{
+		db:                   db,
+		accountAccessor:      accountAccessor,
+		accountTOTPAccessor:  accountTOTPAccessor,
+		recoveryCodeAccessor: recoveryCodeAccessor,
+		totpConfig:           totpConfig,
+		box:                  box,
+		logger:               logger,
+	}, nil
+}
+
+// Binds a sealed secret to the account it belongs to.
+func totpSecretAssociatedData(accountId uint64) []byte {
+	return binary.BigEndian.AppendUint64([]byte("totp:"), accountId)
+}
+
+// Computes the RFC 6238 code of the secret for the given time step.
+func totpCodeAt(secret []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1_000_000)
+}
+
+func isTOTPCodeShaped(code string) bool {
+	if len(code) != totpDigits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func totpStepAt(t time.Time) int64 {
+	return t.Unix() / int64(totpPeriod/time.Second)
+}
+
+// Looks for the step within the allowed skew whose code matches. Steps up to
+// the last accepted one are skipped, so a code cannot be replayed.
+func (t totp) matchStep(secret []byte, code string, lastUsedStep int64) (int64, bool) {
+	current := totpStepAt(time.Now())
+	skew := int64(t.totpConfig.Skew)
+	for step := current - skew; step <= current+skew; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCodeAt(secret, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func (t totp) otpauthURI(username string, secret string) string {
+	label := url.PathEscape(username)
+	if t.totpConfig.Issuer != "" {
+		label = url.PathEscape(t.totpConfig.Issuer) + ":" + label
+	}
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	if t.totpConfig.Issuer != "" {
+		query.Set("issuer", t.totpConfig.Issuer)
+	}
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(int(totpPeriod/time.Second)))
+
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+func (t totp) EnrollTOTP(
+	ctx context.Context,
+	params EnrollTOTPParams,
+) (EnrollTOTPOutput, error) {
+	emptyObj := EnrollTOTPOutput{}
+	if t.box == nil {
+		return emptyObj, ErrTOTPNotConfigured
+	}
+
+	tx, err := t.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return emptyObj, ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	acc, err := t.accountAccessor.
+		WithExecutor(tx).
+		GetAccount(ctx, params.AccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.NotFound, "account not found")
+	}
+
+	existing, err := t.accountTOTPAccessor.
+		WithExecutor(tx).
+		GetAccountTOTP(ctx, acc.Id)
+	if err == nil && existing.ConfirmedAt != nil {
+		return emptyObj, ErrTOTPAlreadyEnabled
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return emptyObj, status.Error(codes.Internal, "failed to get totp")
+	}
+
+	secret := make([]byte, totpSecretByteLength)
+	if _, err := rand.Read(secret); err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to generate totp secret")
+	}
+	sealed, err := t.box.seal(secret, totpSecretAssociatedData(acc.Id))
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to encrypt totp secret")
+	}
+
+	err = t.accountTOTPAccessor.
+		WithExecutor(tx).
+		DeleteAccountTOTP(ctx, acc.Id)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to delete totp")
+	}
+	err = t.accountTOTPAccessor.
+		WithExecutor(tx).
+		CreateAccountTOTP(ctx, database.AccountTOTP{
+			OfAccountId:     acc.Id,
+			EncryptedSecret: sealed,
+		})
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to create totp")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return emptyObj, ErrTxCommitFailed
+	}
+
+	encoded := totpSecretEncoding.EncodeToString(secret)
+	return EnrollTOTPOutput{
+		Secret: encoded,
+		URI:    t.otpauthURI(acc.Username, encoded),
+	}, nil
+}
+
+// Checks the code against the stored secret and records its step on the given
+// executor.
+func (t totp) useCode(
+	ctx context.Context,
+	exec database.Executor,
+	at database.AccountTOTP,
+	code string,
+) (bool, error) {
+	if t.box == nil {
+		return false, ErrTOTPNotConfigured
+	}
+
+	secret, err := t.box.open(at.EncryptedSecret, totpSecretAssociatedData(at.OfAccountId))
+	if err != nil {
+		utils.LoggerWithContext(ctx, t.logger).
+			With(zap.Any("of_account_id", at.OfAccountId)).
+			With(zap.Error(err)).
+			Error("failed to decrypt totp secret")
+		return false, status.Error(codes.Internal, "failed to decrypt totp secret")
+	}
+
+	step, ok := t.matchStep(secret, code, at.LastUsedStep)
+	if !ok {
+		return false, nil
+	}
+
+	// Fails when a concurrent request accepted the same code first
+	err = t.accountTOTPAccessor.
+		WithExecutor(exec).
+		UseAccountTOTPStep(ctx, at.OfAccountId, step)
+	if err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (t totp) ConfirmTOTP(
+	ctx context.Context,
+	params ConfirmTOTPParams,
+) error {
+	tx, err := t.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	at, err := t.accountTOTPAccessor.
+		WithExecutor(tx).
+		GetAccountTOTP(ctx, params.AccountId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTOTPNotEnrolled
+		}
+		return status.Error(codes.Internal, "failed to get totp")
+	}
+	if at.ConfirmedAt != nil {
+		return ErrTOTPAlreadyEnabled
+	}
+
+	ok, err := t.useCode(ctx, tx, at, params.Code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTOTPCodeInvalid
+	}
+
+	err = t.accountTOTPAccessor.
+		WithExecutor(tx).
+		ConfirmAccountTOTP(ctx, at.OfAccountId, time.Now())
+	if err != nil {
+		return status.Error(codes.Internal, "failed to confirm totp")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return ErrTxCommitFailed
+	}
+	return nil
+}
+
+// Requires a current code, so a stolen session alone cannot turn TOTP off.
+func (t totp) DisableTOTP(
+	ctx context.Context,
+	params DisableTOTPParams,
+) error {
+	tx, err := t.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	at, err := t.accountTOTPAccessor.
+		WithExecutor(tx).
+		GetAccountTOTP(ctx, params.AccountId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTOTPNotEnrolled
+		}
+		return status.Error(codes.Internal, "failed to get totp")
+	}
+
+	if at.ConfirmedAt != nil {
+		ok, err := t.useCode(ctx, tx, at, params.Code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrTOTPCodeInvalid
+		}
+	}
+
+	err = t.recoveryCodeAccessor.
+		WithExecutor(tx).
+		DeleteRecoveryCodeOfAccount(ctx, at.OfAccountId)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to delete recovery codes")
+	}
+	err = t.accountTOTPAccessor.
+		WithExecutor(tx).
+		DeleteAccountTOTP(ctx, at.OfAccountId)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to delete totp")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return ErrTxCommitFailed
+	}
+	return nil
+}
+
+func (t totp) IsTOTPEnabled(
+	ctx context.Context,
+	accountId uint64,
+) (bool, error) {
+	at, err := t.accountTOTPAccessor.GetAccountTOTP(ctx, accountId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, status.Error(codes.Internal, "failed to get totp")
+	}
+	return at.ConfirmedAt != nil, nil
+}
+
+func (t totp) VerifyTOTPCode(
+	ctx context.Context,
+	accountId uint64,
+	code string,
+) (bool, error) {
+	at, err := t.accountTOTPAccessor.GetAccountTOTP(ctx, accountId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, status.Error(codes.Internal, "failed to get totp")
+	}
+	if at.ConfirmedAt == nil {
+		return false, nil
+	}
+	return t.useCode(ctx, t.db, at, code)
+}
@@ -0,0 +1,22 @@
+package logic
+
+type EnrollTOTPParams struct {
+	AccountId uint64
+}
+
+type EnrollTOTPOutput struct {
+	// Base32 encoded, for entering the secret by hand.
+	Secret string
+	// The otpauth:// URI authenticator apps read from a QR code.
+	URI string
+}
+
+type ConfirmTOTPParams struct {
+	AccountId uint64
+	Code      string
+}
+
+type DisableTOTPParams struct {
+	AccountId uint64
+	Code      string
+}
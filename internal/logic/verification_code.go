@@ -0,0 +1,139 @@
+package logic
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultVerificationCodeLength      = 6
+	defaultVerificationCodeMaxAttempts = 5
+)
+
+// Generates a code of the given number of decimal digits, leading zeros kept.
+func generateNumericCode(length int) (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil)
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*s", length, n.String()), nil
+}
+
+// Issues and checks the short numeric codes sent to an email address or a
+// phone number to prove the account holder owns it. The codes are short
+// enough to guess offline, so their hashes only keep them out of plain sight;
+// the TTL and the attempt limit are what protect them.
+type verificationCodes struct {
+	verificationCodeAccessor database.VerificationCodeAccessor
+	codeConfig               configs.VerificationCode
+	logger                   *zap.Logger
+}
+
+func (v verificationCodes) length() int {
+	if v.codeConfig.Length <= 0 {
+		return defaultVerificationCodeLength
+	}
+	return v.codeConfig.Length
+}
+
+func (v verificationCodes) maxAttempts() uint32 {
+	if v.codeConfig.MaxAttempts == 0 {
+		return defaultVerificationCodeMaxAttempts
+	}
+	return v.codeConfig.MaxAttempts
+}
+
+// Creates a new code for the target. Only the latest code of the account on a
+// channel counts, so issuing one supersedes the codes sent before.
+func (v verificationCodes) issue(
+	ctx context.Context,
+	accountId uint64,
+	channel database.VerificationChannel,
+	target string,
+) (string, time.Time, error) {
+	code, err := generateNumericCode(v.length())
+	if err != nil {
+		return "", time.Time{}, status.Error(codes.Internal, "failed to generate verification code")
+	}
+
+	expiresAt := time.Now().Add(v.codeConfig.TTL)
+	_, err = v.verificationCodeAccessor.CreateVerificationCode(ctx, database.VerificationCode{
+		OfAccountId: accountId,
+		Channel:     channel,
+		Target:      target,
+		HashedCode:  hashOpaqueToken(code),
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return "", time.Time{}, status.Error(codes.Internal, "failed to create verification code")
+	}
+
+	return code, expiresAt, nil
+}
+
+// Checks the code against the latest one issued for the target and marks it
+// used on the given transaction. A wrong guess is counted outside of the
+// transaction, so it sticks even though the caller rolls back.
+func (v verificationCodes) redeem(
+	ctx context.Context,
+	tx *sql.Tx,
+	accountId uint64,
+	channel database.VerificationChannel,
+	target string,
+	code string,
+) error {
+	vc, err := v.verificationCodeAccessor.
+		WithExecutor(tx).
+		GetLatestVerificationCode(ctx, accountId, channel)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrVerificationCodeInvalid
+		}
+		return status.Error(codes.Internal, "failed to get verification code")
+	}
+
+	now := time.Now()
+	// A code sent to an address the account no longer has proves nothing.
+	if vc.UsedAt != nil || vc.Target != target {
+		return ErrVerificationCodeInvalid
+	}
+	if now.After(vc.ExpiresAt) {
+		return ErrVerificationCodeExpired
+	}
+	if vc.FailedAttempts >= v.maxAttempts() {
+		return ErrVerificationCodeAttemptsExceeded
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashOpaqueToken(code)), []byte(vc.HashedCode)) != 1 {
+		err = v.verificationCodeAccessor.IncreaseVerificationCodeFailedAttempts(ctx, vc.Id)
+		if err != nil {
+			utils.LoggerWithContext(ctx, v.logger).
+				With(zap.Error(err)).
+				Warn("failed to count wrong verification code")
+		}
+		return ErrVerificationCodeInvalid
+	}
+
+	err = v.verificationCodeAccessor.
+		WithExecutor(tx).
+		UseVerificationCode(ctx, vc.Id, now)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to use verification code")
+	}
+
+	return nil
+}
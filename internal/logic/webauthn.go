@@ -0,0 +1,509 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/utils"
+	"github.com/go-webauthn/webauthn/protocol"
+	gowebauthn "github.com/go-webauthn/webauthn/webauthn"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const webAuthnSessionByteLength = 32
+
+// Passkeys verify the user on the authenticator, so an assertion stands in for
+// both the password and the second factor.
+type WebAuthn interface {
+	BeginWebAuthnRegistration(ctx context.Context, params BeginWebAuthnRegistrationParams) (BeginWebAuthnCeremonyOutput, error)
+	FinishWebAuthnRegistration(ctx context.Context, params FinishWebAuthnRegistrationParams) (FinishWebAuthnRegistrationOutput, error)
+
+	BeginWebAuthnAssertion(ctx context.Context, params BeginWebAuthnAssertionParams) (BeginWebAuthnCeremonyOutput, error)
+	// Rejects an assertion whose sign count did not increase, which is the
+	// sign of a cloned authenticator.
+	FinishWebAuthnAssertion(ctx context.Context, params FinishWebAuthnAssertionParams) (FinishWebAuthnAssertionOutput, error)
+}
+
+type webAuthn struct {
+	db                         *sql.DB
+	accountAccessor            database.AccountAccessor
+	webAuthnCredentialAccessor database.WebAuthnCredentialAccessor
+	webAuthnSessionAccessor    database.WebAuthnSessionAccessor
+	loginThrottleLogic         LoginThrottle
+	webAuthnConfig             configs.WebAuthn
+	relyingParty               *gowebauthn.WebAuthn
+	logger                     *zap.Logger
+}
+
+func NewWebAuthn(
+	db *sql.DB,
+	accountAccessor database.AccountAccessor,
+	webAuthnCredentialAccessor database.WebAuthnCredentialAccessor,
+	webAuthnSessionAccessor database.WebAuthnSessionAccessor,
+	loginThrottleLogic LoginThrottle,
+	webAuthnConfig configs.WebAuthn,
+	logger *zap.Logger,
+) (WebAuthn, error) {
+	relyingParty, err := gowebauthn.New(&gowebauthn.Config{
+		RPID:          webAuthnConfig.RPID,
+		RPDisplayName: webAuthnConfig.RPDisplayName,
+		RPOrigins:     webAuthnConfig.RPOrigins,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to set the webauthn relying party up: %w", err)
+	}
+
+	return &webAuthn{
+		db:                         db,
+		accountAccessor:            accountAccessor,
+		webAuthnCredentialAccessor: webAuthnCredentialAccessor,
+		webAuthnSessionAccessor:    webAuthnSessionAccessor,
+		loginThrottleLogic:         loginThrottleLogic,
+		webAuthnConfig:             webAuthnConfig,
+		relyingParty:               relyingParty,
+		logger:                     logger,
+	}, nil
+}
+
+// Presents an account and its credentials to the webauthn library.
+type webAuthnUser struct {
+	account     database.Account
+	credentials []gowebauthn.Credential
+}
+
+// The user handle is the account id, it never changes and reveals nothing
+// the account id does not already.
+func webAuthnUserHandleOf(accountId uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, accountId)
+}
+
+func (u webAuthnUser) WebAuthnID() []byte {
+	return webAuthnUserHandleOf(u.account.Id)
+}
+
+func (u webAuthnUser) WebAuthnName() string {
+	return u.account.Username
+}
+
+func (u webAuthnUser) WebAuthnDisplayName() string {
+	if u.account.Fullname != "" {
+		return u.account.Fullname
+	}
+	return u.account.Username
+}
+
+func (u webAuthnUser) WebAuthnCredentials() []gowebauthn.Credential {
+	return u.credentials
+}
+
+func encodeWebAuthnCredentialId(id []byte) string {
+	return base64.RawURLEncoding.EncodeToString(id)
+}
+
+func toWebAuthnLibraryCredential(wc database.WebAuthnCredential) (gowebauthn.Credential, error) {
+	id, err := base64.RawURLEncoding.DecodeString(wc.CredentialId)
+	if err != nil {
+		return gowebauthn.Credential{}, err
+	}
+
+	transports := make([]protocol.AuthenticatorTransport, 0, len(wc.Transports))
+	for _, transport := range wc.Transports {
+		transports = append(transports, protocol.AuthenticatorTransport(transport))
+	}
+
+	return gowebauthn.Credential{
+		ID:              id,
+		PublicKey:       wc.PublicKey,
+		AttestationType: wc.AttestationType,
+		Transport:       transports,
+		Flags: gowebauthn.CredentialFlags{
+			BackupEligible: wc.BackupEligible,
+			BackupState:    wc.BackupState,
+		},
+		Authenticator: gowebauthn.Authenticator{
+			AAGUID:    wc.AAGUID,
+			SignCount: wc.SignCount,
+		},
+	}, nil
+}
+
+func (w webAuthn) userOf(ctx context.Context, acc database.Account) (webAuthnUser, error) {
+	rows, err := w.webAuthnCredentialAccessor.GetWebAuthnCredentialList(ctx, acc.Id)
+	if err != nil {
+		return webAuthnUser{}, status.Error(codes.Internal, "failed to get webauthn credentials")
+	}
+
+	credentials := make([]gowebauthn.Credential, 0, len(rows))
+	for _, row := range rows {
+		credential, err := toWebAuthnLibraryCredential(row)
+		if err != nil {
+			return webAuthnUser{}, status.Error(codes.Internal, "failed to decode webauthn credential")
+		}
+		credentials = append(credentials, credential)
+	}
+
+	return webAuthnUser{
+		account:     acc,
+		credentials: credentials,
+	}, nil
+}
+
+// Stores the library's session data and returns what the client needs to run
+// the ceremony.
+func (w webAuthn) beginCeremony(
+	ctx context.Context,
+	ofAccountId uint64,
+	ceremony database.WebAuthnCeremony,
+	options any,
+	session *gowebauthn.SessionData,
+) (BeginWebAuthnCeremonyOutput, error) {
+	emptyObj := BeginWebAuthnCeremonyOutput{}
+	encodedOptions, err := json.Marshal(options)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to encode webauthn options")
+	}
+	encodedSession, err := json.Marshal(session)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to encode webauthn session")
+	}
+
+	sessionId, err := generateOpaqueToken(webAuthnSessionByteLength)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to generate webauthn session")
+	}
+
+	expiresAt := time.Now().Add(w.webAuthnConfig.SessionTTL)
+	_, err = w.webAuthnSessionAccessor.CreateWebAuthnSession(ctx, database.WebAuthnSession{
+		OfAccountId:   ofAccountId,
+		HashedSession: hashOpaqueToken(sessionId),
+		Ceremony:      ceremony,
+		SessionData:   string(encodedSession),
+		ExpiresAt:     expiresAt,
+	})
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to create webauthn session")
+	}
+
+	return BeginWebAuthnCeremonyOutput{
+		SessionId: sessionId,
+		Options:   string(encodedOptions),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (w webAuthn) loadCeremony(
+	ctx context.Context,
+	sessionId string,
+	ceremony database.WebAuthnCeremony,
+) (database.WebAuthnSession, gowebauthn.SessionData, error) {
+	if sessionId == "" {
+		return database.WebAuthnSession{}, gowebauthn.SessionData{}, ErrWebAuthnSessionInvalid
+	}
+
+	ws, err := w.webAuthnSessionAccessor.
+		GetWebAuthnSessionByHashedSession(ctx, hashOpaqueToken(sessionId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return database.WebAuthnSession{}, gowebauthn.SessionData{}, ErrWebAuthnSessionInvalid
+		}
+		return database.WebAuthnSession{}, gowebauthn.SessionData{}, status.Error(codes.Internal, "failed to get webauthn session")
+	}
+	if ws.Ceremony != ceremony || ws.UsedAt != nil {
+		return database.WebAuthnSession{}, gowebauthn.SessionData{}, ErrWebAuthnSessionInvalid
+	}
+	if time.Now().After(ws.ExpiresAt) {
+		return database.WebAuthnSession{}, gowebauthn.SessionData{}, ErrWebAuthnSessionExpired
+	}
+
+	var session gowebauthn.SessionData
+	if err := json.Unmarshal([]byte(ws.SessionData), &session); err != nil {
+		return database.WebAuthnSession{}, gowebauthn.SessionData{}, status.Error(codes.Internal, "failed to decode webauthn session")
+	}
+
+	return ws, session, nil
+}
+
+func (w webAuthn) BeginWebAuthnRegistration(
+	ctx context.Context,
+	params BeginWebAuthnRegistrationParams,
+) (BeginWebAuthnCeremonyOutput, error) {
+	emptyObj := BeginWebAuthnCeremonyOutput{}
+	acc, err := w.accountAccessor.GetAccount(ctx, params.AccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.NotFound, "account not found")
+	}
+
+	user, err := w.userOf(ctx, acc)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	creation, session, err := w.relyingParty.BeginRegistration(user,
+		gowebauthn.WithExclusions(gowebauthn.Credentials(user.credentials).CredentialDescriptors()),
+		gowebauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred))
+	if err != nil {
+		utils.LoggerWithContext(ctx, w.logger).
+			With(zap.Error(err)).
+			Error("failed to begin webauthn registration")
+		return emptyObj, status.Error(codes.Internal, "failed to begin webauthn registration")
+	}
+
+	return w.beginCeremony(ctx, acc.Id, database.WebAuthnCeremonyRegistration, creation, session)
+}
+
+func (w webAuthn) FinishWebAuthnRegistration(
+	ctx context.Context,
+	params FinishWebAuthnRegistrationParams,
+) (FinishWebAuthnRegistrationOutput, error) {
+	emptyObj := FinishWebAuthnRegistrationOutput{}
+	logger := utils.LoggerWithContext(ctx, w.logger)
+	ws, session, err := w.loadCeremony(ctx, params.SessionId, database.WebAuthnCeremonyRegistration)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	acc, err := w.accountAccessor.GetAccount(ctx, ws.OfAccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.NotFound, "account not found")
+	}
+	user, err := w.userOf(ctx, acc)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes([]byte(params.Credential))
+	if err != nil {
+		logger.With(zap.Error(err)).Debug("failed to parse webauthn credential")
+		return emptyObj, ErrWebAuthnCredentialInvalid
+	}
+	credential, err := w.relyingParty.CreateCredential(user, session, parsed)
+	if err != nil {
+		logger.With(zap.Error(err)).Debug("failed to verify webauthn credential")
+		return emptyObj, ErrWebAuthnCredentialInvalid
+	}
+
+	credentialId := encodeWebAuthnCredentialId(credential.ID)
+	_, err = w.webAuthnCredentialAccessor.GetWebAuthnCredentialByCredentialId(ctx, credentialId)
+	if err == nil {
+		return emptyObj, ErrWebAuthnCredentialExists
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return emptyObj, status.Error(codes.Internal, "failed to get webauthn credential")
+	}
+
+	transports := make([]string, 0, len(credential.Transport))
+	for _, transport := range credential.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	tx, err := w.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return emptyObj, ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	err = w.webAuthnSessionAccessor.
+		WithExecutor(tx).
+		UseWebAuthnSession(ctx, ws.Id, time.Now())
+	if err != nil {
+		return emptyObj, ErrWebAuthnSessionInvalid
+	}
+	_, err = w.webAuthnCredentialAccessor.
+		WithExecutor(tx).
+		CreateWebAuthnCredential(ctx, database.WebAuthnCredential{
+			OfAccountId:     acc.Id,
+			CredentialId:    credentialId,
+			PublicKey:       credential.PublicKey,
+			AttestationType: credential.AttestationType,
+			AAGUID:          credential.Authenticator.AAGUID,
+			SignCount:       credential.Authenticator.SignCount,
+			Transports:      transports,
+			BackupEligible:  credential.Flags.BackupEligible,
+			BackupState:     credential.Flags.BackupState,
+		})
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to create webauthn credential")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return emptyObj, ErrTxCommitFailed
+	}
+
+	return FinishWebAuthnRegistrationOutput{
+		AccountId:    acc.Id,
+		CredentialId: credentialId,
+	}, nil
+}
+
+// An unknown username gets the same options as a username without
+// credentials, both fall back to letting the authenticator pick a passkey.
+func (w webAuthn) BeginWebAuthnAssertion(
+	ctx context.Context,
+	params BeginWebAuthnAssertionParams,
+) (BeginWebAuthnCeremonyOutput, error) {
+	emptyObj := BeginWebAuthnCeremonyOutput{}
+	var user webAuthnUser
+	if params.Username != "" {
+		acc, err := w.accountAccessor.GetAccountByUsername(ctx, params.Username)
+		if err == nil {
+			user, err = w.userOf(ctx, acc)
+			if err != nil {
+				return emptyObj, err
+			}
+		}
+	}
+
+	var (
+		assertion *protocol.CredentialAssertion
+		session   *gowebauthn.SessionData
+		err       error
+	)
+	userVerification := gowebauthn.WithUserVerification(protocol.VerificationRequired)
+	if len(user.credentials) > 0 {
+		assertion, session, err = w.relyingParty.BeginLogin(user, userVerification)
+	} else {
+		user = webAuthnUser{}
+		assertion, session, err = w.relyingParty.BeginDiscoverableLogin(userVerification)
+	}
+	if err != nil {
+		utils.LoggerWithContext(ctx, w.logger).
+			With(zap.Error(err)).
+			Error("failed to begin webauthn assertion")
+		return emptyObj, status.Error(codes.Internal, "failed to begin webauthn assertion")
+	}
+
+	return w.beginCeremony(ctx, user.account.Id, database.WebAuthnCeremonyAssertion, assertion, session)
+}
+
+func (w webAuthn) FinishWebAuthnAssertion(
+	ctx context.Context,
+	params FinishWebAuthnAssertionParams,
+) (FinishWebAuthnAssertionOutput, error) {
+	emptyObj := FinishWebAuthnAssertionOutput{}
+	logger := utils.LoggerWithContext(ctx, w.logger)
+	ws, session, err := w.loadCeremony(ctx, params.SessionId, database.WebAuthnCeremonyAssertion)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes([]byte(params.Credential))
+	if err != nil {
+		logger.With(zap.Error(err)).Debug("failed to parse webauthn assertion")
+		return emptyObj, ErrWebAuthnAssertionFailed
+	}
+
+	// Until the credential names an account, only the address is throttled
+	attempt := LoginAttemptParams{Address: params.Address}
+	recordFailure := func() {
+		if err := w.loginThrottleLogic.RecordLoginFailure(ctx, attempt); err != nil {
+			logger.With(zap.Error(err)).Warn("failed to record login failure")
+		}
+	}
+
+	wc, err := w.webAuthnCredentialAccessor.
+		GetWebAuthnCredentialByCredentialId(ctx, encodeWebAuthnCredentialId(parsed.RawID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			recordFailure()
+			return emptyObj, ErrWebAuthnAssertionFailed
+		}
+		return emptyObj, status.Error(codes.Internal, "failed to get webauthn credential")
+	}
+	if ws.OfAccountId != 0 && ws.OfAccountId != wc.OfAccountId {
+		recordFailure()
+		return emptyObj, ErrWebAuthnAssertionFailed
+	}
+
+	acc, err := w.accountAccessor.GetAccount(ctx, wc.OfAccountId)
+	if err != nil {
+		return emptyObj, status.Error(codes.Internal, "failed to get account")
+	}
+	attempt.Username = acc.Username
+	if err := w.loginThrottleLogic.CheckLoginAllowed(ctx, attempt); err != nil {
+		return emptyObj, err
+	}
+
+	user, err := w.userOf(ctx, acc)
+	if err != nil {
+		return emptyObj, err
+	}
+
+	var credential *gowebauthn.Credential
+	if ws.OfAccountId == 0 {
+		credential, err = w.relyingParty.ValidateDiscoverableLogin(
+			func(_, userHandle []byte) (gowebauthn.User, error) {
+				if !bytes.Equal(userHandle, user.WebAuthnID()) {
+					return nil, errors.New("user handle does not match the credential")
+				}
+				return user, nil
+			}, session, parsed)
+	} else {
+		credential, err = w.relyingParty.ValidateLogin(user, session, parsed)
+	}
+	if err != nil {
+		logger.With(zap.Error(err)).Debug("failed to verify webauthn assertion")
+		recordFailure()
+		return emptyObj, ErrWebAuthnAssertionFailed
+	}
+
+	if credential.Authenticator.CloneWarning {
+		logger.With(zap.Any("of_account_id", acc.Id)).
+			With(zap.Any("credential_id", wc.CredentialId)).
+			With(zap.Uint32("stored_sign_count", wc.SignCount)).
+			With(zap.Uint32("sign_count", parsed.Response.AuthenticatorData.Counter)).
+			Warn("webauthn sign count did not increase, the authenticator may have been cloned")
+		recordFailure()
+		return emptyObj, ErrWebAuthnCloneDetected
+	}
+
+	if err := checkAccountStatus(acc); err != nil {
+		return emptyObj, err
+	}
+
+	tx, err := w.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return emptyObj, ErrTxBeginFailed
+	}
+	defer tx.Rollback()
+
+	err = w.webAuthnSessionAccessor.
+		WithExecutor(tx).
+		UseWebAuthnSession(ctx, ws.Id, time.Now())
+	if err != nil {
+		return emptyObj, ErrWebAuthnSessionInvalid
+	}
+	// Fails when a concurrent assertion already moved the counter past this one
+	err = w.webAuthnCredentialAccessor.
+		WithExecutor(tx).
+		UseWebAuthnCredential(ctx, wc.Id,
+			credential.Authenticator.SignCount,
+			credential.Flags.BackupState,
+			time.Now())
+	if err != nil {
+		return emptyObj, ErrWebAuthnCloneDetected
+	}
+
+	if err = tx.Commit(); err != nil {
+		return emptyObj, ErrTxCommitFailed
+	}
+
+	if err := w.loginThrottleLogic.RecordLoginSuccess(ctx, attempt); err != nil {
+		logger.With(zap.Error(err)).Warn("failed to clear login failures")
+	}
+
+	return FinishWebAuthnAssertionOutput{
+		AccountId:    acc.Id,
+		CredentialId: wc.CredentialId,
+	}, nil
+}
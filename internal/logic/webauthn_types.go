@@ -0,0 +1,44 @@
+package logic
+
+import "time"
+
+type BeginWebAuthnRegistrationParams struct {
+	AccountId uint64
+}
+
+type BeginWebAuthnAssertionParams struct {
+	// Optional. Without it the authenticator picks one of its passkeys.
+	Username string
+}
+
+type BeginWebAuthnCeremonyOutput struct {
+	// Passed back on finish.
+	SessionId string
+	// The JSON options for navigator.credentials.create() or .get().
+	Options   string
+	ExpiresAt time.Time
+}
+
+type FinishWebAuthnRegistrationParams struct {
+	SessionId string
+	// The JSON serialized PublicKeyCredential from navigator.credentials.create().
+	Credential string
+}
+
+type FinishWebAuthnRegistrationOutput struct {
+	AccountId    uint64
+	CredentialId string
+}
+
+type FinishWebAuthnAssertionParams struct {
+	SessionId string
+	// The JSON serialized PublicKeyCredential from navigator.credentials.get().
+	Credential string
+	// The peer address of the caller, used to throttle failed assertions.
+	Address string
+}
+
+type FinishWebAuthnAssertionOutput struct {
+	AccountId    uint64
+	CredentialId string
+}
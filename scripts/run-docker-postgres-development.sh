@@ -0,0 +1,6 @@
+#! /bin/sh
+docker run \
+    -e POSTGRES_PASSWORD=postgres \
+    -e POSTGRES_DB=db_account_service \
+    -p 11002:5432 \
+    -d postgres:18.0
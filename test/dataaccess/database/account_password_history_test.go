@@ -0,0 +1,47 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountPasswordHistory(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	aphAsor := database.NewAccountPasswordHistoryAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	accId, err := aAsor.CreateAccount(ctx, RandomAccount())
+	require.NoError(t, err)
+
+	hashedStrings := make([]string, 0, 4)
+	for range 4 {
+		hashedString := RandomString(60)
+		hashedStrings = append(hashedStrings, hashedString)
+		require.NoError(t, aphAsor.CreateAccountPasswordHistory(ctx, database.AccountPasswordHistory{
+			OfAccountId:  accId,
+			HashedString: hashedString,
+		}))
+	}
+
+	// Newest first, limited to the requested size
+	history, err := aphAsor.GetAccountPasswordHistoryList(ctx, accId, 3)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	require.Equal(t, hashedStrings[3], history[0].HashedString)
+	require.Equal(t, hashedStrings[1], history[2].HashedString)
+
+	require.NoError(t, aphAsor.DeleteAccountPasswordHistoryBefore(ctx, accId, history[2].Id))
+	history, err = aphAsor.GetAccountPasswordHistoryList(ctx, accId, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	require.NoError(t, aphAsor.DeleteAccountPasswordHistoryOfAccount(ctx, accId))
+	history, err = aphAsor.GetAccountPasswordHistoryList(ctx, accId, 10)
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	require.NoError(t, aAsor.DeleteAccount(ctx, accId))
+}
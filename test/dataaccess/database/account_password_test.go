@@ -83,3 +83,43 @@ func TestUpdateAccountPassword(t *testing.T) {
 	require.NoError(t, pAsor.DeleteAccountPassword(ctx, id))
 	require.NoError(t, aAsor.DeleteAccount(ctx, id))
 }
+
+func TestUpdateAccountPasswordIfUnchanged(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	pAsor := database.NewAccountPasswordAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	acc := RandomAccount()
+	id, err := aAsor.CreateAccount(ctx, acc)
+	require.NoError(t, err)
+	require.NotZero(t, id)
+
+	input := database.AccountPassword{
+		OfAccountId:  id,
+		HashedString: RandomString(128),
+	}
+	require.NoError(t, pAsor.CreateAccountPassword(ctx, input))
+
+	// A password changed since it was read is kept
+	changed := input
+	changed.HashedString = RandomString(128)
+	require.NoError(t, pAsor.UpdateAccountPassword(ctx, changed))
+	rehashed := input
+	rehashed.HashedString = RandomString(128)
+	isUpdated, err := pAsor.UpdateAccountPasswordIfUnchanged(ctx, rehashed, input.HashedString)
+	require.NoError(t, err)
+	require.False(t, isUpdated)
+	output, err := pAsor.GetAccountPassword(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, changed.HashedString, output.HashedString)
+
+	isUpdated, err = pAsor.UpdateAccountPasswordIfUnchanged(ctx, rehashed, changed.HashedString)
+	require.NoError(t, err)
+	require.True(t, isUpdated)
+	output, err = pAsor.GetAccountPassword(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, rehashed.HashedString, output.HashedString)
+
+	require.NoError(t, pAsor.DeleteAccountPassword(ctx, id))
+	require.NoError(t, aAsor.DeleteAccount(ctx, id))
+}
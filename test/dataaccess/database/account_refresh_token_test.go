@@ -0,0 +1,108 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/stretchr/testify/require"
+)
+
+func RandomRefreshToken(ofAccountId uint64, familyId string) database.RefreshToken {
+	return database.RefreshToken{
+		OfAccountId: ofAccountId,
+		FamilyId:    familyId,
+		HashedToken: RandomString(64),
+		ExpiresAt:   time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+}
+
+func TestCreateAndGetRefreshToken(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	rtAsor := database.NewRefreshTokenAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	accId, err := aAsor.CreateAccount(ctx, RandomAccount())
+	require.NoError(t, err)
+
+	input := RandomRefreshToken(accId, RandomString(32))
+	id, err := rtAsor.CreateRefreshToken(ctx, input)
+	require.NoError(t, err)
+	require.NotZero(t, id)
+
+	output, err := rtAsor.GetRefreshTokenByHashedToken(ctx, input.HashedToken)
+	require.NoError(t, err)
+	require.Equal(t, id, output.Id)
+	require.Equal(t, input.OfAccountId, output.OfAccountId)
+	require.Equal(t, input.FamilyId, output.FamilyId)
+	require.Equal(t, input.HashedToken, output.HashedToken)
+	require.Nil(t, output.RotatedAt)
+	require.Nil(t, output.RevokedAt)
+
+	require.NoError(t, rtAsor.DeleteRefreshTokenOfAccount(ctx, accId))
+	require.NoError(t, aAsor.DeleteAccount(ctx, accId))
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	rtAsor := database.NewRefreshTokenAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	accId, err := aAsor.CreateAccount(ctx, RandomAccount())
+	require.NoError(t, err)
+
+	input := RandomRefreshToken(accId, RandomString(32))
+	id, err := rtAsor.CreateRefreshToken(ctx, input)
+	require.NoError(t, err)
+
+	require.NoError(t, rtAsor.RotateRefreshToken(ctx, id, time.Now()))
+	// A token can only be rotated once
+	require.Error(t, rtAsor.RotateRefreshToken(ctx, id, time.Now()))
+
+	output, err := rtAsor.GetRefreshTokenByHashedToken(ctx, input.HashedToken)
+	require.NoError(t, err)
+	require.NotNil(t, output.RotatedAt)
+	require.Nil(t, output.RevokedAt)
+
+	require.NoError(t, rtAsor.DeleteRefreshTokenOfAccount(ctx, accId))
+	require.NoError(t, aAsor.DeleteAccount(ctx, accId))
+}
+
+func TestRevokeRefreshTokenFamily(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	rtAsor := database.NewRefreshTokenAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	accId, err := aAsor.CreateAccount(ctx, RandomAccount())
+	require.NoError(t, err)
+
+	familyId := RandomString(32)
+	in1 := RandomRefreshToken(accId, familyId)
+	in2 := RandomRefreshToken(accId, familyId)
+	in3 := RandomRefreshToken(accId, RandomString(32))
+	for _, in := range []database.RefreshToken{in1, in2, in3} {
+		_, err := rtAsor.CreateRefreshToken(ctx, in)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, rtAsor.RevokeRefreshTokenFamily(ctx, familyId, time.Now()))
+
+	out1, err := rtAsor.GetRefreshTokenByHashedToken(ctx, in1.HashedToken)
+	require.NoError(t, err)
+	require.NotNil(t, out1.RevokedAt)
+	out2, err := rtAsor.GetRefreshTokenByHashedToken(ctx, in2.HashedToken)
+	require.NoError(t, err)
+	require.NotNil(t, out2.RevokedAt)
+	out3, err := rtAsor.GetRefreshTokenByHashedToken(ctx, in3.HashedToken)
+	require.NoError(t, err)
+	require.Nil(t, out3.RevokedAt)
+
+	require.NoError(t, rtAsor.RevokeRefreshTokenOfAccount(ctx, accId, time.Now()))
+	out3, err = rtAsor.GetRefreshTokenByHashedToken(ctx, in3.HashedToken)
+	require.NoError(t, err)
+	require.NotNil(t, out3.RevokedAt)
+
+	require.NoError(t, rtAsor.DeleteRefreshTokenOfAccount(ctx, accId))
+	require.NoError(t, aAsor.DeleteAccount(ctx, accId))
+}
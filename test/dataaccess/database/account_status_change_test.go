@@ -0,0 +1,46 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountStatusChange(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	ascAsor := database.NewAccountStatusChangeAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	accId, err := aAsor.CreateAccount(ctx, RandomAccount())
+	require.NoError(t, err)
+
+	inputs := []database.AccountStatusChange{
+		{OfAccountId: accId, FromStatusId: 2, ToStatusId: 3, Reason: "spam", ActorAccountId: 42},
+		// Changes made by the service itself have no actor
+		{OfAccountId: accId, FromStatusId: 3, ToStatusId: 2, Reason: "appeal accepted"},
+	}
+	for _, input := range inputs {
+		id, err := ascAsor.CreateAccountStatusChange(ctx, input)
+		require.NoError(t, err)
+		require.NotZero(t, id)
+	}
+
+	output, err := ascAsor.GetAccountStatusChangeList(ctx, accId)
+	require.NoError(t, err)
+	require.Len(t, output, len(inputs))
+	for i, input := range inputs {
+		require.Equal(t, input.FromStatusId, output[i].FromStatusId)
+		require.Equal(t, input.ToStatusId, output[i].ToStatusId)
+		require.Equal(t, input.Reason, output[i].Reason)
+		require.Equal(t, input.ActorAccountId, output[i].ActorAccountId)
+	}
+
+	require.NoError(t, ascAsor.DeleteAccountStatusChangeOfAccount(ctx, accId))
+	output, err = ascAsor.GetAccountStatusChangeList(ctx, accId)
+	require.NoError(t, err)
+	require.Empty(t, output)
+
+	require.NoError(t, aAsor.DeleteAccount(ctx, accId))
+}
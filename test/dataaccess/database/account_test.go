@@ -2,7 +2,9 @@ package database_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/Fiagram/account_service/internal/dataaccess/database"
 	"github.com/stretchr/testify/require"
@@ -112,6 +114,292 @@ func TestUpdateAccount(t *testing.T) {
 	require.NoError(t, errD)
 }
 
+func TestSetAccountEmailVerified(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	input := RandomAccount()
+	id, err := aAsor.CreateAccount(ctx, input)
+	require.NoError(t, err)
+
+	acc, err := aAsor.GetAccount(ctx, id)
+	require.NoError(t, err)
+	require.Nil(t, acc.EmailVerifiedAt)
+
+	// Only the email the code was sent to can be verified
+	require.Error(t, aAsor.SetAccountEmailVerified(ctx, id, RandomGmailAddress(), time.Now()))
+	require.NoError(t, aAsor.SetAccountEmailVerified(ctx, id, input.Email, time.Now()))
+
+	acc, err = aAsor.GetAccount(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, acc.EmailVerifiedAt)
+
+	acc.EmailVerifiedAt = nil
+	acc.Email = RandomGmailAddress()
+	require.NoError(t, aAsor.UpdateAccount(ctx, acc))
+	acc, err = aAsor.GetAccount(ctx, id)
+	require.NoError(t, err)
+	require.Nil(t, acc.EmailVerifiedAt)
+
+	require.NoError(t, aAsor.DeleteAccount(ctx, id))
+}
+
+func TestSetAccountPhoneVerified(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	input := RandomAccount()
+	id, err := aAsor.CreateAccount(ctx, input)
+	require.NoError(t, err)
+
+	// Only the phone number the code was sent to can be verified
+	require.Error(t, aAsor.SetAccountPhoneVerified(ctx, id, "+1"+input.PhoneNumber, time.Now()))
+	require.NoError(t, aAsor.SetAccountPhoneVerified(ctx, id, input.PhoneNumber, time.Now()))
+
+	acc, err := aAsor.GetAccount(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, acc.PhoneVerifiedAt)
+	require.Nil(t, acc.EmailVerifiedAt)
+
+	require.NoError(t, aAsor.DeleteAccount(ctx, id))
+}
+
+func TestUpdateAccountStatus(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	input := RandomAccount()
+	id, err := aAsor.CreateAccount(ctx, input)
+	require.NoError(t, err)
+
+	require.NoError(t, aAsor.UpdateAccountStatus(ctx, id, input.StatusId, 3))
+	// The status only moves on from the one the caller saw
+	require.Error(t, aAsor.UpdateAccountStatus(ctx, id, input.StatusId, 5))
+
+	acc, err := aAsor.GetAccount(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, uint8(3), acc.StatusId)
+
+	require.NoError(t, aAsor.DeleteAccount(ctx, id))
+}
+
+func TestSoftDeleteAccount(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	input := RandomAccount()
+	id, err := aAsor.CreateAccount(ctx, input)
+	require.NoError(t, err)
+
+	deletedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, aAsor.SoftDeleteAccount(ctx, id, deletedAt))
+	require.Error(t, aAsor.SoftDeleteAccount(ctx, id, deletedAt))
+
+	// Deleted accounts are left out of every read
+	_, err = aAsor.GetAccount(ctx, id)
+	require.Error(t, err)
+	_, err = aAsor.GetAccountByUsername(ctx, input.Username)
+	require.Error(t, err)
+	accs, err := aAsor.GetAccountList(ctx, []uint64{id})
+	require.NoError(t, err)
+	require.Empty(t, accs)
+	// Yet the username stays reserved
+	isTaken, err := aAsor.IsUsernameTaken(ctx, input.Username)
+	require.NoError(t, err)
+	require.True(t, isTaken)
+
+	ids, err := aAsor.GetDeletedAccountIdList(ctx, time.Now(), 0, 1000)
+	require.NoError(t, err)
+	require.Contains(t, ids, id)
+	ids, err = aAsor.GetDeletedAccountIdList(ctx, deletedAt, 0, 1000)
+	require.NoError(t, err)
+	require.NotContains(t, ids, id)
+	// Read page by page past the last id
+	ids, err = aAsor.GetDeletedAccountIdList(ctx, time.Now(), id-1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{id}, ids)
+	ids, err = aAsor.GetDeletedAccountIdList(ctx, time.Now(), id, 1000)
+	require.NoError(t, err)
+	require.NotContains(t, ids, id)
+
+	// Past the restore window
+	require.Error(t, aAsor.RestoreAccount(ctx, id, time.Now()))
+	require.NoError(t, aAsor.RestoreAccount(ctx, id, deletedAt.Add(-time.Minute)))
+	acc, err := aAsor.GetAccount(ctx, id)
+	require.NoError(t, err)
+	require.Nil(t, acc.DeletedAt)
+
+	// Only deleted accounts are purged
+	require.Error(t, aAsor.PurgeAccount(ctx, id, time.Now()))
+	require.NoError(t, aAsor.SoftDeleteAccount(ctx, id, deletedAt))
+	require.Error(t, aAsor.PurgeAccount(ctx, id, deletedAt))
+	require.NoError(t, aAsor.PurgeAccount(ctx, id, time.Now()))
+
+	isTaken, err = aAsor.IsUsernameTaken(ctx, input.Username)
+	require.NoError(t, err)
+	require.False(t, isTaken)
+}
+
+func TestListAccounts(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	// The underscore must match itself only, not any character
+	base := RandomString(20)
+	prefix := base + "_"
+	var ids []uint64
+	for i := 0; i < 3; i++ {
+		input := RandomAccount()
+		input.Username = prefix + RandomString(20)
+		id, err := aAsor.CreateAccount(ctx, input)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	decoy := RandomAccount()
+	decoy.Username = base + "x" + RandomString(20)
+	decoyId, err := aAsor.CreateAccount(ctx, decoy)
+	require.NoError(t, err)
+
+	accs, err := aAsor.ListAccounts(ctx, database.AccountListQuery{
+		UsernamePrefix: prefix,
+		Limit:          2,
+	})
+	require.NoError(t, err)
+	require.Len(t, accs, 2)
+	require.Equal(t, ids[0], accs[0].Id)
+	require.Equal(t, ids[1], accs[1].Id)
+
+	accs, err = aAsor.ListAccounts(ctx, database.AccountListQuery{
+		UsernamePrefix: prefix,
+		AfterId:        ids[1],
+		Limit:          2,
+	})
+	require.NoError(t, err)
+	require.Len(t, accs, 1)
+	require.Equal(t, ids[2], accs[0].Id)
+
+	accs, err = aAsor.ListAccounts(ctx, database.AccountListQuery{
+		UsernamePrefix: prefix,
+		AfterId:        ids[2],
+		IsDescending:   true,
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Len(t, accs, 2)
+	require.Equal(t, ids[1], accs[0].Id)
+	require.Equal(t, ids[0], accs[1].Id)
+
+	suspended := uint8(3)
+	require.NoError(t, aAsor.UpdateAccountStatus(ctx, ids[1], 2, suspended))
+	accs, err = aAsor.ListAccounts(ctx, database.AccountListQuery{
+		UsernamePrefix: prefix,
+		StatusId:       &suspended,
+		CreatedAfter:   time.Now().Add(-time.Hour),
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Len(t, accs, 1)
+	require.Equal(t, ids[1], accs[0].Id)
+
+	for _, id := range append(ids, decoyId) {
+		require.NoError(t, aAsor.DeleteAccount(ctx, id))
+	}
+}
+
+func TestStreamAccounts(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	prefix := RandomString(20)
+	var ids []uint64
+	for i := 0; i < 3; i++ {
+		input := RandomAccount()
+		input.Username = prefix + RandomString(20)
+		id, err := aAsor.CreateAccount(ctx, input)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	var streamed []uint64
+	err := aAsor.StreamAccounts(ctx, database.AccountListQuery{
+		UsernamePrefix: prefix,
+		IsDescending:   true,
+	}, func(acc database.Account) error {
+		streamed = append(streamed, acc.Id)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{ids[2], ids[1], ids[0]}, streamed)
+
+	// An error of fn stops the stream
+	errStop := errors.New("stop")
+	streamed = nil
+	err = aAsor.StreamAccounts(ctx, database.AccountListQuery{
+		UsernamePrefix: prefix,
+	}, func(acc database.Account) error {
+		streamed = append(streamed, acc.Id)
+		return errStop
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, []uint64{ids[0]}, streamed)
+
+	for _, id := range ids {
+		require.NoError(t, aAsor.DeleteAccount(ctx, id))
+	}
+}
+
+func TestSearchAccounts(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	word := RandomString(20)
+	other := RandomString(20)
+	byUsername := RandomAccount()
+	byUsername.Username = word + RandomString(10)
+	byUsername.Fullname = other + " " + RandomVnPersonName()
+	byUsernameId, err := aAsor.CreateAccount(ctx, byUsername)
+	require.NoError(t, err)
+	byFullname := RandomAccount()
+	byFullname.Fullname = RandomVnPersonName() + " " + word
+	byFullnameId, err := aAsor.CreateAccount(ctx, byFullname)
+	require.NoError(t, err)
+
+	results, err := aAsor.SearchAccounts(ctx, database.AccountSearchQuery{
+		Terms: []string{word[:10]},
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.ElementsMatch(t, []uint64{byUsernameId, byFullnameId},
+		[]uint64{results[0].Id, results[1].Id})
+	require.GreaterOrEqual(t, results[0].Score, results[1].Score)
+
+	// Pages by offset
+	page, err := aAsor.SearchAccounts(ctx, database.AccountSearchQuery{
+		Terms:  []string{word[:10]},
+		Offset: 1,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, results[1].Id, page[0].Id)
+
+	// Every term must match
+	results, err = aAsor.SearchAccounts(ctx, database.AccountSearchQuery{
+		Terms: []string{word[:10], other[:10]},
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, byUsernameId, results[0].Id)
+
+	_, err = aAsor.SearchAccounts(ctx, database.AccountSearchQuery{Limit: 10})
+	require.ErrorIs(t, err, database.ErrLackOfInfor)
+
+	require.NoError(t, aAsor.DeleteAccount(ctx, byUsernameId))
+	require.NoError(t, aAsor.DeleteAccount(ctx, byFullnameId))
+}
+
 func TestIsUsernameTaken(t *testing.T) {
 	aAsor := database.NewAccountAccessor(sqlDb, logger)
 	ctx := context.Background()
@@ -0,0 +1,56 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountTOTP(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	atAsor := database.NewAccountTOTPAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	accId, err := aAsor.CreateAccount(ctx, RandomAccount())
+	require.NoError(t, err)
+
+	_, err = atAsor.GetAccountTOTP(ctx, accId)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	input := database.AccountTOTP{
+		OfAccountId:     accId,
+		EncryptedSecret: RandomString(64),
+	}
+	require.NoError(t, atAsor.CreateAccountTOTP(ctx, input))
+
+	output, err := atAsor.GetAccountTOTP(ctx, accId)
+	require.NoError(t, err)
+	require.Equal(t, input.EncryptedSecret, output.EncryptedSecret)
+	require.Nil(t, output.ConfirmedAt)
+	require.Zero(t, output.LastUsedStep)
+
+	require.NoError(t, atAsor.ConfirmAccountTOTP(ctx, accId, time.Now()))
+	// Confirming twice changes nothing
+	require.Error(t, atAsor.ConfirmAccountTOTP(ctx, accId, time.Now()))
+
+	require.NoError(t, atAsor.UseAccountTOTPStep(ctx, accId, 100))
+	// Neither the same nor an earlier step can be used again
+	require.Error(t, atAsor.UseAccountTOTPStep(ctx, accId, 100))
+	require.Error(t, atAsor.UseAccountTOTPStep(ctx, accId, 99))
+	require.NoError(t, atAsor.UseAccountTOTPStep(ctx, accId, 101))
+
+	output, err = atAsor.GetAccountTOTP(ctx, accId)
+	require.NoError(t, err)
+	require.NotNil(t, output.ConfirmedAt)
+	require.Equal(t, int64(101), output.LastUsedStep)
+
+	require.NoError(t, atAsor.DeleteAccountTOTP(ctx, accId))
+	_, err = atAsor.GetAccountTOTP(ctx, accId)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.NoError(t, aAsor.DeleteAccount(ctx, accId))
+}
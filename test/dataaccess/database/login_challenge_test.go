@@ -0,0 +1,46 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginChallenge(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	lcAsor := database.NewLoginChallengeAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	accId, err := aAsor.CreateAccount(ctx, RandomAccount())
+	require.NoError(t, err)
+
+	input := database.LoginChallenge{
+		OfAccountId:     accId,
+		HashedChallenge: RandomString(64),
+		ExpiresAt:       time.Now().Add(time.Minute).Truncate(time.Second),
+	}
+	id, err := lcAsor.CreateLoginChallenge(ctx, input)
+	require.NoError(t, err)
+	require.NotZero(t, id)
+
+	require.NoError(t, lcAsor.IncreaseLoginChallengeFailedAttempts(ctx, id))
+	output, err := lcAsor.GetLoginChallengeByHashedChallenge(ctx, input.HashedChallenge)
+	require.NoError(t, err)
+	require.Equal(t, id, output.Id)
+	require.Equal(t, accId, output.OfAccountId)
+	require.Equal(t, uint32(1), output.FailedAttempts)
+	require.Nil(t, output.UsedAt)
+
+	require.NoError(t, lcAsor.UseLoginChallenge(ctx, id, time.Now()))
+	// A challenge can only be used once
+	require.Error(t, lcAsor.UseLoginChallenge(ctx, id, time.Now()))
+
+	require.NoError(t, lcAsor.DeleteLoginChallengeOfAccount(ctx, accId))
+	_, err = lcAsor.GetLoginChallengeByHashedChallenge(ctx, input.HashedChallenge)
+	require.Error(t, err)
+
+	require.NoError(t, aAsor.DeleteAccount(ctx, accId))
+}
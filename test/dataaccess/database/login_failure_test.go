@@ -0,0 +1,139 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAndGetLoginFailure(t *testing.T) {
+	lfAsor := database.NewLoginFailureAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	input := database.LoginFailure{
+		Scope:        database.LoginFailureScopeUsername,
+		Identifier:   RandomString(50),
+		FailureCount: 1,
+		LastFailedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, lfAsor.CreateLoginFailure(ctx, input))
+
+	output, err := lfAsor.GetLoginFailure(ctx, input.Scope, input.Identifier)
+	require.NoError(t, err)
+	require.Equal(t, input.Scope, output.Scope)
+	require.Equal(t, input.Identifier, output.Identifier)
+	require.Equal(t, input.FailureCount, output.FailureCount)
+	require.Nil(t, output.LockedUntil)
+
+	// The same identifier is tracked separately per scope
+	_, err = lfAsor.GetLoginFailure(ctx, database.LoginFailureScopeAddress, input.Identifier)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.NoError(t, lfAsor.DeleteLoginFailure(ctx, input.Scope, input.Identifier))
+	_, err = lfAsor.GetLoginFailure(ctx, input.Scope, input.Identifier)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUpdateLoginFailure(t *testing.T) {
+	lfAsor := database.NewLoginFailureAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	input := database.LoginFailure{
+		Scope:        database.LoginFailureScopeAddress,
+		Identifier:   RandomString(15),
+		FailureCount: 1,
+		LastFailedAt: time.Now().Truncate(time.Second),
+	}
+	require.NoError(t, lfAsor.CreateLoginFailure(ctx, input))
+
+	lockedUntil := time.Now().Add(time.Hour).Truncate(time.Second)
+	updated := input
+	updated.FailureCount = 10
+	updated.LockedUntil = &lockedUntil
+	require.NoError(t, lfAsor.UpdateLoginFailure(ctx, updated))
+
+	output, err := lfAsor.GetLoginFailure(ctx, input.Scope, input.Identifier)
+	require.NoError(t, err)
+	require.Equal(t, updated.FailureCount, output.FailureCount)
+	require.NotNil(t, output.LockedUntil)
+	require.True(t, lockedUntil.Equal(*output.LockedUntil))
+
+	require.NoError(t, lfAsor.DeleteLoginFailure(ctx, input.Scope, input.Identifier))
+}
+
+func TestIncrementLoginFailure(t *testing.T) {
+	lfAsor := database.NewLoginFailureAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	scope := database.LoginFailureScopeUsername
+	identifier := RandomString(50)
+	firstFailedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	output, err := lfAsor.IncrementLoginFailure(ctx, scope, identifier, firstFailedAt, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), output.FailureCount)
+	require.True(t, firstFailedAt.Equal(output.LastFailedAt))
+
+	// Failures older than resetBefore are forgotten unless locked
+	now := time.Now().Truncate(time.Second)
+	resetBefore := now.Add(-time.Minute)
+	output, err = lfAsor.IncrementLoginFailure(ctx, scope, identifier, now, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), output.FailureCount)
+	lockedUntil := now.Add(time.Hour)
+	isLocked, err := lfAsor.LockLoginFailure(ctx, scope, identifier, lockedUntil, now)
+	require.NoError(t, err)
+	require.True(t, isLocked)
+	isLocked, err = lfAsor.LockLoginFailure(ctx, scope, identifier, lockedUntil.Add(time.Hour), now)
+	require.NoError(t, err)
+	require.False(t, isLocked)
+
+	// The lock starts the count over
+	later := lockedUntil.Add(time.Minute)
+	output, err = lfAsor.IncrementLoginFailure(ctx, scope, identifier, now.Add(time.Second), &resetBefore)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), output.FailureCount)
+	require.NotNil(t, output.LockedUntil)
+	require.True(t, lockedUntil.Equal(*output.LockedUntil))
+	laterResetBefore := later.Add(-time.Minute)
+	output, err = lfAsor.IncrementLoginFailure(ctx, scope, identifier, later, &laterResetBefore)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), output.FailureCount)
+	require.Nil(t, output.LockedUntil)
+
+	require.NoError(t, lfAsor.DeleteLoginFailure(ctx, scope, identifier))
+}
+
+func TestIncrementLoginFailureConcurrently(t *testing.T) {
+	lfAsor := database.NewLoginFailureAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	scope := database.LoginFailureScopeAddress
+	identifier := RandomString(15)
+	const failures = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, failures)
+	for range failures {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := lfAsor.IncrementLoginFailure(ctx, scope, identifier, time.Now(), nil)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	output, err := lfAsor.GetLoginFailure(ctx, scope, identifier)
+	require.NoError(t, err)
+	require.Equal(t, uint32(failures), output.FailureCount)
+
+	require.NoError(t, lfAsor.DeleteLoginFailure(ctx, scope, identifier))
+}
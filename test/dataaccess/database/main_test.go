@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"log"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Fiagram/account_service/internal/configs"
@@ -15,11 +16,24 @@ var sqlDb *sql.DB
 var logger *zap.Logger
 
 func TestMain(m *testing.M) {
-	// Use the default config to test database connection
-	config, err := configs.NewConfig("")
+	// Use a fresh SQLite database, so the suite needs no server, unless a
+	// config is given to run it against another database
+	configFilePath := os.Getenv("ACCOUNT_SERVICE_TEST_CONFIG")
+	config, err := configs.NewConfig(configFilePath)
 	if err != nil {
 		log.Fatal("failed to init config default")
 	}
+	dir := ""
+	if configFilePath == "" {
+		dir, err = os.MkdirTemp("", "account_service_test")
+		if err != nil {
+			log.Fatal("failed to create the database directory")
+		}
+		config.Database = configs.Database{
+			Type:     string(configs.DatabaseTypeSqlite),
+			Database: filepath.Join(dir, "db_account_service.sqlite"),
+		}
+	}
 
 	logger = zap.NewNop()
 
@@ -27,8 +41,12 @@ func TestMain(m *testing.M) {
 	if err != nil {
 		log.Fatal("failed to init and migrate up database")
 	}
-	defer dbCleanup()
 	sqlDb = db
 
-	os.Exit(m.Run())
+	code := m.Run()
+	dbCleanup()
+	if dir != "" {
+		os.RemoveAll(dir)
+	}
+	os.Exit(code)
 }
@@ -0,0 +1,112 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/stretchr/testify/require"
+)
+
+func initMemoryDatabase(t *testing.T) *sql.DB {
+	db, dbCleanup, err := database.InitDatabase(configs.Database{
+		Type: string(configs.DatabaseTypeMemory),
+	}, logger)
+	require.NoError(t, err)
+	t.Cleanup(dbCleanup)
+	return db
+}
+
+func TestMemoryRefusedToServe(t *testing.T) {
+	_, _, err := database.InitAndCheckDatabase(configs.Database{
+		Type:        string(configs.DatabaseTypeMemory),
+		AutoMigrate: true,
+	}, logger)
+	require.ErrorIs(t, err, database.ErrDatabaseTypeTestOnly)
+}
+
+func TestMemoryTransaction(t *testing.T) {
+	db := initMemoryDatabase(t)
+	ctx := context.Background()
+	aAsor := database.NewAccountAccessor(db, logger)
+	apAsor := database.NewAccountPasswordAccessor(db, logger)
+
+	// Rolled back changes are seen within the transaction only
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	input := RandomAccount()
+	rolledBackId, err := aAsor.WithExecutor(tx).CreateAccount(ctx, input)
+	require.NoError(t, err)
+	_, err = aAsor.WithExecutor(tx).GetAccount(ctx, rolledBackId)
+	require.NoError(t, err)
+	_, err = aAsor.GetAccount(ctx, rolledBackId)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.NoError(t, tx.Rollback())
+	_, err = aAsor.GetAccount(ctx, rolledBackId)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Committed changes are seen by everyone
+	tx, err = db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	id, err := aAsor.WithExecutor(tx).CreateAccount(ctx, input)
+	require.NoError(t, err)
+	require.Greater(t, id, rolledBackId)
+	require.NoError(t, apAsor.WithExecutor(tx).CreateAccountPassword(ctx, database.AccountPassword{
+		OfAccountId:  id,
+		HashedString: RandomString(64),
+	}))
+	require.NoError(t, tx.Commit())
+	acc, err := aAsor.GetAccount(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, input.Username, acc.Username)
+	_, err = apAsor.GetAccountPassword(ctx, id)
+	require.NoError(t, err)
+
+	// The password holds on to the account, as the foreign key does
+	require.Error(t, aAsor.DeleteAccount(ctx, id))
+	require.NoError(t, apAsor.DeleteAccountPassword(ctx, id))
+	require.NoError(t, aAsor.DeleteAccount(ctx, id))
+}
+
+func TestMemoryTransactionConflict(t *testing.T) {
+	db := initMemoryDatabase(t)
+	ctx := context.Background()
+	aAsor := database.NewAccountAccessor(db, logger)
+
+	input := RandomAccount()
+	first, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer first.Rollback()
+	second, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer second.Rollback()
+
+	_, err = aAsor.WithExecutor(first).CreateAccount(ctx, input)
+	require.NoError(t, err)
+	_, err = aAsor.WithExecutor(second).CreateAccount(ctx, input)
+	require.NoError(t, err)
+
+	// The second commit replays its changes over the first, which took the
+	// username meanwhile
+	require.NoError(t, first.Commit())
+	require.Error(t, second.Commit())
+	accounts, err := aAsor.ListAccounts(ctx, database.AccountListQuery{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+}
+
+func TestMemoryRunsNoSQL(t *testing.T) {
+	db := initMemoryDatabase(t)
+	ctx := context.Background()
+
+	err := database.NewRefreshTokenAccessor(db, logger).
+		RevokeRefreshTokenOfAccount(ctx, 1, time.Now())
+	require.Error(t, err)
+
+	statuses, err := database.NewMigrator(db, logger).Status(ctx)
+	require.NoError(t, err)
+	require.Empty(t, statuses)
+}
@@ -0,0 +1,42 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator(t *testing.T) {
+	migrator := database.NewMigrator(sqlDb, logger)
+	ctx := context.Background()
+
+	// TestMain has migrated the schema up
+	require.NoError(t, migrator.CheckSchema(ctx))
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, statuses)
+	for _, status := range statuses {
+		require.NotNil(t, status.AppliedAt, status.Id)
+		require.False(t, status.IsUnknown, status.Id)
+	}
+
+	rolledBack, err := migrator.DownSteps(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, rolledBack)
+	require.ErrorIs(t, migrator.CheckSchema(ctx), database.ErrSchemaBehind)
+	behind, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Nil(t, behind[len(behind)-1].AppliedAt)
+
+	applied, err := migrator.UpSteps(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, applied)
+	require.NoError(t, migrator.CheckSchema(ctx))
+
+	id, err := migrator.Redo(ctx)
+	require.NoError(t, err)
+	require.Equal(t, statuses[len(statuses)-1].Id, id)
+	require.NoError(t, migrator.CheckSchema(ctx))
+}
@@ -0,0 +1,48 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordResetToken(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	prtAsor := database.NewPasswordResetTokenAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	accId, err := aAsor.CreateAccount(ctx, RandomAccount())
+	require.NoError(t, err)
+
+	input := database.PasswordResetToken{
+		OfAccountId: accId,
+		HashedToken: RandomString(64),
+		ExpiresAt:   time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+	id, err := prtAsor.CreatePasswordResetToken(ctx, input)
+	require.NoError(t, err)
+	require.NotZero(t, id)
+
+	output, err := prtAsor.GetPasswordResetTokenByHashedToken(ctx, input.HashedToken)
+	require.NoError(t, err)
+	require.Equal(t, id, output.Id)
+	require.Equal(t, input.OfAccountId, output.OfAccountId)
+	require.Nil(t, output.UsedAt)
+
+	require.NoError(t, prtAsor.UsePasswordResetToken(ctx, id, time.Now()))
+	// A token can only be used once
+	require.Error(t, prtAsor.UsePasswordResetToken(ctx, id, time.Now()))
+
+	output, err = prtAsor.GetPasswordResetTokenByHashedToken(ctx, input.HashedToken)
+	require.NoError(t, err)
+	require.NotNil(t, output.UsedAt)
+
+	require.NoError(t, prtAsor.DeletePasswordResetTokenOfAccount(ctx, accId))
+	_, err = prtAsor.GetPasswordResetTokenByHashedToken(ctx, input.HashedToken)
+	require.Error(t, err)
+
+	require.NoError(t, aAsor.DeleteAccount(ctx, accId))
+}
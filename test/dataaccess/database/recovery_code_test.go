@@ -0,0 +1,51 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoveryCode(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	rcAsor := database.NewRecoveryCodeAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	accId, err := aAsor.CreateAccount(ctx, RandomAccount())
+	require.NoError(t, err)
+
+	ids := make([]uint64, 0, 3)
+	for range 3 {
+		id, err := rcAsor.CreateRecoveryCode(ctx, database.RecoveryCode{
+			OfAccountId: accId,
+			HashedCode:  RandomString(64),
+		})
+		require.NoError(t, err)
+		require.NotZero(t, id)
+		ids = append(ids, id)
+	}
+
+	unused, err := rcAsor.GetUnusedRecoveryCodeList(ctx, accId)
+	require.NoError(t, err)
+	require.Len(t, unused, 3)
+
+	require.NoError(t, rcAsor.UseRecoveryCode(ctx, ids[1], time.Now()))
+	// A code can only be used once
+	require.Error(t, rcAsor.UseRecoveryCode(ctx, ids[1], time.Now()))
+
+	unused, err = rcAsor.GetUnusedRecoveryCodeList(ctx, accId)
+	require.NoError(t, err)
+	require.Len(t, unused, 2)
+	require.Equal(t, ids[0], unused[0].Id)
+	require.Equal(t, ids[2], unused[1].Id)
+
+	require.NoError(t, rcAsor.DeleteRecoveryCodeOfAccount(ctx, accId))
+	unused, err = rcAsor.GetUnusedRecoveryCodeList(ctx, accId)
+	require.NoError(t, err)
+	require.Empty(t, unused)
+
+	require.NoError(t, aAsor.DeleteAccount(ctx, accId))
+}
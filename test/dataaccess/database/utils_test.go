@@ -67,5 +67,6 @@ func RandomAccount() database.Account {
 		Email:       RandomGmailAddress(),
 		PhoneNumber: RandomVnPhoneNum(),
 		RoleId:      2,
+		StatusId:    2,
 	}
 }
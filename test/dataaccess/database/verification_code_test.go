@@ -0,0 +1,63 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerificationCode(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	vcAsor := database.NewVerificationCodeAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	acc := RandomAccount()
+	accId, err := aAsor.CreateAccount(ctx, acc)
+	require.NoError(t, err)
+
+	for range 2 {
+		_, err = vcAsor.CreateVerificationCode(ctx, database.VerificationCode{
+			OfAccountId: accId,
+			Channel:     database.VerificationChannelEmail,
+			Target:      acc.Email,
+			HashedCode:  RandomString(64),
+			ExpiresAt:   time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+	}
+	latestId, err := vcAsor.CreateVerificationCode(ctx, database.VerificationCode{
+		OfAccountId: accId,
+		Channel:     database.VerificationChannelEmail,
+		Target:      acc.Email,
+		HashedCode:  RandomString(64),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	latest, err := vcAsor.GetLatestVerificationCode(ctx, accId, database.VerificationChannelEmail)
+	require.NoError(t, err)
+	require.Equal(t, latestId, latest.Id)
+	require.Equal(t, acc.Email, latest.Target)
+	require.Zero(t, latest.FailedAttempts)
+	require.Nil(t, latest.UsedAt)
+
+	_, err = vcAsor.GetLatestVerificationCode(ctx, accId, database.VerificationChannelPhone)
+	require.Error(t, err)
+
+	require.NoError(t, vcAsor.IncreaseVerificationCodeFailedAttempts(ctx, latestId))
+	require.NoError(t, vcAsor.IncreaseVerificationCodeFailedAttempts(ctx, latestId))
+	require.NoError(t, vcAsor.UseVerificationCode(ctx, latestId, time.Now()))
+	// A code can only be used once
+	require.Error(t, vcAsor.UseVerificationCode(ctx, latestId, time.Now()))
+
+	latest, err = vcAsor.GetLatestVerificationCode(ctx, accId, database.VerificationChannelEmail)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), latest.FailedAttempts)
+	require.NotNil(t, latest.UsedAt)
+
+	require.NoError(t, vcAsor.DeleteVerificationCodeOfAccount(ctx, accId))
+	require.NoError(t, aAsor.DeleteAccount(ctx, accId))
+}
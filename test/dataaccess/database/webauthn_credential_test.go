@@ -0,0 +1,60 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebAuthnCredential(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	wcAsor := database.NewWebAuthnCredentialAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	accId, err := aAsor.CreateAccount(ctx, RandomAccount())
+	require.NoError(t, err)
+
+	input := database.WebAuthnCredential{
+		OfAccountId:     accId,
+		CredentialId:    RandomString(22),
+		PublicKey:       []byte(RandomString(77)),
+		AttestationType: "none",
+		AAGUID:          make([]byte, 16),
+		SignCount:       1,
+		Transports:      []string{"internal", "hybrid"},
+		BackupEligible:  true,
+	}
+	id, err := wcAsor.CreateWebAuthnCredential(ctx, input)
+	require.NoError(t, err)
+	require.NotZero(t, id)
+
+	output, err := wcAsor.GetWebAuthnCredentialByCredentialId(ctx, input.CredentialId)
+	require.NoError(t, err)
+	require.Equal(t, id, output.Id)
+	require.Equal(t, accId, output.OfAccountId)
+	require.Equal(t, input.PublicKey, output.PublicKey)
+	require.Equal(t, input.Transports, output.Transports)
+	require.True(t, output.BackupEligible)
+	require.Nil(t, output.LastUsedAt)
+
+	require.NoError(t, wcAsor.UseWebAuthnCredential(ctx, id, 5, true, time.Now()))
+	// The sign count must increase between assertions
+	require.Error(t, wcAsor.UseWebAuthnCredential(ctx, id, 5, true, time.Now()))
+	require.Error(t, wcAsor.UseWebAuthnCredential(ctx, id, 3, true, time.Now()))
+
+	list, err := wcAsor.GetWebAuthnCredentialList(ctx, accId)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, uint32(5), list[0].SignCount)
+	require.True(t, list[0].BackupState)
+	require.NotNil(t, list[0].LastUsedAt)
+
+	require.NoError(t, wcAsor.DeleteWebAuthnCredentialOfAccount(ctx, accId))
+	_, err = wcAsor.GetWebAuthnCredentialByCredentialId(ctx, input.CredentialId)
+	require.Error(t, err)
+
+	require.NoError(t, aAsor.DeleteAccount(ctx, accId))
+}
@@ -0,0 +1,61 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebAuthnSession(t *testing.T) {
+	aAsor := database.NewAccountAccessor(sqlDb, logger)
+	wsAsor := database.NewWebAuthnSessionAccessor(sqlDb, logger)
+	ctx := context.Background()
+
+	accId, err := aAsor.CreateAccount(ctx, RandomAccount())
+	require.NoError(t, err)
+
+	input := database.WebAuthnSession{
+		OfAccountId:   accId,
+		HashedSession: RandomString(64),
+		Ceremony:      database.WebAuthnCeremonyRegistration,
+		SessionData:   `{"challenge":"` + RandomString(43) + `"}`,
+		ExpiresAt:     time.Now().Add(time.Minute).Truncate(time.Second),
+	}
+	id, err := wsAsor.CreateWebAuthnSession(ctx, input)
+	require.NoError(t, err)
+	require.NotZero(t, id)
+
+	// Discoverable assertions do not know the account yet
+	anonymous := database.WebAuthnSession{
+		HashedSession: RandomString(64),
+		Ceremony:      database.WebAuthnCeremonyAssertion,
+		SessionData:   `{}`,
+		ExpiresAt:     input.ExpiresAt,
+	}
+	_, err = wsAsor.CreateWebAuthnSession(ctx, anonymous)
+	require.NoError(t, err)
+	output, err := wsAsor.GetWebAuthnSessionByHashedSession(ctx, anonymous.HashedSession)
+	require.NoError(t, err)
+	require.Zero(t, output.OfAccountId)
+
+	output, err = wsAsor.GetWebAuthnSessionByHashedSession(ctx, input.HashedSession)
+	require.NoError(t, err)
+	require.Equal(t, id, output.Id)
+	require.Equal(t, accId, output.OfAccountId)
+	require.Equal(t, input.Ceremony, output.Ceremony)
+	require.Equal(t, input.SessionData, output.SessionData)
+	require.Nil(t, output.UsedAt)
+
+	require.NoError(t, wsAsor.UseWebAuthnSession(ctx, id, time.Now()))
+	// A session can only be used once
+	require.Error(t, wsAsor.UseWebAuthnSession(ctx, id, time.Now()))
+
+	require.NoError(t, wsAsor.DeleteWebAuthnSessionOfAccount(ctx, accId))
+	_, err = wsAsor.GetWebAuthnSessionByHashedSession(ctx, input.HashedSession)
+	require.Error(t, err)
+
+	require.NoError(t, aAsor.DeleteAccount(ctx, accId))
+}
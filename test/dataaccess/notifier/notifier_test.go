@@ -0,0 +1,89 @@
+package notifier_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/notifier"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFileNotifier(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "notifications.log")
+	n, err := notifier.NewNotifier(configs.Notifier{
+		Type:     configs.NotifierTypeFile,
+		FilePath: filePath,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	sent := []notifier.Message{
+		{Channel: notifier.ChannelEmail, Recipient: "a@example.com", Subject: "first", Body: "one"},
+		{Channel: notifier.ChannelSMS, Recipient: "+15550100", Body: "two\nlines"},
+	}
+	for _, message := range sent {
+		require.NoError(t, n.Send(ctx, message))
+	}
+
+	file, err := os.Open(filePath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var received []notifier.Message
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var message notifier.Message
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &message))
+		received = append(received, message)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, received, len(sent))
+	for i := range sent {
+		require.Equal(t, sent[i].Channel, received[i].Channel)
+		require.Equal(t, sent[i].Recipient, received[i].Recipient)
+		require.Equal(t, sent[i].Subject, received[i].Subject)
+		require.Equal(t, sent[i].Body, received[i].Body)
+		require.False(t, received[i].CreatedAt.IsZero())
+	}
+}
+
+func TestNewNotifier(t *testing.T) {
+	n, err := notifier.NewNotifier(configs.Notifier{}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, n.Send(context.Background(), notifier.Message{Body: "logged"}))
+
+	_, err = notifier.NewNotifier(configs.Notifier{Type: configs.NotifierTypeFile}, zap.NewNop())
+	require.Error(t, err)
+
+	_, err = notifier.NewNotifier(configs.Notifier{Type: "carrier-pigeon"}, zap.NewNop())
+	require.Error(t, err)
+}
+
+func TestFileSMSSender(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "sms.log")
+	sender, err := notifier.NewSMSSender(configs.SMSSender{
+		Type:     configs.SMSSenderTypeFile,
+		FilePath: filePath,
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, sender.SendSMS(context.Background(), "+84912345678", "code 123456"))
+
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	var message notifier.Message
+	require.NoError(t, json.Unmarshal(content, &message))
+	require.Equal(t, notifier.ChannelSMS, message.Channel)
+	require.Equal(t, "+84912345678", message.Recipient)
+	require.Equal(t, "code 123456", message.Body)
+
+	_, err = notifier.NewSMSSender(configs.SMSSender{Type: configs.SMSSenderTypeFile}, zap.NewNop())
+	require.Error(t, err)
+}
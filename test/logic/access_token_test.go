@@ -0,0 +1,166 @@
+package logic_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func publicKeyFromJWK(t *testing.T, jwk logic.JSONWebKey) *ecdsa.PublicKey {
+	require.Equal(t, "EC", jwk.Kty)
+	require.Equal(t, "P-256", jwk.Crv)
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	require.NoError(t, err)
+	y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+	require.NoError(t, err)
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+}
+
+func TestIssueAccessTokenVerifiableByJWKS(t *testing.T) {
+	ctx := context.Background()
+	accessTokenLogic, err := logic.NewAccessToken(config.Auth.AccessToken, zap.NewNop())
+	require.NoError(t, err)
+
+	output, err := accessTokenLogic.IssueAccessToken(ctx, logic.IssueAccessTokenParams{
+		AccountId: 42,
+		Username:  "username",
+		Role:      logic.Admin,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, output.AccessToken)
+
+	jwks, err := accessTokenLogic.GetJWKS(ctx)
+	require.NoError(t, err)
+	require.Len(t, jwks.Keys, 1)
+	require.Equal(t, "ES256", jwks.Keys[0].Alg)
+
+	claims := &logic.AccessTokenClaims{}
+	token, err := jwt.ParseWithClaims(output.AccessToken, claims,
+		func(token *jwt.Token) (any, error) {
+			require.Equal(t, jwks.Keys[0].Kid, token.Header["kid"])
+			return publicKeyFromJWK(t, jwks.Keys[0]), nil
+		},
+		jwt.WithValidMethods([]string{"ES256"}),
+		jwt.WithIssuer(config.Auth.AccessToken.Issuer),
+		jwt.WithAudience(config.Auth.AccessToken.Audience),
+	)
+	require.NoError(t, err)
+	require.True(t, token.Valid)
+	require.Equal(t, "42", claims.Subject)
+	require.Equal(t, "username", claims.Username)
+	require.Equal(t, "admin", claims.Role)
+	require.WithinDuration(t, output.ExpiresAt, claims.ExpiresAt.Time, time.Second)
+}
+
+func TestAccessTokenWithConfiguredKeys(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	keyConfigs := make([]configs.SigningKey, 0, 2)
+	for _, id := range []string{"current", "previous"} {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		require.NoError(t, err)
+		path := filepath.Join(dir, id+".pem")
+		require.NoError(t, os.WriteFile(path,
+			pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+		keyConfigs = append(keyConfigs, configs.SigningKey{Id: id, PrivateKeyFile: path})
+	}
+
+	accessTokenConfig := config.Auth.AccessToken
+	accessTokenConfig.Keys = keyConfigs
+	accessTokenLogic, err := logic.NewAccessToken(accessTokenConfig, zap.NewNop())
+	require.NoError(t, err)
+
+	jwks, err := accessTokenLogic.GetJWKS(ctx)
+	require.NoError(t, err)
+	require.Len(t, jwks.Keys, 2)
+	require.Equal(t, "current", jwks.Keys[0].Kid)
+	require.Equal(t, "previous", jwks.Keys[1].Kid)
+
+	output, err := accessTokenLogic.IssueAccessToken(ctx, logic.IssueAccessTokenParams{
+		AccountId: 7,
+		Username:  "username",
+		Role:      logic.Member,
+	})
+	require.NoError(t, err)
+
+	token, _, err := jwt.NewParser().ParseUnverified(output.AccessToken, &logic.AccessTokenClaims{})
+	require.NoError(t, err)
+	require.Equal(t, "current", token.Header["kid"])
+}
+
+func TestAccessTokenWithMissingKeyFile(t *testing.T) {
+	accessTokenConfig := config.Auth.AccessToken
+	accessTokenConfig.Keys = []configs.SigningKey{{
+		Id:             "missing",
+		PrivateKeyFile: filepath.Join(t.TempDir(), "missing.pem"),
+	}}
+	_, err := logic.NewAccessToken(accessTokenConfig, zap.NewNop())
+	require.Error(t, err)
+}
+
+func TestVerifyAccessToken(t *testing.T) {
+	ctx := context.Background()
+	accessTokenLogic, err := logic.NewAccessToken(config.Auth.AccessToken, zap.NewNop())
+	require.NoError(t, err)
+
+	output, err := accessTokenLogic.IssueAccessToken(ctx, logic.IssueAccessTokenParams{
+		AccountId: 42,
+		Username:  "username",
+		Role:      logic.Member,
+	})
+	require.NoError(t, err)
+	verified, err := accessTokenLogic.VerifyAccessToken(ctx, logic.VerifyAccessTokenParams{
+		AccessToken: output.AccessToken,
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), verified.AccountId)
+	require.Equal(t, "username", verified.Username)
+
+	for _, accessToken := range []string{"", "not-a-token", output.AccessToken + "x"} {
+		_, err = accessTokenLogic.VerifyAccessToken(ctx, logic.VerifyAccessTokenParams{
+			AccessToken: accessToken,
+		})
+		require.ErrorIs(t, err, logic.ErrAccessTokenInvalid)
+	}
+
+	// Tokens of another key or past their lifetime are refused
+	otherLogic, err := logic.NewAccessToken(config.Auth.AccessToken, zap.NewNop())
+	require.NoError(t, err)
+	_, err = otherLogic.VerifyAccessToken(ctx, logic.VerifyAccessTokenParams{
+		AccessToken: output.AccessToken,
+	})
+	require.ErrorIs(t, err, logic.ErrAccessTokenInvalid)
+
+	expiredConfig := config.Auth.AccessToken
+	expiredConfig.TTL = -time.Minute
+	expiredLogic, err := logic.NewAccessToken(expiredConfig, zap.NewNop())
+	require.NoError(t, err)
+	expired, err := expiredLogic.IssueAccessToken(ctx, logic.IssueAccessTokenParams{AccountId: 42})
+	require.NoError(t, err)
+	_, err = expiredLogic.VerifyAccessToken(ctx, logic.VerifyAccessTokenParams{
+		AccessToken: expired.AccessToken,
+	})
+	require.ErrorIs(t, err, logic.ErrAccessTokenInvalid)
+}
@@ -0,0 +1,207 @@
+package logic_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/stretchr/testify/require"
+)
+
+// Serves a single account that can be deleted softly and restored.
+type stubDeletionAccountAccessor struct {
+	database.AccountAccessor
+	account database.Account
+}
+
+func (s *stubDeletionAccountAccessor) GetAccountByUsername(_ context.Context, username string) (database.Account, error) {
+	if username != s.account.Username || s.account.DeletedAt != nil {
+		return database.Account{}, errors.New("account not found")
+	}
+	return s.account, nil
+}
+
+func (s *stubDeletionAccountAccessor) SoftDeleteAccount(_ context.Context, id uint64, deletedAt time.Time) error {
+	if id != s.account.Id || s.account.DeletedAt != nil {
+		return errors.New("failed to effect row")
+	}
+	s.account.DeletedAt = &deletedAt
+	return nil
+}
+
+func (s *stubDeletionAccountAccessor) RestoreAccount(_ context.Context, id uint64, deletedAfter time.Time) error {
+	if id != s.account.Id || s.account.DeletedAt == nil || !s.account.DeletedAt.After(deletedAfter) {
+		return errors.New("failed to effect row")
+	}
+	s.account.DeletedAt = nil
+	return nil
+}
+
+func (s *stubDeletionAccountAccessor) WithExecutor(_ database.Executor) database.AccountAccessor {
+	return s
+}
+
+// Serves deleted accounts to purge, failing to purge the one of failingId.
+type stubPurgeAccountAccessor struct {
+	database.AccountAccessor
+	deletedIds []uint64
+	failingId  uint64
+}
+
+func (s *stubPurgeAccountAccessor) GetDeletedAccountIdList(
+	_ context.Context,
+	_ time.Time,
+	afterId uint64,
+	limit int,
+) ([]uint64, error) {
+	var ids []uint64
+	for _, id := range s.deletedIds {
+		if id > afterId && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (s *stubPurgeAccountAccessor) PurgeAccount(_ context.Context, id uint64, _ time.Time) error {
+	if id == s.failingId {
+		return errors.New("failed to effect row")
+	}
+	s.deletedIds = slices.DeleteFunc(s.deletedIds, func(deletedId uint64) bool { return deletedId == id })
+	return nil
+}
+
+func (s *stubPurgeAccountAccessor) WithExecutor(_ database.Executor) database.AccountAccessor {
+	return s
+}
+
+// The accessors below have nothing to remove on purge.
+
+type stubPurgeVerificationCodeAccessor struct {
+	database.VerificationCodeAccessor
+}
+
+func (s stubPurgeVerificationCodeAccessor) DeleteVerificationCodeOfAccount(_ context.Context, _ uint64) error {
+	return nil
+}
+
+func (s stubPurgeVerificationCodeAccessor) WithExecutor(_ database.Executor) database.VerificationCodeAccessor {
+	return s
+}
+
+type stubPurgeRecoveryCodeAccessor struct {
+	database.RecoveryCodeAccessor
+}
+
+func (s stubPurgeRecoveryCodeAccessor) DeleteRecoveryCodeOfAccount(_ context.Context, _ uint64) error {
+	return nil
+}
+
+func (s stubPurgeRecoveryCodeAccessor) WithExecutor(_ database.Executor) database.RecoveryCodeAccessor {
+	return s
+}
+
+type stubPurgePasswordResetTokenAccessor struct {
+	database.PasswordResetTokenAccessor
+}
+
+func (s stubPurgePasswordResetTokenAccessor) DeletePasswordResetTokenOfAccount(_ context.Context, _ uint64) error {
+	return nil
+}
+
+func (s stubPurgePasswordResetTokenAccessor) WithExecutor(_ database.Executor) database.PasswordResetTokenAccessor {
+	return s
+}
+
+type stubPurgeAccountPasswordHistoryAccessor struct {
+	database.AccountPasswordHistoryAccessor
+}
+
+func (s stubPurgeAccountPasswordHistoryAccessor) DeleteAccountPasswordHistoryOfAccount(_ context.Context, _ uint64) error {
+	return nil
+}
+
+func (s stubPurgeAccountPasswordHistoryAccessor) WithExecutor(_ database.Executor) database.AccountPasswordHistoryAccessor {
+	return s
+}
+
+func TestDeleteAndRestoreAccount(t *testing.T) {
+	ctx := context.Background()
+	accountAccessor := &stubDeletionAccountAccessor{account: database.Account{
+		Id:       1,
+		Username: RandomString(20),
+		RoleId:   uint8(logic.Member),
+		StatusId: uint8(logic.AccountStatusActive),
+	}}
+	refreshTokenAccessor := &stubRefreshTokenAccessor{}
+	accountLogic := newTestAccountLogic(t, func(deps *logic.AccountDependencies) {
+		deps.AccountAccessor = accountAccessor
+		deps.RefreshTokenAccessor = refreshTokenAccessor
+		deps.AccountDeletionConfig = configs.AccountDeletion{RetentionPeriod: time.Hour}
+	})
+
+	err := accountLogic.DeleteAccountByUsername(ctx, logic.DeleteAccountByUsernameParams{
+		Username: accountAccessor.account.Username,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, accountAccessor.account.DeletedAt)
+	// Deleting signs the account out everywhere
+	require.Equal(t, 1, refreshTokenAccessor.revocations)
+
+	err = accountLogic.DeleteAccount(ctx, logic.DeleteAccountParams{AccountId: 1})
+	require.Error(t, err)
+
+	output, err := accountLogic.RestoreAccount(ctx, logic.RestoreAccountParams{AccountId: 1})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), output.AccountId)
+	require.Nil(t, accountAccessor.account.DeletedAt)
+
+	_, err = accountLogic.RestoreAccount(ctx, logic.RestoreAccountParams{AccountId: 1})
+	require.ErrorIs(t, err, logic.ErrAccountNotRestorable)
+
+	// Past the retention period the account waits for its purge only
+	require.NoError(t, accountLogic.DeleteAccount(ctx, logic.DeleteAccountParams{AccountId: 1}))
+	deletedAt := time.Now().Add(-2 * time.Hour)
+	accountAccessor.account.DeletedAt = &deletedAt
+	_, err = accountLogic.RestoreAccount(ctx, logic.RestoreAccountParams{AccountId: 1})
+	require.ErrorIs(t, err, logic.ErrAccountNotRestorable)
+}
+
+func TestPurgeDeletedAccountsPastFailingOne(t *testing.T) {
+	ctx := context.Background()
+	accountAccessor := &stubPurgeAccountAccessor{
+		deletedIds: []uint64{1, 2, 3, 4, 5},
+		failingId:  1,
+	}
+	accountLogic := newTestAccountLogic(t, func(deps *logic.AccountDependencies) {
+		deps.AccountAccessor = accountAccessor
+		deps.AccountPasswordAccessor = stubAccountPasswordAccessor{}
+		deps.AccountPasswordHistoryAccessor = stubPurgeAccountPasswordHistoryAccessor{}
+		deps.RefreshTokenAccessor = &stubRefreshTokenAccessor{}
+		deps.PasswordResetTokenAccessor = stubPurgePasswordResetTokenAccessor{}
+		deps.VerificationCodeAccessor = stubPurgeVerificationCodeAccessor{}
+		deps.AccountTOTPAccessor = newStubAccountTOTPAccessor()
+		deps.LoginChallengeAccessor = newStubLoginChallengeAccessor()
+		deps.RecoveryCodeAccessor = stubPurgeRecoveryCodeAccessor{}
+		deps.WebAuthnCredentialAccessor = newStubWebAuthnCredentialAccessor()
+		deps.WebAuthnSessionAccessor = newStubWebAuthnSessionAccessor()
+		deps.AccountStatusChangeAccessor = &stubAccountStatusChangeAccessor{}
+		deps.AccountDeletionConfig = configs.AccountDeletion{RetentionPeriod: time.Hour, PurgeBatchSize: 2}
+	})
+
+	// The account failing at the head of the list does not stall the ones
+	// after it, which one run purges batch after batch
+	output, err := accountLogic.PurgeDeletedAccounts(ctx, logic.PurgeDeletedAccountsParams{})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3, 4, 5}, output.PurgedAccountIds)
+	require.Equal(t, []uint64{1}, accountAccessor.deletedIds)
+
+	output, err = accountLogic.PurgeDeletedAccounts(ctx, logic.PurgeDeletedAccountsParams{})
+	require.NoError(t, err)
+	require.Empty(t, output.PurgedAccountIds)
+}
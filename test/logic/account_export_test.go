@@ -0,0 +1,85 @@
+package logic_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/stretchr/testify/require"
+)
+
+func exportedAccountOf(id uint64) logic.ExportedAccount {
+	return logic.ExportedAccount{
+		AccountId: id,
+		AccountInfo: logic.AccountInfo{
+			Username:    "alice",
+			Fullname:    "Alice Nguyễn",
+			Email:       "alice@example.com",
+			PhoneNumber: "+84912345678",
+			Role:        logic.Admin,
+			Status:      logic.AccountStatusActive,
+		},
+		CreatedAt:      time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC),
+		HashedPassword: "$argon2id$v=19$m=8192,t=1,p=1$c2FsdA$a2V5",
+	}
+}
+
+func TestAccountExportWriterCSV(t *testing.T) {
+	var out bytes.Buffer
+	writer, err := logic.NewAccountExportWriter(&out, logic.AccountFileFormatCSV, logic.AccountExportOptions{})
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(exportedAccountOf(1)))
+	require.NoError(t, writer.Flush())
+	require.Equal(t,
+		"id,username,fullname,email,phone_number,role,status,created_at\n"+
+			"1,alice,Alice Nguyễn,alice@example.com,+84912345678,admin,active,2026-01-02T15:04:05Z\n",
+		out.String())
+
+	// The header is written even with no account
+	out.Reset()
+	writer, err = logic.NewAccountExportWriter(&out, logic.AccountFileFormatCSV,
+		logic.AccountExportOptions{Columns: []string{"Username", "email"}})
+	require.NoError(t, err)
+	require.NoError(t, writer.Flush())
+	require.Equal(t, "username,email\n", out.String())
+}
+
+func TestAccountExportWriterJSONLMaskPII(t *testing.T) {
+	var out bytes.Buffer
+	writer, err := logic.NewAccountExportWriter(&out, logic.AccountFileFormatJSONL, logic.AccountExportOptions{
+		Columns: []string{"id", "username", "fullname", "email", "phone_number"},
+		MaskPII: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(exportedAccountOf(7)))
+	require.NoError(t, writer.Flush())
+	require.Equal(t,
+		`{"id":7,"username":"alice","fullname":"A**** N*****","email":"a****@example.com","phone_number":"**********78"}`+"\n",
+		out.String())
+}
+
+func TestAccountExportWriterPasswordHashes(t *testing.T) {
+	var out bytes.Buffer
+	_, err := logic.NewAccountExportWriter(&out, logic.AccountFileFormatCSV,
+		logic.AccountExportOptions{Columns: []string{"username", "hashed_password"}})
+	require.ErrorIs(t, err, logic.ErrPasswordHashExportForbidden)
+
+	writer, err := logic.NewAccountExportWriter(&out, logic.AccountFileFormatCSV,
+		logic.AccountExportOptions{IncludePasswordHashes: true})
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(exportedAccountOf(1)))
+	require.NoError(t, writer.Flush())
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.True(t, strings.HasSuffix(lines[0], ",hashed_password"))
+	require.True(t, strings.HasSuffix(lines[1], `,"$argon2id$v=19$m=8192,t=1,p=1$c2FsdA$a2V5"`))
+
+	for _, columns := range [][]string{{"username", "nickname"}, {"email", "email"}} {
+		_, err := logic.NewAccountExportWriter(&out, logic.AccountFileFormatCSV,
+			logic.AccountExportOptions{Columns: columns})
+		require.Error(t, err)
+	}
+	_, err = logic.NewAccountExportWriter(&out, logic.AccountFileFormatNone, logic.AccountExportOptions{})
+	require.ErrorIs(t, err, logic.ErrAccountFileFormatInvalid)
+}
@@ -0,0 +1,201 @@
+package logic_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/stretchr/testify/require"
+)
+
+// Creates accounts with increasing ids, failing for the usernames in failing.
+type stubImportAccountAccessor struct {
+	database.AccountAccessor
+	taken   map[string]bool
+	failing map[string]bool
+	created []database.Account
+}
+
+func (s *stubImportAccountAccessor) IsUsernameTaken(_ context.Context, username string) (bool, error) {
+	return s.taken[strings.ToLower(username)], nil
+}
+
+func (s *stubImportAccountAccessor) CreateAccount(_ context.Context, acc database.Account) (uint64, error) {
+	if s.failing[acc.Username] {
+		return 0, errors.New("failed to insert")
+	}
+	acc.Id = uint64(len(s.created) + 1)
+	s.created = append(s.created, acc)
+	return acc.Id, nil
+}
+
+func (s *stubImportAccountAccessor) WithExecutor(_ database.Executor) database.AccountAccessor {
+	return s
+}
+
+type stubImportAccountPasswordAccessor struct {
+	database.AccountPasswordAccessor
+	passwords map[uint64]string
+}
+
+func (s *stubImportAccountPasswordAccessor) CreateAccountPassword(_ context.Context, password database.AccountPassword) error {
+	s.passwords[password.OfAccountId] = password.HashedString
+	return nil
+}
+
+func (s *stubImportAccountPasswordAccessor) WithExecutor(_ database.Executor) database.AccountPasswordAccessor {
+	return s
+}
+
+func newStubImportAccountLogic(
+	t *testing.T,
+	accountAccessor database.AccountAccessor,
+	accountPasswordAccessor database.AccountPasswordAccessor,
+) (logic.Account, logic.Hash) {
+	hashLogic := newFastHashLogic(t)
+	accountLogic := newTestAccountLogic(t, func(deps *logic.AccountDependencies) {
+		deps.AccountAccessor = accountAccessor
+		deps.AccountPasswordAccessor = accountPasswordAccessor
+		deps.HashLogic = hashLogic
+	})
+	return accountLogic, hashLogic
+}
+
+func outcomesOf(output logic.ImportAccountsOutput) []logic.ImportAccountsOutcome {
+	outcomes := make([]logic.ImportAccountsOutcome, 0, len(output.Results))
+	for _, result := range output.Results {
+		outcomes = append(outcomes, result.Outcome)
+	}
+	return outcomes
+}
+
+func TestImportAccountsCSV(t *testing.T) {
+	ctx := context.Background()
+	accountAccessor := &stubImportAccountAccessor{taken: map[string]bool{"taken": true}}
+	accountPasswordAccessor := &stubImportAccountPasswordAccessor{passwords: map[uint64]string{}}
+	accountLogic, hashLogic := newStubImportAccountLogic(t, accountAccessor, accountPasswordAccessor)
+
+	hashed, err := hashLogic.Hash(ctx, "imported-elsewhere")
+	require.NoError(t, err)
+	file := "Username,Email,Role,Status,Password,Hashed_Password\n" +
+		"alice,alice@example.com,admin,pending,correct-horse,\n" +
+		"bob,,,,,\"" + hashed + "\"\n" +
+		"ALICE,,,,correct-horse,\n" +
+		"taken,,,,correct-horse,\n" +
+		"carol,,owner,,correct-horse,\n" +
+		"dave,,,,short,\n" +
+		"erin,,,suspended,correct-horse,\n" +
+		"frank,,,,,not-a-hash\n" +
+		"heidi,,,,,$argon2id$v=19$m=8,t=0,p=0$c2FsdHNhbHQ$a2V5a2V5a2V5\n" +
+		"grace,only,three\n"
+
+	rows, err := logic.NewAccountImportReader(strings.NewReader(file), logic.AccountFileFormatCSV)
+	require.NoError(t, err)
+	output, err := accountLogic.ImportAccounts(ctx, logic.ImportAccountsParams{Rows: rows})
+	require.NoError(t, err)
+	require.Equal(t, []logic.ImportAccountsOutcome{
+		logic.ImportAccountsOutcomeCreated,
+		logic.ImportAccountsOutcomeCreated,
+		logic.ImportAccountsOutcomeDuplicate,
+		logic.ImportAccountsOutcomeDuplicate,
+		logic.ImportAccountsOutcomeInvalid,
+		logic.ImportAccountsOutcomeInvalid,
+		logic.ImportAccountsOutcomeInvalid,
+		logic.ImportAccountsOutcomeInvalid,
+		logic.ImportAccountsOutcomeInvalid,
+		logic.ImportAccountsOutcomeInvalid,
+	}, outcomesOf(output))
+	require.Equal(t, 2, output.Counts[logic.ImportAccountsOutcomeCreated])
+	require.Equal(t, 6, output.Counts[logic.ImportAccountsOutcomeInvalid])
+	for i, result := range output.Results {
+		require.Equal(t, i+1, result.Row)
+	}
+
+	require.Len(t, accountAccessor.created, 2)
+	alice := accountAccessor.created[0]
+	require.Equal(t, "alice", alice.Username)
+	require.Equal(t, uint8(logic.Admin), alice.RoleId)
+	require.Equal(t, uint8(logic.AccountStatusPending), alice.StatusId)
+	require.Equal(t, alice.Id, output.Results[0].AccountId)
+	isMatched, err := hashLogic.IsHashEqual(ctx, "correct-horse", accountPasswordAccessor.passwords[alice.Id])
+	require.NoError(t, err)
+	require.True(t, isMatched)
+
+	// A hashed password is stored as given
+	bob := accountAccessor.created[1]
+	require.Equal(t, uint8(logic.Member), bob.RoleId)
+	require.Equal(t, uint8(logic.AccountStatusActive), bob.StatusId)
+	require.Equal(t, hashed, accountPasswordAccessor.passwords[bob.Id])
+}
+
+func TestImportAccountsJSONLDryRun(t *testing.T) {
+	ctx := context.Background()
+	accountAccessor := &stubImportAccountAccessor{}
+	accountPasswordAccessor := &stubImportAccountPasswordAccessor{passwords: map[uint64]string{}}
+	accountLogic, _ := newStubImportAccountLogic(t, accountAccessor, accountPasswordAccessor)
+
+	file := `{"username": "alice", "password": "correct-horse"}` + "\n" +
+		"\n" +
+		`{"username": "bob", "password": "correct-horse", "nickname": "b"}` + "\n" +
+		`{"username": "carol", "password": "correct-horse", "hashed_password": "x"}` + "\n"
+
+	rows, err := logic.NewAccountImportReader(strings.NewReader(file), logic.AccountFileFormatJSONL)
+	require.NoError(t, err)
+	output, err := accountLogic.ImportAccounts(ctx, logic.ImportAccountsParams{
+		Rows:   rows,
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []logic.ImportAccountsOutcome{
+		logic.ImportAccountsOutcomeValid,
+		logic.ImportAccountsOutcomeInvalid,
+		logic.ImportAccountsOutcomeInvalid,
+	}, outcomesOf(output))
+	require.Empty(t, accountAccessor.created)
+	require.Empty(t, accountPasswordAccessor.passwords)
+}
+
+func TestImportAccountsBatchFallback(t *testing.T) {
+	ctx := context.Background()
+	accountAccessor := &stubImportAccountAccessor{failing: map[string]bool{"bob": true}}
+	accountPasswordAccessor := &stubImportAccountPasswordAccessor{passwords: map[uint64]string{}}
+	accountLogic, _ := newStubImportAccountLogic(t, accountAccessor, accountPasswordAccessor)
+
+	file := "username,password\n" +
+		"alice,correct-horse\n" +
+		"bob,correct-horse\n" +
+		"carol,correct-horse\n"
+	rows, err := logic.NewAccountImportReader(strings.NewReader(file), logic.AccountFileFormatCSV)
+	require.NoError(t, err)
+	output, err := accountLogic.ImportAccounts(ctx, logic.ImportAccountsParams{
+		Rows:      rows,
+		BatchSize: 3,
+	})
+	require.NoError(t, err)
+
+	// The failing row is reported alone, the others of its batch are created
+	require.Equal(t, []logic.ImportAccountsOutcome{
+		logic.ImportAccountsOutcomeCreated,
+		logic.ImportAccountsOutcomeFailed,
+		logic.ImportAccountsOutcomeCreated,
+	}, outcomesOf(output))
+	require.NotZero(t, output.Results[0].AccountId)
+	require.NotZero(t, output.Results[2].AccountId)
+	require.NotEmpty(t, output.Results[1].Message)
+}
+
+func TestNewAccountImportReaderInvalid(t *testing.T) {
+	_, err := logic.NewAccountImportReader(strings.NewReader(""), logic.AccountFileFormatNone)
+	require.ErrorIs(t, err, logic.ErrAccountFileFormatInvalid)
+
+	for _, header := range []string{"email,password\n", "username,nickname\n", "username,username\n"} {
+		rows, err := logic.NewAccountImportReader(strings.NewReader(header), logic.AccountFileFormatCSV)
+		require.NoError(t, err)
+		_, err = rows.Read()
+		require.Error(t, err)
+		require.NotErrorIs(t, err, logic.ErrImportRowUnreadable)
+	}
+}
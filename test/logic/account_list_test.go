@@ -0,0 +1,171 @@
+package logic_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/stretchr/testify/require"
+)
+
+// Pages through accounts held in id order, ignoring the filters.
+type stubListAccountAccessor struct {
+	database.AccountAccessor
+	accounts []database.Account
+}
+
+func (s *stubListAccountAccessor) ListAccounts(_ context.Context, query database.AccountListQuery) ([]database.Account, error) {
+	var out []database.Account
+	for i := range s.accounts {
+		acc := s.accounts[i]
+		if query.IsDescending {
+			acc = s.accounts[len(s.accounts)-1-i]
+		}
+		if query.AfterId != 0 &&
+			(!query.IsDescending && acc.Id <= query.AfterId || query.IsDescending && acc.Id >= query.AfterId) {
+			continue
+		}
+		if len(out) == query.Limit {
+			break
+		}
+		out = append(out, acc)
+	}
+	return out, nil
+}
+
+func newStubListAccountLogic(t testing.TB, accountAccessor database.AccountAccessor) logic.Account {
+	return newTestAccountLogic(t, func(deps *logic.AccountDependencies) {
+		deps.AccountAccessor = accountAccessor
+	})
+}
+
+func TestListAccounts(t *testing.T) {
+	ctx := context.Background()
+	accountAccessor := &stubListAccountAccessor{}
+	for id := uint64(1); id <= 5; id++ {
+		accountAccessor.accounts = append(accountAccessor.accounts, database.Account{
+			Id:       id,
+			Username: RandomString(20),
+			RoleId:   uint8(logic.Member),
+			StatusId: uint8(logic.AccountStatusActive),
+		})
+	}
+	accountLogic := newStubListAccountLogic(t, accountAccessor)
+
+	params := logic.ListAccountsParams{PageSize: 2, Order: logic.AccountOrderIdDescending}
+	var ids []uint64
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+		output, err := accountLogic.ListAccounts(ctx, params)
+		require.NoError(t, err)
+		ids = append(ids, output.AccountIds...)
+		require.Len(t, output.AccountInfos, len(output.AccountIds))
+		if output.NextPageToken == "" {
+			break
+		}
+		params.PageToken = output.NextPageToken
+	}
+	require.Equal(t, []uint64{5, 4, 3, 2, 1}, ids)
+
+	// An exactly full last page has no page after it
+	output, err := accountLogic.ListAccounts(ctx, logic.ListAccountsParams{PageSize: 5})
+	require.NoError(t, err)
+	require.Len(t, output.AccountIds, 5)
+	require.Empty(t, output.NextPageToken)
+
+	_, err = accountLogic.ListAccounts(ctx, logic.ListAccountsParams{PageSize: -1})
+	require.ErrorIs(t, err, logic.ErrPageSizeInvalid)
+}
+
+func TestListAccountsPageTokenInvalid(t *testing.T) {
+	ctx := context.Background()
+	accountAccessor := &stubListAccountAccessor{}
+	for id := uint64(1); id <= 3; id++ {
+		accountAccessor.accounts = append(accountAccessor.accounts, database.Account{Id: id})
+	}
+	accountLogic := newStubListAccountLogic(t, accountAccessor)
+
+	output, err := accountLogic.ListAccounts(ctx, logic.ListAccountsParams{PageSize: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, output.NextPageToken)
+
+	_, err = accountLogic.ListAccounts(ctx, logic.ListAccountsParams{
+		PageSize:  1,
+		PageToken: RandomString(20),
+	})
+	require.ErrorIs(t, err, logic.ErrPageTokenInvalid)
+
+	// A token only pages the listing it came from
+	_, err = accountLogic.ListAccounts(ctx, logic.ListAccountsParams{
+		PageSize:      1,
+		PageToken:     output.NextPageToken,
+		AccountFilter: logic.AccountFilter{UsernamePrefix: "a"},
+	})
+	require.ErrorIs(t, err, logic.ErrPageTokenInvalid)
+	_, err = accountLogic.ListAccounts(ctx, logic.ListAccountsParams{
+		PageSize:  1,
+		PageToken: output.NextPageToken,
+		Order:     logic.AccountOrderIdDescending,
+	})
+	require.ErrorIs(t, err, logic.ErrPageTokenInvalid)
+
+	// The page size may change between pages
+	output, err = accountLogic.ListAccounts(ctx, logic.ListAccountsParams{
+		PageSize:  5,
+		PageToken: output.NextPageToken,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, output.AccountIds)
+}
+
+func (s *stubListAccountAccessor) StreamAccounts(_ context.Context, query database.AccountListQuery, fn func(database.Account) error) error {
+	accs, err := s.ListAccounts(context.Background(), database.AccountListQuery{
+		IsDescending: query.IsDescending,
+		Limit:        len(s.accounts),
+	})
+	if err != nil {
+		return err
+	}
+	for _, acc := range accs {
+		if err := fn(acc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestStreamAccounts(t *testing.T) {
+	ctx := context.Background()
+	accountAccessor := &stubListAccountAccessor{}
+	for id := uint64(1); id <= 3; id++ {
+		accountAccessor.accounts = append(accountAccessor.accounts, database.Account{
+			Id:       id,
+			Username: RandomString(20),
+			StatusId: uint8(logic.AccountStatusActive),
+		})
+	}
+	accountLogic := newStubListAccountLogic(t, accountAccessor)
+
+	var streamed []logic.StreamAccountsOutput
+	err := accountLogic.StreamAccounts(ctx, logic.StreamAccountsParams{
+		Order: logic.AccountOrderIdDescending,
+	}, func(output logic.StreamAccountsOutput) error {
+		streamed = append(streamed, output)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, streamed, 3)
+	require.Equal(t, uint64(3), streamed[0].AccountId)
+	require.Equal(t, accountAccessor.accounts[2].Username, streamed[0].AccountInfo.Username)
+	require.Equal(t, logic.AccountStatusActive, streamed[0].AccountInfo.Status)
+
+	// The error of a failed send comes back as is, say the client has gone
+	errGone := errors.New("client has gone")
+	err = accountLogic.StreamAccounts(ctx, logic.StreamAccountsParams{},
+		func(output logic.StreamAccountsOutput) error {
+			return errGone
+		})
+	require.ErrorIs(t, err, errGone)
+}
@@ -0,0 +1,208 @@
+package logic_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Fails the revocations of the refresh tokens, so the transaction around
+// them is rolled back.
+type failingRefreshTokenAccessor struct {
+	database.RefreshTokenAccessor
+}
+
+func (s failingRefreshTokenAccessor) RevokeRefreshTokenOfAccount(_ context.Context, _ uint64, _ time.Time) error {
+	return errors.New("failed to revoke")
+}
+
+func (s failingRefreshTokenAccessor) WithExecutor(_ database.Executor) database.RefreshTokenAccessor {
+	return s
+}
+
+// Builds an account logic over a memory database, with its accounts,
+// passwords and roles held by the memory accessors.
+func newMemoryAccountLogic(
+	t *testing.T,
+	refreshTokenAccessor database.RefreshTokenAccessor,
+) (logic.Account, database.AccountRoleAccessor) {
+	db, dbCleanup, err := database.InitDatabase(configs.Database{
+		Type: string(configs.DatabaseTypeMemory),
+	}, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(dbCleanup)
+
+	accountAccessor := database.NewAccountAccessor(db, zap.NewNop())
+	loginThrottleLogic := logic.NewLoginThrottle(newStubLoginFailureAccessor(), configs.Lockout{}, zap.NewNop())
+	secondFactorLogic := logic.NewSecondFactor(accountAccessor, newStubLoginChallengeAccessor(),
+		&stubTOTP{}, &stubRecoveryCode{}, loginThrottleLogic,
+		configs.SecondFactor{ChallengeTTL: time.Minute, MaxAttempts: 3}, zap.NewNop())
+	accountLogic := newTestAccountLogic(t, func(deps *logic.AccountDependencies) {
+		deps.DB = db
+		deps.AccountAccessor = accountAccessor
+		deps.AccountPasswordAccessor = database.NewAccountPasswordAccessor(db, zap.NewNop())
+		deps.RefreshTokenAccessor = refreshTokenAccessor
+		deps.LoginThrottleLogic = loginThrottleLogic
+		deps.SecondFactorLogic = secondFactorLogic
+		deps.AccountDeletionConfig = configs.AccountDeletion{RetentionPeriod: time.Hour}
+	})
+	return accountLogic, database.NewAccountRoleAccessor(db, zap.NewNop())
+}
+
+func requireStatusCode(t *testing.T, err error, code codes.Code) {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok, err)
+	require.Equal(t, code, st.Code(), err)
+}
+
+func TestMemoryCreateAndGetAccount(t *testing.T) {
+	ctx := context.Background()
+	accountLogic, roleAccessor := newMemoryAccountLogic(t, &stubRefreshTokenAccessor{})
+
+	info := logic.AccountInfo{
+		Username: RandomString(20),
+		Fullname: "Nguyen Van A",
+		Email:    "a@example.com",
+		Role:     logic.Admin,
+	}
+	created, err := accountLogic.CreateAccount(ctx, logic.CreateAccountParams{
+		AccountInfo: info,
+		Password:    "correct-horse",
+	})
+	require.NoError(t, err)
+	require.NotZero(t, created.AccountId)
+
+	got, err := accountLogic.GetAccount(ctx, logic.GetAccountParams{AccountId: created.AccountId})
+	require.NoError(t, err)
+	require.Equal(t, info.Username, got.AccountInfo.Username)
+	require.Equal(t, info.Email, got.AccountInfo.Email)
+	require.Equal(t, logic.Admin, got.AccountInfo.Role)
+	require.Equal(t, logic.AccountStatusActive, got.AccountInfo.Status)
+	role, err := roleAccessor.GetRoleById(ctx, uint8(got.AccountInfo.Role))
+	require.NoError(t, err)
+	require.Equal(t, "admin", role.Name)
+
+	valid, err := accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+		Username: info.Username,
+		Password: "correct-horse",
+	})
+	require.NoError(t, err)
+	require.Equal(t, created.AccountId, valid.AccountId)
+
+	// Usernames compare case-insensitively
+	info.Username = strings.ToUpper(info.Username)
+	_, err = accountLogic.CreateAccount(ctx, logic.CreateAccountParams{
+		AccountInfo: info,
+		Password:    "correct-horse",
+	})
+	requireStatusCode(t, err, codes.AlreadyExists)
+
+	_, err = accountLogic.GetAccount(ctx, logic.GetAccountParams{AccountId: created.AccountId + 1})
+	requireStatusCode(t, err, codes.NotFound)
+}
+
+func TestMemoryUpdateAccountInfo(t *testing.T) {
+	ctx := context.Background()
+	accountLogic, _ := newMemoryAccountLogic(t, &stubRefreshTokenAccessor{})
+
+	info := logic.AccountInfo{
+		Username: RandomString(20),
+		Email:    "before@example.com",
+		Role:     logic.Member,
+	}
+	created, err := accountLogic.CreateAccount(ctx, logic.CreateAccountParams{
+		AccountInfo: info,
+		Password:    "correct-horse",
+	})
+	require.NoError(t, err)
+
+	info.Fullname = "Tran Thi B"
+	info.Email = "after@example.com"
+	_, err = accountLogic.UpdateAccountInfo(ctx, logic.UpdateAccountInfoParams{
+		AccountId:          created.AccountId,
+		UpdatedAccountInfo: info,
+	})
+	require.NoError(t, err)
+
+	got, err := accountLogic.GetAccount(ctx, logic.GetAccountParams{AccountId: created.AccountId})
+	require.NoError(t, err)
+	require.Equal(t, info.Fullname, got.AccountInfo.Fullname)
+	require.Equal(t, info.Email, got.AccountInfo.Email)
+}
+
+func TestMemoryDeleteAndRestoreAccountByUsername(t *testing.T) {
+	ctx := context.Background()
+	refreshTokenAccessor := &stubRefreshTokenAccessor{}
+	accountLogic, _ := newMemoryAccountLogic(t, refreshTokenAccessor)
+
+	username := RandomString(20)
+	created, err := accountLogic.CreateAccount(ctx, logic.CreateAccountParams{
+		AccountInfo: logic.AccountInfo{Username: username, Role: logic.Member},
+		Password:    "correct-horse",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, accountLogic.DeleteAccountByUsername(ctx, logic.DeleteAccountByUsernameParams{
+		Username: username,
+	}))
+	require.Equal(t, 1, refreshTokenAccessor.revocations)
+	_, err = accountLogic.GetAccount(ctx, logic.GetAccountParams{AccountId: created.AccountId})
+	requireStatusCode(t, err, codes.NotFound)
+	err = accountLogic.DeleteAccountByUsername(ctx, logic.DeleteAccountByUsernameParams{
+		Username: username,
+	})
+	requireStatusCode(t, err, codes.NotFound)
+
+	// The username stays reserved until the account is purged
+	isTaken, err := accountLogic.IsUsernameTaken(ctx, logic.IsUsernameTakenParams{Username: username})
+	require.NoError(t, err)
+	require.True(t, isTaken.IsTaken)
+
+	_, err = accountLogic.RestoreAccount(ctx, logic.RestoreAccountParams{AccountId: created.AccountId})
+	require.NoError(t, err)
+	_, err = accountLogic.GetAccount(ctx, logic.GetAccountParams{AccountId: created.AccountId})
+	require.NoError(t, err)
+}
+
+func TestMemoryChangePasswordRolledBack(t *testing.T) {
+	ctx := context.Background()
+	accountLogic, _ := newMemoryAccountLogic(t, failingRefreshTokenAccessor{})
+
+	username := RandomString(20)
+	created, err := accountLogic.CreateAccount(ctx, logic.CreateAccountParams{
+		AccountInfo: logic.AccountInfo{Username: username, Role: logic.Member},
+		Password:    "correct-horse",
+	})
+	require.NoError(t, err)
+
+	// The password is updated within the transaction, which the failing
+	// revocation rolls back
+	_, err = accountLogic.ChangePassword(ctx, logic.ChangePasswordParams{
+		AccountId:       created.AccountId,
+		CurrentPassword: "correct-horse",
+		NewPassword:     "battery-staple",
+	})
+	requireStatusCode(t, err, codes.Internal)
+
+	_, err = accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+		Username: username,
+		Password: "correct-horse",
+	})
+	require.NoError(t, err)
+	_, err = accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+		Username: username,
+		Password: "battery-staple",
+	})
+	require.ErrorIs(t, err, logic.ErrInvalidCredentials)
+}
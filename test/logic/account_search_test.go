@@ -0,0 +1,103 @@
+package logic_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/stretchr/testify/require"
+)
+
+// Returns the held results as found, paging them by offset.
+type stubSearchAccountAccessor struct {
+	database.AccountAccessor
+	results []database.AccountSearchResult
+	queries []database.AccountSearchQuery
+}
+
+func (s *stubSearchAccountAccessor) SearchAccounts(_ context.Context, query database.AccountSearchQuery) ([]database.AccountSearchResult, error) {
+	s.queries = append(s.queries, query)
+	if query.Offset >= len(s.results) {
+		return nil, nil
+	}
+	results := s.results[query.Offset:]
+	if len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results, nil
+}
+
+func TestSearchAccounts(t *testing.T) {
+	ctx := context.Background()
+	accountAccessor := &stubSearchAccountAccessor{results: []database.AccountSearchResult{
+		{
+			Account: database.Account{
+				Id:       1,
+				Username: "vanan",
+				Fullname: "Nguyen Van An",
+				Email:    "an.nguyen@gmail.com",
+			},
+			Score: 2,
+		},
+		{
+			Account: database.Account{
+				Id:       2,
+				Username: "tranan",
+				Fullname: "<b>An</b> Tran",
+				Email:    "tran@gmail.com",
+			},
+			Score: 1,
+		},
+	}}
+	accountLogic := newStubListAccountLogic(t, accountAccessor)
+
+	output, err := accountLogic.SearchAccounts(ctx, logic.SearchAccountsParams{
+		Query:    "  An, an  NGUYEN! ",
+		PageSize: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"an", "nguyen"}, accountAccessor.queries[0].Terms)
+	require.Len(t, output.Results, 1)
+	require.NotEmpty(t, output.NextPageToken)
+	result := output.Results[0]
+	require.Equal(t, uint64(1), result.AccountId)
+	require.Equal(t, float64(2), result.Score)
+	// Inside a word, a term is highlighted only when it starts no word, and
+	// adjacent matches are highlighted together
+	require.Equal(t, []logic.AccountFieldHighlight{
+		{Field: logic.AccountFieldUsername, Highlighted: "v<em>anan</em>"},
+		{Field: logic.AccountFieldFullname, Highlighted: "<em>Nguyen</em> Van <em>An</em>"},
+		{Field: logic.AccountFieldEmail, Highlighted: "<em>an</em>.<em>nguyen</em>@gmail.com"},
+	}, result.Highlights)
+
+	output, err = accountLogic.SearchAccounts(ctx, logic.SearchAccountsParams{
+		Query:     "nguyen an",
+		PageSize:  1,
+		PageToken: output.NextPageToken,
+	})
+	require.NoError(t, err)
+	require.Empty(t, output.NextPageToken)
+	require.Len(t, output.Results, 1)
+	// The rest of a field is escaped
+	require.Equal(t, []logic.AccountFieldHighlight{
+		{Field: logic.AccountFieldUsername, Highlighted: "tr<em>anan</em>"},
+		{Field: logic.AccountFieldFullname, Highlighted: "&lt;b&gt;<em>An</em>&lt;/b&gt; Tran"},
+		{Field: logic.AccountFieldEmail, Highlighted: "tr<em>an</em>@gmail.com"},
+	}, output.Results[0].Highlights)
+
+	// A token only pages the search it came from
+	output, err = accountLogic.SearchAccounts(ctx, logic.SearchAccountsParams{
+		Query:    "an nguyen",
+		PageSize: 1,
+	})
+	require.NoError(t, err)
+	_, err = accountLogic.SearchAccounts(ctx, logic.SearchAccountsParams{
+		Query:     "an",
+		PageToken: output.NextPageToken,
+	})
+	require.ErrorIs(t, err, logic.ErrPageTokenInvalid)
+
+	_, err = accountLogic.SearchAccounts(ctx, logic.SearchAccountsParams{Query: " @.- "})
+	require.ErrorIs(t, err, logic.ErrSearchQueryInvalid)
+}
@@ -0,0 +1,184 @@
+package logic_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// Serves a single account whose status can change.
+type stubStatusAccountAccessor struct {
+	database.AccountAccessor
+	mu      sync.Mutex
+	account database.Account
+}
+
+func (s *stubStatusAccountAccessor) GetAccount(_ context.Context, id uint64) (database.Account, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id != s.account.Id {
+		return database.Account{}, errors.New("account not found")
+	}
+	return s.account, nil
+}
+
+func (s *stubStatusAccountAccessor) UpdateAccountStatus(_ context.Context, id uint64, fromStatusId uint8, toStatusId uint8) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id != s.account.Id || fromStatusId != s.account.StatusId {
+		return errors.New("failed to effect row")
+	}
+	s.account.StatusId = toStatusId
+	return nil
+}
+
+func (s *stubStatusAccountAccessor) WithExecutor(_ database.Executor) database.AccountAccessor {
+	return s
+}
+
+type stubAccountStatusChangeAccessor struct {
+	database.AccountStatusChangeAccessor
+	changes []database.AccountStatusChange
+}
+
+func (s *stubAccountStatusChangeAccessor) CreateAccountStatusChange(_ context.Context, asc database.AccountStatusChange) (uint64, error) {
+	s.changes = append(s.changes, asc)
+	return uint64(len(s.changes)), nil
+}
+
+func (s *stubAccountStatusChangeAccessor) DeleteAccountStatusChangeOfAccount(_ context.Context, _ uint64) error {
+	return nil
+}
+
+func (s *stubAccountStatusChangeAccessor) WithExecutor(_ database.Executor) database.AccountStatusChangeAccessor {
+	return s
+}
+
+// Counts the revocations of the refresh tokens of an account.
+type stubRefreshTokenAccessor struct {
+	database.RefreshTokenAccessor
+	revocations int
+}
+
+func (s *stubRefreshTokenAccessor) RevokeRefreshTokenOfAccount(_ context.Context, _ uint64, _ time.Time) error {
+	s.revocations++
+	return nil
+}
+
+func (s *stubRefreshTokenAccessor) DeleteRefreshTokenOfAccount(_ context.Context, _ uint64) error {
+	return nil
+}
+
+func (s *stubRefreshTokenAccessor) WithExecutor(_ database.Executor) database.RefreshTokenAccessor {
+	return s
+}
+
+func requireAccountStatusReason(t *testing.T, err error, reason string) {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	require.Equal(t, reason, info.GetReason())
+	require.Equal(t, logic.ErrorDomain, info.GetDomain())
+}
+
+func TestCheckAccountValidAccountStatus(t *testing.T) {
+	ctx := context.Background()
+	password := RandomString(20)
+	cases := map[logic.AccountStatus]string{
+		logic.AccountStatusPending:     logic.ReasonAccountPending,
+		logic.AccountStatusSuspended:   logic.ReasonAccountSuspended,
+		logic.AccountStatusLocked:      logic.ReasonAccountLocked,
+		logic.AccountStatusDeactivated: logic.ReasonAccountDeactivated,
+	}
+	for accountStatus, reason := range cases {
+		t.Run(accountStatus.String(), func(t *testing.T) {
+			acc := database.Account{
+				Id:       1,
+				Username: RandomString(20),
+				RoleId:   uint8(logic.Member),
+				StatusId: uint8(accountStatus),
+			}
+			accountLogic, _, _ := newStubAccountLogicOf(t, acc, password, &stubTOTP{}, &stubRecoveryCode{})
+
+			_, err := accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+				Username: acc.Username,
+				Password: password,
+			})
+			requireAccountStatusReason(t, err, reason)
+
+			// A wrong password says nothing about the status
+			_, err = accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+				Username: acc.Username,
+				Password: RandomString(20),
+			})
+			require.ErrorIs(t, err, logic.ErrInvalidCredentials)
+		})
+	}
+}
+
+func TestAccountStatusTransitions(t *testing.T) {
+	ctx := context.Background()
+	accountAccessor := &stubStatusAccountAccessor{account: database.Account{
+		Id:       1,
+		Username: RandomString(20),
+		RoleId:   uint8(logic.Member),
+		StatusId: uint8(logic.AccountStatusActive),
+	}}
+	statusChangeAccessor := &stubAccountStatusChangeAccessor{}
+	refreshTokenAccessor := &stubRefreshTokenAccessor{}
+	accountLogic := newTestAccountLogic(t, func(deps *logic.AccountDependencies) {
+		deps.AccountAccessor = accountAccessor
+		deps.RefreshTokenAccessor = refreshTokenAccessor
+		deps.AccountStatusChangeAccessor = statusChangeAccessor
+	})
+
+	params := logic.ChangeAccountStatusParams{
+		AccountId:      1,
+		Reason:         "spam",
+		ActorAccountId: 9,
+	}
+
+	_, err := accountLogic.SuspendAccount(ctx, logic.ChangeAccountStatusParams{AccountId: 1})
+	require.ErrorIs(t, err, logic.ErrAccountStatusReasonRequired)
+
+	output, err := accountLogic.SuspendAccount(ctx, params)
+	require.NoError(t, err)
+	require.Equal(t, logic.AccountStatusSuspended, output.Status)
+	require.Equal(t, 1, refreshTokenAccessor.revocations)
+
+	_, err = accountLogic.SuspendAccount(ctx, params)
+	require.ErrorIs(t, err, logic.ErrAccountStatusTransitionInvalid)
+
+	output, err = accountLogic.ReactivateAccount(ctx, params)
+	require.NoError(t, err)
+	require.Equal(t, logic.AccountStatusActive, output.Status)
+	require.Equal(t, 1, refreshTokenAccessor.revocations)
+
+	output, err = accountLogic.DeactivateAccount(ctx, params)
+	require.NoError(t, err)
+	require.Equal(t, logic.AccountStatusDeactivated, output.Status)
+	require.Equal(t, 2, refreshTokenAccessor.revocations)
+
+	_, err = accountLogic.SuspendAccount(ctx, params)
+	require.ErrorIs(t, err, logic.ErrAccountStatusTransitionInvalid)
+
+	require.Len(t, statusChangeAccessor.changes, 3)
+	require.Equal(t, database.AccountStatusChange{
+		OfAccountId:    1,
+		FromStatusId:   uint8(logic.AccountStatusActive),
+		ToStatusId:     uint8(logic.AccountStatusSuspended),
+		Reason:         "spam",
+		ActorAccountId: 9,
+	}, statusChangeAccessor.changes[0])
+}
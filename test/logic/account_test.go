@@ -0,0 +1,295 @@
+package logic_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// Serves a single account. Methods that are not overridden panic through the
+// nil embedded interface, which flags any unexpected call in a test.
+type stubAccountAccessor struct {
+	database.AccountAccessor
+	account database.Account
+}
+
+func (s stubAccountAccessor) GetAccountByUsername(_ context.Context, username string) (database.Account, error) {
+	if username != s.account.Username {
+		return database.Account{}, sql.ErrNoRows
+	}
+	return s.account, nil
+}
+
+func (s stubAccountAccessor) GetAccount(_ context.Context, id uint64) (database.Account, error) {
+	if id != s.account.Id {
+		return database.Account{}, sql.ErrNoRows
+	}
+	return s.account, nil
+}
+
+type stubAccountPasswordAccessor struct {
+	database.AccountPasswordAccessor
+	password database.AccountPassword
+}
+
+func (s stubAccountPasswordAccessor) GetAccountPassword(_ context.Context, id uint64) (database.AccountPassword, error) {
+	if id != s.password.OfAccountId {
+		return database.AccountPassword{}, sql.ErrNoRows
+	}
+	return s.password, nil
+}
+
+func (s stubAccountPasswordAccessor) DeleteAccountPassword(_ context.Context, _ uint64) error {
+	return nil
+}
+
+func (s stubAccountPasswordAccessor) WithExecutor(_ database.Executor) database.AccountPasswordAccessor {
+	return s
+}
+
+// Keeps the password of one account, running changeAfterRead once after the
+// first read as if the password were changed by another request meanwhile.
+type racingAccountPasswordAccessor struct {
+	database.AccountPasswordAccessor
+	mu              sync.Mutex
+	password        database.AccountPassword
+	changeAfterRead func(*database.AccountPassword)
+}
+
+func (s *racingAccountPasswordAccessor) GetAccountPassword(_ context.Context, id uint64) (database.AccountPassword, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id != s.password.OfAccountId {
+		return database.AccountPassword{}, sql.ErrNoRows
+	}
+	read := s.password
+	if s.changeAfterRead != nil {
+		s.changeAfterRead(&s.password)
+		s.changeAfterRead = nil
+	}
+	return read, nil
+}
+
+func (s *racingAccountPasswordAccessor) UpdateAccountPassword(_ context.Context, ap database.AccountPassword) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.password.HashedString = ap.HashedString
+	return nil
+}
+
+func (s *racingAccountPasswordAccessor) UpdateAccountPasswordIfUnchanged(
+	_ context.Context,
+	ap database.AccountPassword,
+	previousHashedString string,
+) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.password.HashedString != previousHashedString {
+		return false, nil
+	}
+	s.password.HashedString = ap.HashedString
+	return true, nil
+}
+
+// Builds an account logic serving one account with the given password.
+func newStubAccountLogic(t testing.TB, password string) (logic.Account, database.Account) {
+	accountLogic, _, acc := newStubAccountLogicWithTOTP(t, password, &stubTOTP{})
+	return accountLogic, acc
+}
+
+// Like newStubAccountLogic, with the second factor of the account backed by
+// the given TOTP.
+func newStubAccountLogicWithTOTP(
+	t testing.TB,
+	password string,
+	totpLogic logic.TOTP,
+) (logic.Account, logic.SecondFactor, database.Account) {
+	return newStubAccountLogicWithSecondFactor(t, password, totpLogic, &stubRecoveryCode{})
+}
+
+// Like newStubAccountLogicWithTOTP, with recovery codes served by the given
+// logic.
+func newStubAccountLogicWithSecondFactor(
+	t testing.TB,
+	password string,
+	totpLogic logic.TOTP,
+	recoveryCodeLogic logic.RecoveryCode,
+) (logic.Account, logic.SecondFactor, database.Account) {
+	acc := database.Account{
+		Id:       1,
+		Username: RandomString(20),
+		RoleId:   uint8(logic.Member),
+		StatusId: uint8(logic.AccountStatusActive),
+	}
+	return newStubAccountLogicOf(t, acc, password, totpLogic, recoveryCodeLogic)
+}
+
+// Builds an account logic serving the given account.
+func newStubAccountLogicOf(
+	t testing.TB,
+	acc database.Account,
+	password string,
+	totpLogic logic.TOTP,
+	recoveryCodeLogic logic.RecoveryCode,
+) (logic.Account, logic.SecondFactor, database.Account) {
+	hashLogic := newFastHashLogic(t)
+	hashed, err := hashLogic.Hash(context.Background(), password)
+	require.NoError(t, err)
+
+	accountAccessor := stubAccountAccessor{account: acc}
+	loginThrottleLogic := logic.NewLoginThrottle(newStubLoginFailureAccessor(), configs.Lockout{}, zap.NewNop())
+	secondFactorLogic := logic.NewSecondFactor(accountAccessor, newStubLoginChallengeAccessor(),
+		totpLogic, recoveryCodeLogic, loginThrottleLogic,
+		configs.SecondFactor{ChallengeTTL: time.Minute, MaxAttempts: 3}, zap.NewNop())
+	accountLogic := newTestAccountLogic(t, func(deps *logic.AccountDependencies) {
+		deps.AccountAccessor = accountAccessor
+		deps.AccountPasswordAccessor = stubAccountPasswordAccessor{password: database.AccountPassword{
+			OfAccountId:  acc.Id,
+			HashedString: hashed,
+		}}
+		deps.HashLogic = hashLogic
+		deps.LoginThrottleLogic = loginThrottleLogic
+		deps.SecondFactorLogic = secondFactorLogic
+	})
+	return accountLogic, secondFactorLogic, acc
+}
+
+func TestCheckAccountValid(t *testing.T) {
+	ctx := context.Background()
+	password := RandomString(20)
+	accountLogic, acc := newStubAccountLogic(t, password)
+
+	output, err := accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+		Username: acc.Username,
+		Password: password,
+	})
+	require.NoError(t, err)
+	require.Equal(t, acc.Id, output.AccountId)
+}
+
+func TestCheckAccountValidRehash(t *testing.T) {
+	ctx := context.Background()
+	password := RandomString(20)
+	bcryptHashed, err := newHashLogic(t, bcryptHashConfig()).Hash(ctx, password)
+	require.NoError(t, err)
+
+	acc := database.Account{
+		Id:       1,
+		Username: RandomString(20),
+		RoleId:   uint8(logic.Member),
+		StatusId: uint8(logic.AccountStatusActive),
+	}
+	newAccountLogic := func(passwordAccessor database.AccountPasswordAccessor) logic.Account {
+		accountAccessor := stubAccountAccessor{account: acc}
+		loginThrottleLogic := logic.NewLoginThrottle(newStubLoginFailureAccessor(), configs.Lockout{}, zap.NewNop())
+		return newTestAccountLogic(t, func(deps *logic.AccountDependencies) {
+			deps.AccountAccessor = accountAccessor
+			deps.AccountPasswordAccessor = passwordAccessor
+			deps.LoginThrottleLogic = loginThrottleLogic
+			deps.SecondFactorLogic = logic.NewSecondFactor(accountAccessor, newStubLoginChallengeAccessor(),
+				&stubTOTP{}, &stubRecoveryCode{}, loginThrottleLogic,
+				configs.SecondFactor{ChallengeTTL: time.Minute, MaxAttempts: 3}, zap.NewNop())
+		})
+	}
+	hashLogic := newFastHashLogic(t)
+
+	// A bcrypt hash is upgraded to the configured argon2id on login
+	passwordAccessor := &racingAccountPasswordAccessor{password: database.AccountPassword{
+		OfAccountId:  acc.Id,
+		HashedString: bcryptHashed,
+	}}
+	_, err = newAccountLogic(passwordAccessor).CheckAccountValid(ctx, logic.CheckAccountValidParams{
+		Username: acc.Username,
+		Password: password,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, bcryptHashed, passwordAccessor.password.HashedString)
+	require.False(t, hashLogic.NeedsRehash(ctx, passwordAccessor.password.HashedString))
+
+	// A password changed between the check and the rehash is kept
+	changedHashed, err := hashLogic.Hash(ctx, RandomString(20))
+	require.NoError(t, err)
+	passwordAccessor = &racingAccountPasswordAccessor{
+		password: database.AccountPassword{
+			OfAccountId:  acc.Id,
+			HashedString: bcryptHashed,
+		},
+		changeAfterRead: func(ap *database.AccountPassword) {
+			ap.HashedString = changedHashed
+		},
+	}
+	_, err = newAccountLogic(passwordAccessor).CheckAccountValid(ctx, logic.CheckAccountValidParams{
+		Username: acc.Username,
+		Password: password,
+	})
+	require.NoError(t, err)
+	require.Equal(t, changedHashed, passwordAccessor.password.HashedString)
+}
+
+func TestCheckAccountValidIndistinguishableFailures(t *testing.T) {
+	ctx := context.Background()
+	accountLogic, acc := newStubAccountLogic(t, RandomString(20))
+
+	_, errWrongPassword := accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+		Username: acc.Username,
+		Password: RandomString(20),
+	})
+	_, errUnknownUsername := accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+		Username: RandomString(21),
+		Password: RandomString(20),
+	})
+	require.ErrorIs(t, errWrongPassword, logic.ErrInvalidCredentials)
+	require.ErrorIs(t, errUnknownUsername, logic.ErrInvalidCredentials)
+	require.Equal(t, errWrongPassword.Error(), errUnknownUsername.Error())
+
+	// Both paths pay for one hash verification, so neither is a shortcut
+	measure := func(username string) time.Duration {
+		fastest := time.Duration(0)
+		for range 5 {
+			start := time.Now()
+			_, _ = accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+				Username: username,
+				Password: RandomString(20),
+			})
+			if elapsed := time.Since(start); fastest == 0 || elapsed < fastest {
+				fastest = elapsed
+			}
+		}
+		return fastest
+	}
+	wrongPassword := measure(acc.Username)
+	unknownUsername := measure(RandomString(21))
+	require.Less(t, unknownUsername, 2*wrongPassword)
+	require.Less(t, wrongPassword, 2*unknownUsername)
+}
+
+func BenchmarkCheckAccountValid(b *testing.B) {
+	ctx := context.Background()
+	accountLogic, acc := newStubAccountLogic(b, RandomString(20))
+
+	b.Run("WrongPassword", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+				Username: acc.Username,
+				Password: "wrong password",
+			})
+		}
+	})
+
+	b.Run("UnknownUsername", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+				Username: "unknown username",
+				Password: "wrong password",
+			})
+		}
+	})
+}
@@ -2,15 +2,29 @@ package logic_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/Fiagram/account_service/internal/configs"
 	"github.com/Fiagram/account_service/internal/logic"
 	"github.com/stretchr/testify/require"
 )
 
+func bcryptHashConfig() configs.Hash {
+	hashConfig := config.Auth.Hash
+	hashConfig.Algorithm = configs.HashAlgorithmBcrypt
+	return hashConfig
+}
+
+func argon2idHashConfig() configs.Hash {
+	hashConfig := config.Auth.Hash
+	hashConfig.Algorithm = configs.HashAlgorithmArgon2id
+	return hashConfig
+}
+
 func TestHash(t *testing.T) {
 	ctx := context.Background()
-	hashLogic := logic.NewHash(config.Auth.Hash)
+	hashLogic := newHashLogic(t, bcryptHashConfig())
 
 	hs1, err1 := hashLogic.Hash(ctx, RandomString(72))
 	require.NoError(t, err1)
@@ -21,9 +35,17 @@ func TestHash(t *testing.T) {
 	require.Equal(t, "", hs2)
 }
 
+func TestNewHashUnknownAlgorithm(t *testing.T) {
+	hashConfig := config.Auth.Hash
+	hashConfig.Algorithm = "argon2"
+	hashLogic, err := logic.NewHash(hashConfig)
+	require.Error(t, err)
+	require.Nil(t, hashLogic)
+}
+
 func TestIsHashEqual(t *testing.T) {
 	ctx := context.Background()
-	hashLogic := logic.NewHash(config.Auth.Hash)
+	hashLogic := newHashLogic(t, bcryptHashConfig())
 
 	input := RandomString(72)
 	hs1, err1 := hashLogic.Hash(ctx, input)
@@ -38,3 +60,124 @@ func TestIsHashEqual(t *testing.T) {
 	require.NoError(t, errF)
 	require.Equal(t, false, isEqualFalse)
 }
+
+func TestHashArgon2id(t *testing.T) {
+	ctx := context.Background()
+	hashLogic := newHashLogic(t, argon2idHashConfig())
+
+	input := RandomString(200)
+	hs1, err1 := hashLogic.Hash(ctx, input)
+	require.NoError(t, err1)
+	require.True(t, strings.HasPrefix(hs1, "$argon2id$v=19$"))
+	require.LessOrEqual(t, len(hs1), 255)
+
+	isEqualTrue, errT := hashLogic.IsHashEqual(ctx, input, hs1)
+	require.NoError(t, errT)
+	require.Equal(t, true, isEqualTrue)
+
+	isEqualFalse, errF := hashLogic.IsHashEqual(ctx, input[:199], hs1)
+	require.NoError(t, errF)
+	require.Equal(t, false, isEqualFalse)
+
+	// The same input hashes differently because of the random salt
+	hs2, err2 := hashLogic.Hash(ctx, input)
+	require.NoError(t, err2)
+	require.NotEqual(t, hs1, hs2)
+}
+
+func TestIsHashEqualAcrossAlgorithms(t *testing.T) {
+	ctx := context.Background()
+	bcryptLogic := newHashLogic(t, bcryptHashConfig())
+	argon2idLogic := newHashLogic(t, argon2idHashConfig())
+
+	input := RandomString(32)
+	bcryptHashed, err := bcryptLogic.Hash(ctx, input)
+	require.NoError(t, err)
+	argon2idHashed, err := argon2idLogic.Hash(ctx, input)
+	require.NoError(t, err)
+
+	isEqual, err := argon2idLogic.IsHashEqual(ctx, input, bcryptHashed)
+	require.NoError(t, err)
+	require.True(t, isEqual)
+
+	isEqual, err = bcryptLogic.IsHashEqual(ctx, input, argon2idHashed)
+	require.NoError(t, err)
+	require.True(t, isEqual)
+
+	_, err = bcryptLogic.IsHashEqual(ctx, input, "not-a-known-hash")
+	require.Error(t, err)
+}
+
+func TestIsHashEqualRefusesArgon2idParams(t *testing.T) {
+	ctx := context.Background()
+	hashLogic := newHashLogic(t, argon2idHashConfig())
+
+	const saltAndKey = "$c2FsdHNhbHRzYWx0c2FsdA$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5"
+	for _, params := range []string{
+		"m=65536,t=0,p=1",
+		"m=65536,t=1,p=0",
+		"m=4194304,t=1,p=1",
+		"m=65536,t=1000,p=1",
+		"m=65536,t=1,p=255",
+	} {
+		_, err := hashLogic.IsHashEqual(ctx, "input", "$argon2id$v=19$"+params+saltAndKey)
+		require.Error(t, err, params)
+	}
+
+	_, err := hashLogic.IsHashEqual(ctx, "input", "$argon2id$v=19$m=65536,t=1,p=1$c2FsdA$a2V5")
+	require.Error(t, err)
+}
+
+func TestNeedsRehash(t *testing.T) {
+	ctx := context.Background()
+	bcryptLogic := newHashLogic(t, bcryptHashConfig())
+	argon2idLogic := newHashLogic(t, argon2idHashConfig())
+
+	input := RandomString(32)
+	bcryptHashed, err := bcryptLogic.Hash(ctx, input)
+	require.NoError(t, err)
+	argon2idHashed, err := argon2idLogic.Hash(ctx, input)
+	require.NoError(t, err)
+
+	require.False(t, bcryptLogic.NeedsRehash(ctx, bcryptHashed))
+	require.True(t, bcryptLogic.NeedsRehash(ctx, argon2idHashed))
+	require.False(t, argon2idLogic.NeedsRehash(ctx, argon2idHashed))
+	require.True(t, argon2idLogic.NeedsRehash(ctx, bcryptHashed))
+
+	higherCostConfig := bcryptHashConfig()
+	higherCostConfig.Cost++
+	require.True(t, newHashLogic(t, higherCostConfig).NeedsRehash(ctx, bcryptHashed))
+
+	strongerConfig := argon2idHashConfig()
+	strongerConfig.Argon2id.Iterations++
+	require.True(t, newHashLogic(t, strongerConfig).NeedsRehash(ctx, argon2idHashed))
+}
+
+func TestIsHashSupported(t *testing.T) {
+	ctx := context.Background()
+	bcryptLogic := newHashLogic(t, bcryptHashConfig())
+	argon2idLogic := newHashLogic(t, argon2idHashConfig())
+
+	input := RandomString(32)
+	bcryptHashed, err := bcryptLogic.Hash(ctx, input)
+	require.NoError(t, err)
+	argon2idHashed, err := argon2idLogic.Hash(ctx, input)
+	require.NoError(t, err)
+
+	// Either algorithm is supported whichever one is configured
+	require.True(t, bcryptLogic.IsHashSupported(ctx, bcryptHashed))
+	require.True(t, bcryptLogic.IsHashSupported(ctx, argon2idHashed))
+	require.True(t, argon2idLogic.IsHashSupported(ctx, bcryptHashed))
+
+	require.False(t, bcryptLogic.IsHashSupported(ctx, input))
+	require.False(t, bcryptLogic.IsHashSupported(ctx, bcryptHashed[:59]))
+	require.False(t, bcryptLogic.IsHashSupported(ctx, "$argon2id$v=19$m=1,t=1,p=1$$"))
+
+	// Out of range parameters would fail or tie up the server on login
+	const saltAndKey = "$c2FsdHNhbHRzYWx0c2FsdA$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5"
+	require.True(t, argon2idLogic.IsHashSupported(ctx, "$argon2id$v=19$m=65536,t=1,p=1"+saltAndKey))
+	require.False(t, argon2idLogic.IsHashSupported(ctx, "$argon2id$v=19$m=65536,t=0,p=1"+saltAndKey))
+	require.False(t, argon2idLogic.IsHashSupported(ctx, "$argon2id$v=19$m=65536,t=1,p=0"+saltAndKey))
+	require.False(t, argon2idLogic.IsHashSupported(ctx, "$argon2id$v=19$m=4194304,t=1,p=1"+saltAndKey))
+	require.False(t, argon2idLogic.IsHashSupported(ctx, "$argon2id$v=19$m=8,t=0,p=0$c2FsdHNhbHQ$a2V5a2V5a2V5"))
+}
@@ -0,0 +1,219 @@
+package logic_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type loginFailureKey struct {
+	scope      database.LoginFailureScope
+	identifier string
+}
+
+// Keeps login failures in a map so the throttle can be tested without a database.
+type stubLoginFailureAccessor struct {
+	mu   sync.Mutex
+	rows map[loginFailureKey]database.LoginFailure
+}
+
+func newStubLoginFailureAccessor() *stubLoginFailureAccessor {
+	return &stubLoginFailureAccessor{rows: map[loginFailureKey]database.LoginFailure{}}
+}
+
+func (s *stubLoginFailureAccessor) CreateLoginFailure(_ context.Context, lf database.LoginFailure) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rows[loginFailureKey{lf.Scope, lf.Identifier}] = lf
+	return nil
+}
+
+func (s *stubLoginFailureAccessor) GetLoginFailure(_ context.Context, scope database.LoginFailureScope, identifier string) (database.LoginFailure, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lf, ok := s.rows[loginFailureKey{scope, identifier}]
+	if !ok {
+		return database.LoginFailure{}, sql.ErrNoRows
+	}
+	return lf, nil
+}
+
+func (s *stubLoginFailureAccessor) UpdateLoginFailure(ctx context.Context, lf database.LoginFailure) error {
+	return s.CreateLoginFailure(ctx, lf)
+}
+
+func (s *stubLoginFailureAccessor) IncrementLoginFailure(
+	_ context.Context,
+	scope database.LoginFailureScope,
+	identifier string,
+	failedAt time.Time,
+	resetBefore *time.Time,
+) (database.LoginFailure, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := loginFailureKey{scope, identifier}
+	lf, ok := s.rows[key]
+	if !ok {
+		lf = database.LoginFailure{Scope: scope, Identifier: identifier}
+	}
+	isUnlocked := lf.LockedUntil == nil || !lf.LockedUntil.After(failedAt)
+	if ok && resetBefore != nil && isUnlocked && lf.LastFailedAt.Before(*resetBefore) {
+		lf.FailureCount = 0
+		lf.LockedUntil = nil
+	}
+	lf.FailureCount++
+	lf.LastFailedAt = failedAt
+	s.rows[key] = lf
+	return lf, nil
+}
+
+func (s *stubLoginFailureAccessor) LockLoginFailure(
+	_ context.Context,
+	scope database.LoginFailureScope,
+	identifier string,
+	lockedUntil time.Time,
+	now time.Time,
+) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := loginFailureKey{scope, identifier}
+	lf, ok := s.rows[key]
+	if !ok || (lf.LockedUntil != nil && lf.LockedUntil.After(now)) {
+		return false, nil
+	}
+	lf.LockedUntil = &lockedUntil
+	lf.FailureCount = 0
+	s.rows[key] = lf
+	return true, nil
+}
+
+func (s *stubLoginFailureAccessor) DeleteLoginFailure(_ context.Context, scope database.LoginFailureScope, identifier string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.rows, loginFailureKey{scope, identifier})
+	return nil
+}
+
+func (s *stubLoginFailureAccessor) WithExecutor(_ database.Executor) database.LoginFailureAccessor {
+	return s
+}
+
+func requireLoginThrottled(t *testing.T, err error) {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	_, ok = st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+}
+
+func TestLoginThrottleLockout(t *testing.T) {
+	ctx := context.Background()
+	throttle := logic.NewLoginThrottle(newStubLoginFailureAccessor(),
+		configs.Lockout{
+			Account: configs.LockoutPolicy{
+				LockAfter:    3,
+				LockDuration: time.Hour,
+			},
+		},
+		zap.NewNop())
+
+	attempt := logic.LoginAttemptParams{Username: RandomString(20), Address: "10.0.0.1"}
+	for range 2 {
+		require.NoError(t, throttle.CheckLoginAllowed(ctx, attempt))
+		require.NoError(t, throttle.RecordLoginFailure(ctx, attempt))
+	}
+	require.NoError(t, throttle.CheckLoginAllowed(ctx, attempt))
+	require.NoError(t, throttle.RecordLoginFailure(ctx, attempt))
+	requireLoginThrottled(t, throttle.CheckLoginAllowed(ctx, attempt))
+
+	// The lock belongs to the username, not to the address
+	other := logic.LoginAttemptParams{Username: RandomString(20), Address: attempt.Address}
+	require.NoError(t, throttle.CheckLoginAllowed(ctx, other))
+
+	require.NoError(t, throttle.UnlockAccount(ctx, logic.UnlockAccountParams{Username: attempt.Username}))
+	require.NoError(t, throttle.CheckLoginAllowed(ctx, attempt))
+}
+
+func TestLoginThrottleLockoutExpired(t *testing.T) {
+	ctx := context.Background()
+	throttle := logic.NewLoginThrottle(newStubLoginFailureAccessor(),
+		configs.Lockout{
+			Account: configs.LockoutPolicy{
+				LockAfter:    2,
+				LockDuration: 100 * time.Millisecond,
+			},
+		},
+		zap.NewNop())
+
+	attempt := logic.LoginAttemptParams{Username: RandomString(20)}
+	require.NoError(t, throttle.RecordLoginFailure(ctx, attempt))
+	require.NoError(t, throttle.RecordLoginFailure(ctx, attempt))
+	requireLoginThrottled(t, throttle.CheckLoginAllowed(ctx, attempt))
+
+	// Once the lock is over, it takes as many failures again to lock
+	time.Sleep(150 * time.Millisecond)
+	require.NoError(t, throttle.CheckLoginAllowed(ctx, attempt))
+	require.NoError(t, throttle.RecordLoginFailure(ctx, attempt))
+	require.NoError(t, throttle.CheckLoginAllowed(ctx, attempt))
+	require.NoError(t, throttle.RecordLoginFailure(ctx, attempt))
+	requireLoginThrottled(t, throttle.CheckLoginAllowed(ctx, attempt))
+}
+
+func TestLoginThrottleBackoff(t *testing.T) {
+	ctx := context.Background()
+	throttle := logic.NewLoginThrottle(newStubLoginFailureAccessor(),
+		configs.Lockout{
+			Address: configs.LockoutPolicy{
+				BackoffAfter: 2,
+				BackoffBase:  100 * time.Millisecond,
+				BackoffMax:   time.Second,
+			},
+		},
+		zap.NewNop())
+
+	attempt := logic.LoginAttemptParams{Username: RandomString(20), Address: "10.0.0.2"}
+	require.NoError(t, throttle.RecordLoginFailure(ctx, attempt))
+	require.NoError(t, throttle.CheckLoginAllowed(ctx, attempt))
+	require.NoError(t, throttle.RecordLoginFailure(ctx, attempt))
+	requireLoginThrottled(t, throttle.CheckLoginAllowed(ctx, attempt))
+
+	time.Sleep(150 * time.Millisecond)
+	require.NoError(t, throttle.CheckLoginAllowed(ctx, attempt))
+
+	// A success clears the username but keeps the address backing off
+	require.NoError(t, throttle.RecordLoginFailure(ctx, attempt))
+	require.NoError(t, throttle.RecordLoginSuccess(ctx, attempt))
+	requireLoginThrottled(t, throttle.CheckLoginAllowed(ctx, attempt))
+}
+
+func TestLoginThrottleResetAfter(t *testing.T) {
+	ctx := context.Background()
+	throttle := logic.NewLoginThrottle(newStubLoginFailureAccessor(),
+		configs.Lockout{
+			Account: configs.LockoutPolicy{
+				LockAfter:    2,
+				LockDuration: time.Hour,
+				ResetAfter:   100 * time.Millisecond,
+			},
+		},
+		zap.NewNop())
+
+	attempt := logic.LoginAttemptParams{Username: RandomString(20)}
+	require.NoError(t, throttle.RecordLoginFailure(ctx, attempt))
+	time.Sleep(150 * time.Millisecond)
+	require.NoError(t, throttle.RecordLoginFailure(ctx, attempt))
+	require.NoError(t, throttle.CheckLoginAllowed(ctx, attempt))
+}
@@ -0,0 +1,136 @@
+package logic_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Returns the reasons of the field violations carried by a policy error.
+func violationReasonsOf(t *testing.T, err error) []string {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	var reasons []string
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		require.True(t, ok)
+		for _, violation := range badRequest.GetFieldViolations() {
+			require.Equal(t, "password", violation.GetField())
+			require.NotEmpty(t, violation.GetDescription())
+			reasons = append(reasons, violation.GetReason())
+		}
+	}
+	return reasons
+}
+
+func TestValidatePasswordLengthAndClasses(t *testing.T) {
+	ctx := context.Background()
+	policy, err := logic.NewPasswordPolicy(configs.PasswordPolicy{
+		MinLength:        10,
+		MaxBytes:         64,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+	}, newHashLogic(t, argon2idHashConfig()), zap.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, policy.ValidatePassword(ctx,
+		logic.ValidatePasswordParams{Password: "Correct-Horse-42"}))
+
+	require.ElementsMatch(t, []string{
+		"PASSWORD_TOO_SHORT",
+		"PASSWORD_MISSING_UPPERCASE",
+		"PASSWORD_MISSING_DIGIT",
+		"PASSWORD_MISSING_SYMBOL",
+	}, violationReasonsOf(t, policy.ValidatePassword(ctx,
+		logic.ValidatePasswordParams{Password: "short"})))
+
+	require.Contains(t, violationReasonsOf(t, policy.ValidatePassword(ctx,
+		logic.ValidatePasswordParams{Password: ""})),
+		"PASSWORD_TOO_SHORT")
+
+	require.Contains(t, violationReasonsOf(t, policy.ValidatePassword(ctx,
+		logic.ValidatePasswordParams{Password: "Aa1-" + strings.Repeat("x", 61)})),
+		"PASSWORD_TOO_LONG")
+
+	// Length is counted in characters, not bytes
+	require.NoError(t, policy.ValidatePassword(ctx,
+		logic.ValidatePasswordParams{Password: "Mật-khẩu-9"}))
+}
+
+func TestValidatePasswordMaxBytesFollowsHasher(t *testing.T) {
+	ctx := context.Background()
+	policy, err := logic.NewPasswordPolicy(configs.PasswordPolicy{
+		MaxBytes: 1024,
+	}, newHashLogic(t, bcryptHashConfig()), zap.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, policy.ValidatePassword(ctx,
+		logic.ValidatePasswordParams{Password: RandomString(72)}))
+	require.Equal(t, []string{"PASSWORD_TOO_LONG"}, violationReasonsOf(t,
+		policy.ValidatePassword(ctx, logic.ValidatePasswordParams{Password: RandomString(73)})))
+}
+
+func TestValidatePasswordDisallowAccountInfo(t *testing.T) {
+	ctx := context.Background()
+	policy, err := logic.NewPasswordPolicy(configs.PasswordPolicy{
+		DisallowAccountInfo: true,
+	}, newHashLogic(t, argon2idHashConfig()), zap.NewNop())
+	require.NoError(t, err)
+
+	params := logic.ValidatePasswordParams{
+		Username: "johnny",
+		Fullname: "John Doe",
+		Email:    "jd.mail@example.com",
+	}
+	for _, password := range []string{"xxJohnny123", "johndoe!!", "my-JD.MAIL-pw"} {
+		params.Password = password
+		require.Equal(t, []string{"PASSWORD_CONTAINS_ACCOUNT_INFO"},
+			violationReasonsOf(t, policy.ValidatePassword(ctx, params)), password)
+	}
+
+	params.Password = "unrelated passphrase"
+	require.NoError(t, policy.ValidatePassword(ctx, params))
+}
+
+func TestValidatePasswordBreachedList(t *testing.T) {
+	ctx := context.Background()
+	filePath := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte(strings.Join([]string{
+		"# plaintext and SHA-1 entries can be mixed",
+		"password123",
+		// SHA-1 of "letmein", in the Have I Been Pwned format
+		"b7a875fc1ea228b9061041b7cec4bd3c52ab3ce3:12345",
+	}, "\n")), 0o600))
+
+	policy, err := logic.NewPasswordPolicy(configs.PasswordPolicy{
+		BreachedPasswordFile: filePath,
+	}, newHashLogic(t, argon2idHashConfig()), zap.NewNop())
+	require.NoError(t, err)
+
+	for _, password := range []string{"password123", "letmein"} {
+		require.Equal(t, []string{"PASSWORD_BREACHED"}, violationReasonsOf(t,
+			policy.ValidatePassword(ctx, logic.ValidatePasswordParams{Password: password})))
+	}
+	require.NoError(t, policy.ValidatePassword(ctx,
+		logic.ValidatePasswordParams{Password: "password1234"}))
+
+	_, err = logic.NewPasswordPolicy(configs.PasswordPolicy{
+		BreachedPasswordFile: filePath + ".missing",
+	}, newHashLogic(t, argon2idHashConfig()), zap.NewNop())
+	require.Error(t, err)
+}
@@ -0,0 +1,239 @@
+package logic_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Accepts a single fixed code when enabled.
+type stubTOTP struct {
+	logic.TOTP
+	isEnabled bool
+	code      string
+}
+
+func (s *stubTOTP) IsTOTPEnabled(_ context.Context, _ uint64) (bool, error) {
+	return s.isEnabled, nil
+}
+
+func (s *stubTOTP) VerifyTOTPCode(_ context.Context, _ uint64, code string) (bool, error) {
+	return s.isEnabled && code == s.code, nil
+}
+
+// Holds plain codes, each usable once.
+type stubRecoveryCode struct {
+	logic.RecoveryCode
+	mu    sync.Mutex
+	codes []string
+}
+
+func (s *stubRecoveryCode) UseRecoveryCode(_ context.Context, params logic.UseRecoveryCodeParams) (logic.UseRecoveryCodeOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, code := range s.codes {
+		if code == params.Code {
+			s.codes = append(s.codes[:i], s.codes[i+1:]...)
+			return logic.UseRecoveryCodeOutput{IsUsed: true, RemainingCount: len(s.codes)}, nil
+		}
+	}
+	return logic.UseRecoveryCodeOutput{}, nil
+}
+
+// Keeps login challenges in a map so the second factor can be tested without
+// a database.
+type stubLoginChallengeAccessor struct {
+	mu     sync.Mutex
+	nextId uint64
+	rows   map[uint64]database.LoginChallenge
+}
+
+func newStubLoginChallengeAccessor() *stubLoginChallengeAccessor {
+	return &stubLoginChallengeAccessor{rows: map[uint64]database.LoginChallenge{}}
+}
+
+func (s *stubLoginChallengeAccessor) CreateLoginChallenge(_ context.Context, lc database.LoginChallenge) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextId++
+	lc.Id = s.nextId
+	s.rows[lc.Id] = lc
+	return lc.Id, nil
+}
+
+func (s *stubLoginChallengeAccessor) GetLoginChallengeByHashedChallenge(_ context.Context, hashedChallenge string) (database.LoginChallenge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, lc := range s.rows {
+		if lc.HashedChallenge == hashedChallenge {
+			return lc, nil
+		}
+	}
+	return database.LoginChallenge{}, sql.ErrNoRows
+}
+
+func (s *stubLoginChallengeAccessor) IncreaseLoginChallengeFailedAttempts(_ context.Context, id uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lc := s.rows[id]
+	lc.FailedAttempts++
+	s.rows[id] = lc
+	return nil
+}
+
+func (s *stubLoginChallengeAccessor) UseLoginChallenge(_ context.Context, id uint64, usedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lc := s.rows[id]
+	if lc.UsedAt != nil {
+		return sql.ErrNoRows
+	}
+	lc.UsedAt = &usedAt
+	s.rows[id] = lc
+	return nil
+}
+
+func (s *stubLoginChallengeAccessor) DeleteLoginChallengeOfAccount(_ context.Context, ofAccountId uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, lc := range s.rows {
+		if lc.OfAccountId == ofAccountId {
+			delete(s.rows, id)
+		}
+	}
+	return nil
+}
+
+func (s *stubLoginChallengeAccessor) WithExecutor(_ database.Executor) database.LoginChallengeAccessor {
+	return s
+}
+
+// Returns the challenge id carried by a SECOND_FACTOR_REQUIRED error.
+func requireSecondFactorRequired(t *testing.T, err error) string {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.Unauthenticated, st.Code())
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	require.Equal(t, logic.ReasonSecondFactorRequired, info.GetReason())
+	require.Equal(t, logic.ErrorDomain, info.GetDomain())
+	require.NotEmpty(t, info.GetMetadata()["challenge_id"])
+	return info.GetMetadata()["challenge_id"]
+}
+
+func TestCheckAccountValidSecondFactorRequired(t *testing.T) {
+	ctx := context.Background()
+	password := RandomString(20)
+	totpLogic := &stubTOTP{isEnabled: true, code: "123456"}
+	accountLogic, secondFactorLogic, acc := newStubAccountLogicWithTOTP(t, password, totpLogic)
+
+	_, err := accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+		Username: acc.Username,
+		Password: password,
+	})
+	challengeId := requireSecondFactorRequired(t, err)
+
+	output, err := secondFactorLogic.VerifySecondFactor(ctx, logic.VerifySecondFactorParams{
+		ChallengeId: challengeId,
+		Code:        totpLogic.code,
+	})
+	require.NoError(t, err)
+	require.Equal(t, acc.Id, output.AccountId)
+
+	// A challenge finishes one login only
+	_, err = secondFactorLogic.VerifySecondFactor(ctx, logic.VerifySecondFactorParams{
+		ChallengeId: challengeId,
+		Code:        totpLogic.code,
+	})
+	require.ErrorIs(t, err, logic.ErrLoginChallengeInvalid)
+}
+
+func TestVerifySecondFactorAttemptsExceeded(t *testing.T) {
+	ctx := context.Background()
+	password := RandomString(20)
+	totpLogic := &stubTOTP{isEnabled: true, code: "123456"}
+	accountLogic, secondFactorLogic, acc := newStubAccountLogicWithTOTP(t, password, totpLogic)
+
+	_, err := accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+		Username: acc.Username,
+		Password: password,
+	})
+	challengeId := requireSecondFactorRequired(t, err)
+
+	for range 3 {
+		_, err = secondFactorLogic.VerifySecondFactor(ctx, logic.VerifySecondFactorParams{
+			ChallengeId: challengeId,
+			Code:        "000000",
+		})
+		require.ErrorIs(t, err, logic.ErrSecondFactorInvalid)
+	}
+
+	_, err = secondFactorLogic.VerifySecondFactor(ctx, logic.VerifySecondFactorParams{
+		ChallengeId: challengeId,
+		Code:        totpLogic.code,
+	})
+	require.ErrorIs(t, err, logic.ErrLoginChallengeAttemptsExceeded)
+}
+
+func TestVerifySecondFactorUnknownChallenge(t *testing.T) {
+	ctx := context.Background()
+	_, secondFactorLogic, _ := newStubAccountLogicWithTOTP(t, RandomString(20), &stubTOTP{})
+
+	_, err := secondFactorLogic.VerifySecondFactor(ctx, logic.VerifySecondFactorParams{
+		ChallengeId: RandomString(32),
+		Code:        "123456",
+	})
+	require.ErrorIs(t, err, logic.ErrLoginChallengeInvalid)
+}
+
+func TestVerifySecondFactorRecoveryCode(t *testing.T) {
+	ctx := context.Background()
+	password := RandomString(20)
+	recoveryCodeLogic := &stubRecoveryCode{codes: []string{"abcde-fghjk", "mnpqr-stuvw"}}
+	accountLogic, secondFactorLogic, acc := newStubAccountLogicWithSecondFactor(t, password,
+		&stubTOTP{isEnabled: true, code: "123456"}, recoveryCodeLogic)
+
+	login := func() string {
+		_, err := accountLogic.CheckAccountValid(ctx, logic.CheckAccountValidParams{
+			Username: acc.Username,
+			Password: password,
+		})
+		return requireSecondFactorRequired(t, err)
+	}
+
+	challengeId := login()
+	output, err := secondFactorLogic.VerifySecondFactor(ctx, logic.VerifySecondFactorParams{
+		ChallengeId: challengeId,
+		Code:        "abcde-fghjk",
+	})
+	require.NoError(t, err)
+	require.Equal(t, acc.Id, output.AccountId)
+	require.True(t, output.IsRecoveryCodeUsed)
+	require.Equal(t, 1, output.RemainingRecoveryCodes)
+
+	// A used recovery code is consumed
+	challengeId = login()
+	_, err = secondFactorLogic.VerifySecondFactor(ctx, logic.VerifySecondFactorParams{
+		ChallengeId: challengeId,
+		Code:        "abcde-fghjk",
+	})
+	require.ErrorIs(t, err, logic.ErrSecondFactorInvalid)
+
+	output, err = secondFactorLogic.VerifySecondFactor(ctx, logic.VerifySecondFactorParams{
+		ChallengeId: challengeId,
+		Code:        "123456",
+	})
+	require.NoError(t, err)
+	require.False(t, output.IsRecoveryCodeUsed)
+}
@@ -0,0 +1,148 @@
+package logic_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+// Serves an account for any id.
+type stubAnyAccountAccessor struct {
+	database.AccountAccessor
+}
+
+func (s stubAnyAccountAccessor) GetAccount(_ context.Context, id uint64) (database.Account, error) {
+	return database.Account{Id: id, Username: fmt.Sprintf("user%d", id)}, nil
+}
+
+func (s stubAnyAccountAccessor) WithExecutor(_ database.Executor) database.AccountAccessor {
+	return s
+}
+
+// Keeps the TOTP of the accounts in a map so it can be tested without a
+// database.
+type stubAccountTOTPAccessor struct {
+	mu   sync.Mutex
+	rows map[uint64]database.AccountTOTP
+}
+
+func newStubAccountTOTPAccessor() *stubAccountTOTPAccessor {
+	return &stubAccountTOTPAccessor{rows: map[uint64]database.AccountTOTP{}}
+}
+
+func (s *stubAccountTOTPAccessor) CreateAccountTOTP(_ context.Context, at database.AccountTOTP) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rows[at.OfAccountId] = at
+	return nil
+}
+
+func (s *stubAccountTOTPAccessor) GetAccountTOTP(_ context.Context, ofAccountId uint64) (database.AccountTOTP, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	at, ok := s.rows[ofAccountId]
+	if !ok {
+		return database.AccountTOTP{}, sql.ErrNoRows
+	}
+	return at, nil
+}
+
+func (s *stubAccountTOTPAccessor) ConfirmAccountTOTP(_ context.Context, ofAccountId uint64, confirmedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	at := s.rows[ofAccountId]
+	at.ConfirmedAt = &confirmedAt
+	s.rows[ofAccountId] = at
+	return nil
+}
+
+func (s *stubAccountTOTPAccessor) UseAccountTOTPStep(_ context.Context, ofAccountId uint64, step int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	at := s.rows[ofAccountId]
+	at.LastUsedStep = step
+	s.rows[ofAccountId] = at
+	return nil
+}
+
+func (s *stubAccountTOTPAccessor) DeleteAccountTOTP(_ context.Context, ofAccountId uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.rows, ofAccountId)
+	return nil
+}
+
+func (s *stubAccountTOTPAccessor) WithExecutor(_ database.Executor) database.AccountTOTPAccessor {
+	return s
+}
+
+// Computes the current code of the secret an enrollment hands out.
+func currentTOTPCode(t *testing.T, secret string) string {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	require.NoError(t, err)
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(time.Now().Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1_000_000)
+}
+
+func newStubTOTPLogic(t *testing.T, encryptionKey string) (logic.TOTP, *stubAccountTOTPAccessor) {
+	accountTOTPAccessor := newStubAccountTOTPAccessor()
+	totpLogic, err := logic.NewTOTP(newNopTxDB(t), stubAnyAccountAccessor{}, accountTOTPAccessor, nil,
+		configs.TOTP{Issuer: "Fiagram", EncryptionKey: encryptionKey, Skew: 1}, zap.NewNop())
+	require.NoError(t, err)
+	return totpLogic, accountTOTPAccessor
+}
+
+func TestEnrollTOTPWithoutEncryptionKey(t *testing.T) {
+	ctx := context.Background()
+	totpLogic, accountTOTPAccessor := newStubTOTPLogic(t, "")
+
+	_, err := totpLogic.EnrollTOTP(ctx, logic.EnrollTOTPParams{AccountId: 1})
+	require.ErrorIs(t, err, logic.ErrTOTPNotConfigured)
+	require.Empty(t, accountTOTPAccessor.rows)
+}
+
+func TestTOTPSecretBoundToAccount(t *testing.T) {
+	ctx := context.Background()
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	totpLogic, accountTOTPAccessor := newStubTOTPLogic(t, base64.StdEncoding.EncodeToString(key))
+
+	enrolled, err := totpLogic.EnrollTOTP(ctx, logic.EnrollTOTPParams{AccountId: 1})
+	require.NoError(t, err)
+	_, err = totpLogic.EnrollTOTP(ctx, logic.EnrollTOTPParams{AccountId: 2})
+	require.NoError(t, err)
+
+	// The secret of the first account does not open in the row of the second
+	swapped := accountTOTPAccessor.rows[2]
+	swapped.EncryptedSecret = accountTOTPAccessor.rows[1].EncryptedSecret
+	accountTOTPAccessor.rows[2] = swapped
+	code := currentTOTPCode(t, enrolled.Secret)
+	err = totpLogic.ConfirmTOTP(ctx, logic.ConfirmTOTPParams{AccountId: 2, Code: code})
+	requireStatusCode(t, err, codes.Internal)
+
+	require.NoError(t, totpLogic.ConfirmTOTP(ctx, logic.ConfirmTOTPParams{AccountId: 1, Code: code}))
+}
@@ -1,9 +1,18 @@
 package logic_test
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"math/rand"
 	"strings"
+	"testing"
 	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 func RandomString(length uint) string {
@@ -17,3 +26,75 @@ func RandomString(length uint) string {
 	}
 	return strings.TrimSpace(sb.String())
 }
+
+// A driver whose connections only open and close transactions. It backs the
+// *sql.DB of logic under test whose accessors are stubs that ignore the
+// executor, so code running in a transaction can be tested without a database.
+type nopTxDriver struct{}
+
+type nopTxConn struct{}
+
+type nopTx struct{}
+
+func (nopTxDriver) Open(_ string) (driver.Conn, error) { return nopTxConn{}, nil }
+
+func (nopTxConn) Prepare(_ string) (driver.Stmt, error) {
+	return nil, errors.New("nop tx driver runs no statement")
+}
+
+func (nopTxConn) Close() error { return nil }
+
+func (nopTxConn) Begin() (driver.Tx, error) { return nopTx{}, nil }
+
+func (nopTx) Commit() error { return nil }
+
+func (nopTx) Rollback() error { return nil }
+
+func init() {
+	sql.Register("nop_tx", nopTxDriver{})
+}
+
+func newNopTxDB(t testing.TB) *sql.DB {
+	db, err := sql.Open("nop_tx", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func newHashLogic(t testing.TB, hashConfig configs.Hash) logic.Hash {
+	hashLogic, err := logic.NewHash(hashConfig)
+	require.NoError(t, err)
+	return hashLogic
+}
+
+// Hashes with argon2id at a low memory cost, to keep the tests fast.
+func newFastHashLogic(t testing.TB) logic.Hash {
+	hashConfig := argon2idHashConfig()
+	hashConfig.Argon2id.Memory = 8 * 1024
+	return newHashLogic(t, hashConfig)
+}
+
+// Builds an account logic over a database running no statement, with a fast
+// hash, a password policy, a login throttle that never locks and no logging.
+// The overrides set the accessors and whatever else the test needs.
+func newTestAccountLogic(t testing.TB, override func(deps *logic.AccountDependencies)) logic.Account {
+	hashLogic := newFastHashLogic(t)
+	passwordPolicyLogic, err := logic.NewPasswordPolicy(configs.PasswordPolicy{
+		MinLength: 8,
+	}, hashLogic, zap.NewNop())
+	require.NoError(t, err)
+
+	deps := logic.AccountDependencies{
+		DB:                  newNopTxDB(t),
+		HashLogic:           hashLogic,
+		LoginThrottleLogic:  logic.NewLoginThrottle(newStubLoginFailureAccessor(), configs.Lockout{}, zap.NewNop()),
+		PasswordPolicyLogic: passwordPolicyLogic,
+		Logger:              zap.NewNop(),
+	}
+	if override != nil {
+		override(&deps)
+	}
+	return logic.NewAccount(deps)
+}
@@ -0,0 +1,403 @@
+package logic_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Fiagram/account_service/internal/configs"
+	"github.com/Fiagram/account_service/internal/dataaccess/database"
+	"github.com/Fiagram/account_service/internal/logic"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	testRPID   = "localhost"
+	testOrigin = "http://localhost:3000"
+)
+
+// Keeps webauthn credentials in a map, checking sign counts like the database
+// accessor does.
+type stubWebAuthnCredentialAccessor struct {
+	mu     sync.Mutex
+	nextId uint64
+	rows   map[uint64]database.WebAuthnCredential
+}
+
+func newStubWebAuthnCredentialAccessor() *stubWebAuthnCredentialAccessor {
+	return &stubWebAuthnCredentialAccessor{rows: map[uint64]database.WebAuthnCredential{}}
+}
+
+func (s *stubWebAuthnCredentialAccessor) CreateWebAuthnCredential(_ context.Context, wc database.WebAuthnCredential) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextId++
+	wc.Id = s.nextId
+	s.rows[wc.Id] = wc
+	return wc.Id, nil
+}
+
+func (s *stubWebAuthnCredentialAccessor) GetWebAuthnCredentialList(_ context.Context, ofAccountId uint64) ([]database.WebAuthnCredential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []database.WebAuthnCredential
+	for _, wc := range s.rows {
+		if wc.OfAccountId == ofAccountId {
+			out = append(out, wc)
+		}
+	}
+	return out, nil
+}
+
+func (s *stubWebAuthnCredentialAccessor) GetWebAuthnCredentialByCredentialId(_ context.Context, credentialId string) (database.WebAuthnCredential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, wc := range s.rows {
+		if wc.CredentialId == credentialId {
+			return wc, nil
+		}
+	}
+	return database.WebAuthnCredential{}, sql.ErrNoRows
+}
+
+func (s *stubWebAuthnCredentialAccessor) UseWebAuthnCredential(_ context.Context, id uint64, signCount uint32, backupState bool, usedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wc := s.rows[id]
+	if signCount != 0 && signCount <= wc.SignCount {
+		return sql.ErrNoRows
+	}
+	wc.SignCount = signCount
+	wc.BackupState = backupState
+	wc.LastUsedAt = &usedAt
+	s.rows[id] = wc
+	return nil
+}
+
+func (s *stubWebAuthnCredentialAccessor) DeleteWebAuthnCredentialOfAccount(_ context.Context, ofAccountId uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, wc := range s.rows {
+		if wc.OfAccountId == ofAccountId {
+			delete(s.rows, id)
+		}
+	}
+	return nil
+}
+
+func (s *stubWebAuthnCredentialAccessor) WithExecutor(_ database.Executor) database.WebAuthnCredentialAccessor {
+	return s
+}
+
+type stubWebAuthnSessionAccessor struct {
+	mu     sync.Mutex
+	nextId uint64
+	rows   map[uint64]database.WebAuthnSession
+}
+
+func newStubWebAuthnSessionAccessor() *stubWebAuthnSessionAccessor {
+	return &stubWebAuthnSessionAccessor{rows: map[uint64]database.WebAuthnSession{}}
+}
+
+func (s *stubWebAuthnSessionAccessor) CreateWebAuthnSession(_ context.Context, ws database.WebAuthnSession) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextId++
+	ws.Id = s.nextId
+	s.rows[ws.Id] = ws
+	return ws.Id, nil
+}
+
+func (s *stubWebAuthnSessionAccessor) GetWebAuthnSessionByHashedSession(_ context.Context, hashedSession string) (database.WebAuthnSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ws := range s.rows {
+		if ws.HashedSession == hashedSession {
+			return ws, nil
+		}
+	}
+	return database.WebAuthnSession{}, sql.ErrNoRows
+}
+
+func (s *stubWebAuthnSessionAccessor) UseWebAuthnSession(_ context.Context, id uint64, usedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ws := s.rows[id]
+	if ws.UsedAt != nil {
+		return sql.ErrNoRows
+	}
+	ws.UsedAt = &usedAt
+	s.rows[id] = ws
+	return nil
+}
+
+func (s *stubWebAuthnSessionAccessor) DeleteWebAuthnSessionOfAccount(_ context.Context, ofAccountId uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, ws := range s.rows {
+		if ws.OfAccountId == ofAccountId {
+			delete(s.rows, id)
+		}
+	}
+	return nil
+}
+
+func (s *stubWebAuthnSessionAccessor) WithExecutor(_ database.Executor) database.WebAuthnSessionAccessor {
+	return s
+}
+
+// A software authenticator with a P-256 key producing "none" attestations.
+type softAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialId []byte
+	userHandle   []byte
+	signCount    uint32
+}
+
+func newSoftAuthenticator(t *testing.T) *softAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	credentialId := make([]byte, 16)
+	_, err = rand.Read(credentialId)
+	require.NoError(t, err)
+	return &softAuthenticator{key: key, credentialId: credentialId}
+}
+
+// Copies the key, as if the authenticator had been cloned.
+func (a *softAuthenticator) clone() *softAuthenticator {
+	c := *a
+	return &c
+}
+
+func b64url(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func clientDataOf(t *testing.T, ceremony string, challenge string) []byte {
+	clientData, err := json.Marshal(map[string]any{
+		"type":        ceremony,
+		"challenge":   challenge,
+		"origin":      testOrigin,
+		"crossOrigin": false,
+	})
+	require.NoError(t, err)
+	return clientData
+}
+
+func (a *softAuthenticator) authenticatorData(flags byte, attested []byte) []byte {
+	rpIdHash := sha256.Sum256([]byte(testRPID))
+	data := append([]byte{}, rpIdHash[:]...)
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	return append(data, attested...)
+}
+
+// Answers navigator.credentials.create() for the given options.
+func (a *softAuthenticator) register(t *testing.T, options string) string {
+	var creation struct {
+		PublicKey struct {
+			Challenge string `json:"challenge"`
+			User      struct {
+				Id string `json:"id"`
+			} `json:"user"`
+		} `json:"publicKey"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(options), &creation))
+	userHandle, err := base64.RawURLEncoding.DecodeString(creation.PublicKey.User.Id)
+	require.NoError(t, err)
+	a.userHandle = userHandle
+
+	publicKey, err := a.key.PublicKey.ECDH()
+	require.NoError(t, err)
+	point := publicKey.Bytes()
+	coseKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  1,
+		XCoord: point[1:33],
+		YCoord: point[33:],
+	})
+	require.NoError(t, err)
+
+	attested := make([]byte, 16)
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.credentialId)))
+	attested = append(attested, a.credentialId...)
+	attested = append(attested, coseKey...)
+	// User present, user verified and attested credential data included
+	authData := a.authenticatorData(0x45, attested)
+
+	attestationObject, err := webauthncbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": authData,
+	})
+	require.NoError(t, err)
+
+	credential, err := json.Marshal(map[string]any{
+		"id":    b64url(a.credentialId),
+		"rawId": b64url(a.credentialId),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    b64url(clientDataOf(t, "webauthn.create", creation.PublicKey.Challenge)),
+			"attestationObject": b64url(attestationObject),
+			"transports":        []string{"internal"},
+		},
+	})
+	require.NoError(t, err)
+	return string(credential)
+}
+
+// Answers navigator.credentials.get() for the given options.
+func (a *softAuthenticator) assert(t *testing.T, options string) string {
+	var assertion struct {
+		PublicKey struct {
+			Challenge string `json:"challenge"`
+		} `json:"publicKey"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(options), &assertion))
+
+	a.signCount++
+	// User present and user verified
+	authData := a.authenticatorData(0x05, nil)
+	clientData := clientDataOf(t, "webauthn.get", assertion.PublicKey.Challenge)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(bytes.Clone(authData), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	require.NoError(t, err)
+
+	credential, err := json.Marshal(map[string]any{
+		"id":    b64url(a.credentialId),
+		"rawId": b64url(a.credentialId),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    b64url(clientData),
+			"authenticatorData": b64url(authData),
+			"signature":         b64url(signature),
+			"userHandle":        b64url(a.userHandle),
+		},
+	})
+	require.NoError(t, err)
+	return string(credential)
+}
+
+func newStubWebAuthnLogic(t *testing.T) (logic.WebAuthn, database.Account) {
+	acc := database.Account{
+		Id:       7,
+		Username: RandomString(20),
+		Fullname: "Test User",
+		RoleId:   uint8(logic.Member),
+		StatusId: uint8(logic.AccountStatusActive),
+	}
+	webAuthnLogic, err := logic.NewWebAuthn(newNopTxDB(t),
+		stubAccountAccessor{account: acc},
+		newStubWebAuthnCredentialAccessor(),
+		newStubWebAuthnSessionAccessor(),
+		logic.NewLoginThrottle(newStubLoginFailureAccessor(), configs.Lockout{}, zap.NewNop()),
+		configs.WebAuthn{
+			RPID:          testRPID,
+			RPDisplayName: "Fiagram",
+			RPOrigins:     []string{testOrigin},
+			SessionTTL:    time.Minute,
+		},
+		zap.NewNop())
+	require.NoError(t, err)
+	return webAuthnLogic, acc
+}
+
+func registerSoftAuthenticator(t *testing.T, webAuthnLogic logic.WebAuthn, acc database.Account) *softAuthenticator {
+	ctx := context.Background()
+	authenticator := newSoftAuthenticator(t)
+	begin, err := webAuthnLogic.BeginWebAuthnRegistration(ctx, logic.BeginWebAuthnRegistrationParams{
+		AccountId: acc.Id,
+	})
+	require.NoError(t, err)
+
+	finish, err := webAuthnLogic.FinishWebAuthnRegistration(ctx, logic.FinishWebAuthnRegistrationParams{
+		SessionId:  begin.SessionId,
+		Credential: authenticator.register(t, begin.Options),
+	})
+	require.NoError(t, err)
+	require.Equal(t, acc.Id, finish.AccountId)
+	require.Equal(t, b64url(authenticator.credentialId), finish.CredentialId)
+	return authenticator
+}
+
+func TestWebAuthnRegistrationAndAssertion(t *testing.T) {
+	ctx := context.Background()
+	webAuthnLogic, acc := newStubWebAuthnLogic(t)
+	authenticator := registerSoftAuthenticator(t, webAuthnLogic, acc)
+
+	for _, username := range []string{"", acc.Username} {
+		begin, err := webAuthnLogic.BeginWebAuthnAssertion(ctx, logic.BeginWebAuthnAssertionParams{
+			Username: username,
+		})
+		require.NoError(t, err)
+
+		params := logic.FinishWebAuthnAssertionParams{
+			SessionId:  begin.SessionId,
+			Credential: authenticator.assert(t, begin.Options),
+		}
+		finish, err := webAuthnLogic.FinishWebAuthnAssertion(ctx, params)
+		require.NoError(t, err)
+		require.Equal(t, acc.Id, finish.AccountId)
+
+		// A session finishes one ceremony only
+		_, err = webAuthnLogic.FinishWebAuthnAssertion(ctx, params)
+		require.ErrorIs(t, err, logic.ErrWebAuthnSessionInvalid)
+	}
+}
+
+func TestWebAuthnAssertionWrongChallenge(t *testing.T) {
+	ctx := context.Background()
+	webAuthnLogic, acc := newStubWebAuthnLogic(t)
+	authenticator := registerSoftAuthenticator(t, webAuthnLogic, acc)
+
+	first, err := webAuthnLogic.BeginWebAuthnAssertion(ctx, logic.BeginWebAuthnAssertionParams{})
+	require.NoError(t, err)
+	second, err := webAuthnLogic.BeginWebAuthnAssertion(ctx, logic.BeginWebAuthnAssertionParams{})
+	require.NoError(t, err)
+
+	_, err = webAuthnLogic.FinishWebAuthnAssertion(ctx, logic.FinishWebAuthnAssertionParams{
+		SessionId:  second.SessionId,
+		Credential: authenticator.assert(t, first.Options),
+	})
+	require.ErrorIs(t, err, logic.ErrWebAuthnAssertionFailed)
+}
+
+func TestWebAuthnCloneDetected(t *testing.T) {
+	ctx := context.Background()
+	webAuthnLogic, acc := newStubWebAuthnLogic(t)
+	authenticator := registerSoftAuthenticator(t, webAuthnLogic, acc)
+	cloned := authenticator.clone()
+
+	assert := func(a *softAuthenticator) error {
+		begin, err := webAuthnLogic.BeginWebAuthnAssertion(ctx, logic.BeginWebAuthnAssertionParams{})
+		require.NoError(t, err)
+		_, err = webAuthnLogic.FinishWebAuthnAssertion(ctx, logic.FinishWebAuthnAssertionParams{
+			SessionId:  begin.SessionId,
+			Credential: a.assert(t, begin.Options),
+		})
+		return err
+	}
+
+	require.NoError(t, assert(authenticator))
+	require.NoError(t, assert(authenticator))
+	// The clone is behind the original, its counter did not increase
+	require.ErrorIs(t, assert(cloned), logic.ErrWebAuthnCloneDetected)
+	require.NoError(t, assert(authenticator))
+}